@@ -30,4 +30,7 @@ var (
 
 	// PublicFilesPath is the optional directory for hosting public files.
 	PublicFilesPath = filepath.Join(DataDirectory, "public")
+
+	// RecordingsPath is the directory recorded streams are saved to.
+	RecordingsPath = filepath.Join(DataDirectory, "recordings")
 )
@@ -31,12 +31,17 @@ type Defaults struct {
 
 	Tags               []string
 	RTMPServerPort     int
+	SRTServerPort      int
 	SegmentsInPlaylist int
 
 	SegmentLengthSeconds int
 	WebServerPort        int
 
 	ChatEstablishedUserModeTimeDuration time.Duration
+	ChatMessageEditWindowSeconds        int
+	DirectMessageRetentionDays          int
+	AbuseReportThreshold                int
+	ChatRateLimitPolicy                 models.ChatRateLimitPolicy
 
 	YPEnabled bool
 }
@@ -81,8 +86,22 @@ func GetDefaults() Defaults {
 		WebServerPort:  8080,
 		WebServerIP:    "0.0.0.0",
 		RTMPServerPort: 1935,
+		SRTServerPort:  0, // Disabled
 
 		ChatEstablishedUserModeTimeDuration: time.Minute * 15,
+		ChatMessageEditWindowSeconds:        5 * 60,
+		DirectMessageRetentionDays:          30,
+		AbuseReportThreshold:                3,
+		ChatRateLimitPolicy: models.ChatRateLimitPolicy{
+			Anonymous:                models.ChatRateLimit{EventsPerSecond: 1.5, Burst: 1},
+			Authenticated:            models.ChatRateLimit{EventsPerSecond: 1.5, Burst: 3},
+			Moderator:                models.ChatRateLimit{EventsPerSecond: 5, Burst: 10},
+			Bot:                      models.ChatRateLimit{EventsPerSecond: 5, Burst: 10},
+			RejectionSeconds:         10,
+			EscalationThreshold:      3,
+			EscalationWindowSeconds:  5 * 60,
+			EscalationTimeoutSeconds: 5 * 60,
+		},
 
 		StreamVariants: []models.StreamOutputVariant{
 			{
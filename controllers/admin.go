@@ -4,10 +4,11 @@ import (
 	"net/http"
 
 	"github.com/owncast/owncast/core/rtmp"
+	"github.com/owncast/owncast/models"
 )
 
 // DisconnectInboundConnection will force-disconnect an inbound stream.
 func DisconnectInboundConnection(w http.ResponseWriter, r *http.Request) {
-	rtmp.Disconnect()
+	rtmp.Disconnect(models.DefaultChannelID)
 	w.WriteHeader(http.StatusOK)
 }
@@ -0,0 +1,129 @@
+package admin
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/owncast/owncast/controllers"
+	"github.com/owncast/owncast/core"
+	"github.com/owncast/owncast/core/chat"
+	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/core/webhooks"
+	"github.com/owncast/owncast/models"
+)
+
+// SetChannels will set the channels hosted alongside the default stream.
+func SetChannels(w http.ResponseWriter, r *http.Request) {
+	if !requirePOST(w, r) {
+		return
+	}
+
+	type channelsRequest struct {
+		Value []models.Channel `json:"value"`
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	var request channelsRequest
+	if err := decoder.Decode(&request); err != nil {
+		controllers.WriteSimpleResponse(w, false, "unable to update channels with provided values")
+		return
+	}
+
+	ids := map[string]bool{}
+	for i := range request.Value {
+		channel := &request.Value[i]
+		if !models.IsValidChannelID(channel.ID) {
+			controllers.WriteSimpleResponse(w, false, "channel ids must start with a letter and only contain lowercase letters, numbers and dashes")
+			return
+		}
+
+		if ids[channel.ID] {
+			controllers.WriteSimpleResponse(w, false, "channel ids must be unique")
+			return
+		}
+		ids[channel.ID] = true
+
+		channel.Name = strings.TrimSpace(channel.Name)
+		if channel.Name == "" {
+			channel.Name = channel.ID
+		}
+		channel.StreamTitle = strings.TrimSpace(channel.StreamTitle)
+	}
+
+	previousChannels := data.GetChannels()
+
+	if err := data.SetChannels(request.Value); err != nil {
+		controllers.WriteSimpleResponse(w, false, err.Error())
+		return
+	}
+
+	// Let the chat and webhooks of a channel know its title changed.
+	for _, channel := range request.Value {
+		for _, previous := range previousChannels {
+			if previous.ID != channel.ID || previous.StreamTitle == channel.StreamTitle || channel.StreamTitle == "" {
+				continue
+			}
+
+			if err := chat.SendSystemActionToChannel(channel.ID, fmt.Sprintf("Stream title changed to **%s**", channel.StreamTitle), true); err != nil {
+				log.Errorln(err)
+			}
+			go webhooks.SendChannelStreamStatusEvent(channel.ID, models.StreamTitleUpdated)
+		}
+	}
+
+	message := "channels updated"
+	if unavailable := core.GetUnavailableChannelFeatures(); len(request.Value) > 0 && len(unavailable) > 0 {
+		message += ". " + strings.Join(unavailable, ", ") + " are only available to the default channel"
+	}
+
+	controllers.WriteSimpleResponse(w, true, message)
+}
+
+// SetChatRooms will set the chat rooms available alongside the main chat.
+func SetChatRooms(w http.ResponseWriter, r *http.Request) {
+	if !requirePOST(w, r) {
+		return
+	}
+
+	type chatRoomsRequest struct {
+		Value []models.ChatRoom `json:"value"`
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	var request chatRoomsRequest
+	if err := decoder.Decode(&request); err != nil {
+		controllers.WriteSimpleResponse(w, false, "unable to update chat rooms with provided values")
+		return
+	}
+
+	ids := map[string]bool{}
+	for i := range request.Value {
+		room := &request.Value[i]
+		if !models.IsValidChatRoomID(room.ID) {
+			controllers.WriteSimpleResponse(w, false, "chat room ids must start with a letter and only contain lowercase letters, numbers and dashes")
+			return
+		}
+
+		if ids[room.ID] {
+			controllers.WriteSimpleResponse(w, false, "chat room ids must be unique")
+			return
+		}
+		ids[room.ID] = true
+
+		room.Name = strings.TrimSpace(room.Name)
+		if room.Name == "" {
+			room.Name = room.ID
+		}
+	}
+
+	if err := data.SetChatRooms(request.Value); err != nil {
+		controllers.WriteSimpleResponse(w, false, err.Error())
+		return
+	}
+
+	controllers.WriteSimpleResponse(w, true, "chat rooms updated")
+}
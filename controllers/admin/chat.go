@@ -8,12 +8,14 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/owncast/owncast/controllers"
 	"github.com/owncast/owncast/core/chat"
 	"github.com/owncast/owncast/core/chat/events"
 	"github.com/owncast/owncast/core/data"
 	"github.com/owncast/owncast/core/user"
+	"github.com/owncast/owncast/models"
 	"github.com/owncast/owncast/utils"
 	log "github.com/sirupsen/logrus"
 )
@@ -59,13 +61,25 @@ func BanIPAddress(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	configValue, success := getValueFromRequest(w, r)
-	if !success {
+	type banIPAddressRequest struct {
+		Value string `json:"value"`
+		// Duration is the number of seconds the ban lasts. Zero bans permanently.
+		Duration int `json:"duration,omitempty"`
+	}
+
+	var request banIPAddressRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil || request.Value == "" || request.Duration < 0 {
 		controllers.WriteSimpleResponse(w, false, "unable to ban IP address")
 		return
 	}
 
-	if err := data.BanIPAddress(configValue.Value.(string), "manually added"); err != nil {
+	var expiresAt *time.Time
+	if request.Duration > 0 {
+		expiry := time.Now().Add(time.Duration(request.Duration) * time.Second)
+		expiresAt = &expiry
+	}
+
+	if err := data.BanIPAddress(request.Value, "manually added", "", expiresAt); err != nil {
 		controllers.WriteSimpleResponse(w, false, "error saving IP address ban")
 		return
 	}
@@ -107,8 +121,11 @@ func GetIPAddressBans(w http.ResponseWriter, r *http.Request) {
 // UpdateUserEnabled enable or disable a single user by ID.
 func UpdateUserEnabled(w http.ResponseWriter, r *http.Request) {
 	type blockUserRequest struct {
-		UserID  string `json:"userId"`
-		Enabled bool   `json:"enabled"`
+		UserID string `json:"userId"`
+		// Duration is the number of seconds a user is disabled for. Zero
+		// disables the user permanently.
+		Duration int  `json:"duration,omitempty"`
+		Enabled  bool `json:"enabled"`
 	}
 
 	if r.Method != controllers.POST {
@@ -130,54 +147,22 @@ func UpdateUserEnabled(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Disable/enable the user
-	if err := user.SetEnabled(request.UserID, request.Enabled); err != nil {
-		log.Errorln("error changing user enabled status", err)
-		controllers.WriteSimpleResponse(w, false, err.Error())
+	if request.Duration < 0 {
+		controllers.WriteSimpleResponse(w, false, "duration must not be negative")
 		return
 	}
 
-	// Hide/show the user's chat messages if disabling.
-	// Leave hidden messages hidden to be safe.
-	if !request.Enabled {
-		if err := chat.SetMessageVisibilityForUserID(request.UserID, request.Enabled); err != nil {
-			log.Errorln("error changing user messages visibility", err)
-			controllers.WriteSimpleResponse(w, false, err.Error())
-			return
-		}
-	}
-
-	// Forcefully disconnect the user from the chat
+	// Disable/enable the user
 	if !request.Enabled {
-		clients, err := chat.GetClientsForUser(request.UserID)
-		if len(clients) == 0 {
-			// Nothing to do
-			return
-		}
-
-		if err != nil {
-			log.Errorln("error fetching clients for user: ", err)
+		if err := chat.DisableUser(request.UserID, time.Duration(request.Duration)*time.Second); err != nil {
+			log.Errorln("error disabling user", err)
 			controllers.WriteSimpleResponse(w, false, err.Error())
 			return
 		}
-
-		chat.DisconnectClients(clients)
-		disconnectedUser := user.GetUserByID(request.UserID)
-		_ = chat.SendSystemAction(fmt.Sprintf("**%s** has been removed from chat.", disconnectedUser.DisplayName), true)
-
-		localIP4Address := "127.0.0.1"
-		localIP6Address := "::1"
-
-		// Ban this user's IP address.
-		for _, client := range clients {
-			ipAddress := client.IPAddress
-			if ipAddress != localIP4Address && ipAddress != localIP6Address {
-				reason := fmt.Sprintf("Banning of %s", disconnectedUser.DisplayName)
-				if err := data.BanIPAddress(ipAddress, reason); err != nil {
-					log.Errorln("error banning IP address: ", err)
-				}
-			}
-		}
+	} else if err := user.SetEnabled(request.UserID, true); err != nil {
+		log.Errorln("error changing user enabled status", err)
+		controllers.WriteSimpleResponse(w, false, err.Error())
+		return
 	}
 
 	controllers.WriteSimpleResponse(w, true, fmt.Sprintf("%s enabled: %t", request.UserID, request.Enabled))
@@ -300,11 +285,31 @@ func SendIntegrationChatMessage(integration user.ExternalAPIUser, w http.Respons
 		return
 	}
 
+	if !chat.AllowIntegrationMessage(integration.ID) {
+		w.WriteHeader(http.StatusTooManyRequests)
+		controllers.InternalErrorHandler(w, errors.New("chat rate limit exceeded"))
+		return
+	}
+
 	var event events.UserMessageEvent
 	if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
 		controllers.InternalErrorHandler(w, err)
 		return
 	}
+
+	event.User = getIntegrationChatUser(integration)
+
+	if err := chat.ValidateChatRoom(event.ChannelID, event.RoomID, event.User); err != nil {
+		controllers.BadRequestHandler(w, err)
+		return
+	}
+
+	// Slash commands are run and their replies returned instead of being sent to chat.
+	if reply, isCommand := chat.RunIntegrationCommand(integration, event.Body, event.ChannelID, event.RoomID); isCommand {
+		controllers.WriteSimpleResponse(w, true, reply)
+		return
+	}
+
 	event.SetDefaults()
 	event.RenderBody()
 	event.Type = "CHAT"
@@ -314,15 +319,7 @@ func SendIntegrationChatMessage(integration user.ExternalAPIUser, w http.Respons
 		return
 	}
 
-	event.User = &user.User{
-		ID:           integration.ID,
-		DisplayName:  name,
-		DisplayColor: integration.DisplayColor,
-		CreatedAt:    integration.CreatedAt,
-		IsBot:        true,
-	}
-
-	if err := chat.Broadcast(&event); err != nil {
+	if err := chat.BroadcastToChatRoom(&event, event.ChannelID, event.RoomID); err != nil {
 		controllers.BadRequestHandler(w, err)
 		return
 	}
@@ -336,16 +333,25 @@ func SendIntegrationChatMessage(integration user.ExternalAPIUser, w http.Respons
 func SendChatAction(integration user.ExternalAPIUser, w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 
-	var message events.SystemActionEvent
+	var message struct {
+		events.SystemActionEvent
+		ChannelID string `json:"channelId"`
+		RoomID    string `json:"roomId"`
+	}
 	if err := json.NewDecoder(r.Body).Decode(&message); err != nil {
 		controllers.InternalErrorHandler(w, err)
 		return
 	}
 
+	if err := chat.ValidateChatRoom(message.ChannelID, message.RoomID, getIntegrationChatUser(integration)); err != nil {
+		controllers.BadRequestHandler(w, err)
+		return
+	}
+
 	message.SetDefaults()
 	message.RenderBody()
 
-	if err := chat.SendSystemAction(message.Body, false); err != nil {
+	if err := chat.SendSystemActionToChatRoom(message.ChannelID, message.RoomID, message.Body, false); err != nil {
 		controllers.BadRequestHandler(w, err)
 		return
 	}
@@ -353,6 +359,18 @@ func SendChatAction(integration user.ExternalAPIUser, w http.ResponseWriter, r *
 	controllers.WriteSimpleResponse(w, true, "sent")
 }
 
+// getIntegrationChatUser will return the chat user an external chat
+// integration sends messages as.
+func getIntegrationChatUser(integration user.ExternalAPIUser) *user.User {
+	return &user.User{
+		ID:           integration.ID,
+		DisplayName:  integration.DisplayName,
+		DisplayColor: integration.DisplayColor,
+		CreatedAt:    integration.CreatedAt,
+		IsBot:        true,
+	}
+}
+
 // SetEnableEstablishedChatUserMode sets the requirement for a chat user
 // to be "established" for some time before taking part in chat.
 func SetEnableEstablishedChatUserMode(w http.ResponseWriter, r *http.Request) {
@@ -373,3 +391,230 @@ func SetEnableEstablishedChatUserMode(w http.ResponseWriter, r *http.Request) {
 
 	controllers.WriteSimpleResponse(w, true, "chat established users only mode updated")
 }
+
+// SetChatModes will change one or more of the chat modes. Modes not included
+// in the request are left as they are.
+func SetChatModes(w http.ResponseWriter, r *http.Request) {
+	if !requirePOST(w, r) {
+		return
+	}
+
+	type chatModesRequest struct {
+		SlowModeSeconds   *int  `json:"slowModeSeconds"`
+		AuthenticatedOnly *bool `json:"authenticatedOnly"`
+		EmoteOnly         *bool `json:"emoteOnly"`
+	}
+
+	var request chatModesRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		controllers.WriteSimpleResponse(w, false, "unable to update chat modes with provided values")
+		return
+	}
+
+	modes := data.GetChatModes()
+	if request.SlowModeSeconds != nil {
+		modes.SlowModeSeconds = *request.SlowModeSeconds
+	}
+	if request.AuthenticatedOnly != nil {
+		modes.AuthenticatedOnly = *request.AuthenticatedOnly
+	}
+	if request.EmoteOnly != nil {
+		modes.EmoteOnly = *request.EmoteOnly
+	}
+
+	if err := chat.SetChatModes(modes); err != nil {
+		controllers.WriteSimpleResponse(w, false, err.Error())
+		return
+	}
+
+	controllers.WriteSimpleResponse(w, true, "chat modes updated")
+}
+
+// SetAutomodRules will replace the automatic chat moderation rules.
+func SetAutomodRules(w http.ResponseWriter, r *http.Request) {
+	if !requirePOST(w, r) {
+		return
+	}
+
+	type automodRulesRequest struct {
+		Value []models.AutomodRule `json:"value"`
+	}
+
+	var request automodRulesRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		controllers.WriteSimpleResponse(w, false, "unable to update automod rules with provided values")
+		return
+	}
+
+	rules, err := chat.SetAutomodRules(request.Value)
+	if err != nil {
+		controllers.WriteSimpleResponse(w, false, err.Error())
+		return
+	}
+
+	controllers.WriteResponse(w, rules)
+}
+
+// GetModerationLog will return the most recent moderation actions taken on
+// chat messages.
+func GetModerationLog(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	limit := 100
+	if value := r.URL.Query().Get("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed <= 0 {
+			controllers.WriteSimpleResponse(w, false, "limit must be a positive number")
+			return
+		}
+		limit = parsed
+	}
+
+	controllers.WriteResponse(w, chat.GetModerationLog(limit))
+}
+
+// GetDirectMessages will return the most recent direct messages sent or
+// received by a user, optionally only those with one other user.
+func GetDirectMessages(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	userID := r.URL.Query().Get("userId")
+	if userID == "" {
+		controllers.WriteSimpleResponse(w, false, "a user id is required")
+		return
+	}
+
+	limit := 100
+	if value := r.URL.Query().Get("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed <= 0 {
+			controllers.WriteSimpleResponse(w, false, "limit must be a positive number")
+			return
+		}
+		limit = parsed
+	}
+
+	controllers.WriteResponse(w, chat.GetDirectMessages(userID, r.URL.Query().Get("otherUserId"), limit))
+}
+
+// GetAbuseReports will return the most recent abuse reports, optionally only
+// those with a status.
+func GetAbuseReports(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	status := r.URL.Query().Get("status")
+	if status != "" && !models.IsValidAbuseReportStatus(status) {
+		controllers.WriteSimpleResponse(w, false, status+" is not a valid abuse report status")
+		return
+	}
+
+	limit := 100
+	if value := r.URL.Query().Get("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed <= 0 {
+			controllers.WriteSimpleResponse(w, false, "limit must be a positive number")
+			return
+		}
+		limit = parsed
+	}
+
+	controllers.WriteResponse(w, chat.GetAbuseReports(status, limit))
+}
+
+// SetAbuseReportStatus will mark an abuse report as open, resolved or
+// dismissed.
+func SetAbuseReportStatus(w http.ResponseWriter, r *http.Request) {
+	if !requirePOST(w, r) {
+		return
+	}
+
+	type setAbuseReportStatusRequest struct {
+		Status string `json:"status"`
+		ID     int    `json:"id"`
+	}
+
+	var request setAbuseReportStatusRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil || request.ID == 0 {
+		controllers.WriteSimpleResponse(w, false, "an abuse report id and status are required")
+		return
+	}
+
+	// Moderators are recorded by their user ID, the admin as admin.
+	resolvedBy := "admin"
+	if moderator := user.GetUserByToken(r.URL.Query().Get("accessToken")); moderator != nil {
+		resolvedBy = "moderator:" + moderator.ID
+	}
+
+	if err := chat.SetAbuseReportStatus(request.ID, request.Status, resolvedBy); err != nil {
+		controllers.WriteSimpleResponse(w, false, err.Error())
+		return
+	}
+
+	controllers.WriteSimpleResponse(w, true, "abuse report status updated")
+}
+
+// SetAbuseReportConfig will set when and where moderators are alerted about
+// reported chat messages.
+func SetAbuseReportConfig(w http.ResponseWriter, r *http.Request) {
+	if !requirePOST(w, r) {
+		return
+	}
+
+	var reportConfig models.AbuseReportConfig
+	if err := json.NewDecoder(r.Body).Decode(&reportConfig); err != nil {
+		controllers.WriteSimpleResponse(w, false, "unable to update abuse report alerts "+err.Error())
+		return
+	}
+
+	if reportConfig.Threshold < 0 {
+		controllers.WriteSimpleResponse(w, false, "the report threshold cannot be negative")
+		return
+	}
+
+	if reportConfig.DiscordWebhook != "" && !utils.IsValidURL(reportConfig.DiscordWebhook) {
+		controllers.WriteSimpleResponse(w, false, "the discord webhook must be a valid url")
+		return
+	}
+
+	if err := data.SetAbuseReportConfig(reportConfig); err != nil {
+		controllers.WriteSimpleResponse(w, false, err.Error())
+		return
+	}
+
+	controllers.WriteSimpleResponse(w, true, "abuse report alerts updated")
+}
+
+// SetChatRateLimitPolicy will set the chat rate limits of each role of user
+// and how users that keep exceeding them are timed out.
+func SetChatRateLimitPolicy(w http.ResponseWriter, r *http.Request) {
+	if !requirePOST(w, r) {
+		return
+	}
+
+	var policy models.ChatRateLimitPolicy
+	if err := json.NewDecoder(r.Body).Decode(&policy); err != nil {
+		controllers.WriteSimpleResponse(w, false, "unable to update chat rate limits "+err.Error())
+		return
+	}
+
+	for _, limit := range []models.ChatRateLimit{policy.Anonymous, policy.Authenticated, policy.Moderator, policy.Bot} {
+		if limit.EventsPerSecond < 0 || limit.Burst < 0 {
+			controllers.WriteSimpleResponse(w, false, "chat rate limits cannot be negative")
+			return
+		}
+	}
+
+	if policy.RejectionSeconds < 0 || policy.EscalationThreshold < 0 || policy.EscalationWindowSeconds < 0 || policy.EscalationTimeoutSeconds < 0 {
+		controllers.WriteSimpleResponse(w, false, "chat rate limit timeouts cannot be negative")
+		return
+	}
+
+	if err := data.SetChatRateLimitPolicy(policy); err != nil {
+		controllers.WriteSimpleResponse(w, false, err.Error())
+		return
+	}
+
+	chat.ApplyChatRateLimitPolicy()
+
+	controllers.WriteSimpleResponse(w, true, "chat rate limits updated")
+}
@@ -0,0 +1,164 @@
+package admin
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/owncast/owncast/controllers"
+	"github.com/owncast/owncast/core/chat"
+	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/core/recording"
+	"github.com/owncast/owncast/models"
+	log "github.com/sirupsen/logrus"
+)
+
+// SetChatArchiveConfig will enable or disable the long-term chat archive and
+// set how long it is kept for.
+func SetChatArchiveConfig(w http.ResponseWriter, r *http.Request) {
+	if !requirePOST(w, r) {
+		return
+	}
+
+	var archiveConfig models.ChatArchiveConfig
+	if err := json.NewDecoder(r.Body).Decode(&archiveConfig); err != nil {
+		controllers.WriteSimpleResponse(w, false, "unable to update chat archive configuration "+err.Error())
+		return
+	}
+
+	if archiveConfig.RetentionDays < 0 {
+		controllers.WriteSimpleResponse(w, false, "chat archive retention cannot be negative")
+		return
+	}
+
+	if err := data.SetChatArchiveConfig(archiveConfig); err != nil {
+		controllers.WriteSimpleResponse(w, false, err.Error())
+		return
+	}
+
+	controllers.WriteSimpleResponse(w, true, "chat archive configuration updated")
+}
+
+// SearchChatArchive will return the archived chat events matching a search.
+func SearchChatArchive(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+
+	query, err := getChatArchiveQuery(r)
+	if err != nil {
+		controllers.WriteSimpleResponse(w, false, err.Error())
+		return
+	}
+
+	if value := r.URL.Query().Get("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit <= 0 {
+			controllers.WriteSimpleResponse(w, false, "limit must be a positive number")
+			return
+		}
+		query.Limit = limit
+	}
+
+	entries, err := chat.SearchChatArchive(query)
+	if err != nil {
+		log.Debugln("error searching chat archive", err)
+		controllers.WriteSimpleResponse(w, false, "unable to search the chat archive. check the search text is valid")
+		return
+	}
+
+	controllers.WriteResponse(w, entries)
+}
+
+// ExportChatArchive will download the archived chat of a recorded stream, or
+// of a time range, as JSON, CSV or WebVTT subtitles.
+func ExportChatArchive(w http.ResponseWriter, r *http.Request) {
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = chat.ChatExportJSON
+	}
+	if !chat.IsValidChatExportFormat(format) {
+		controllers.WriteSimpleResponse(w, false, format+" is not a valid chat export format")
+		return
+	}
+
+	query, err := getChatArchiveQuery(r)
+	if err != nil {
+		controllers.WriteSimpleResponse(w, false, err.Error())
+		return
+	}
+
+	// The stream start is the beginning of the recording when exporting a
+	// recorded stream, otherwise the beginning of the time range.
+	var streamStart time.Time
+	if recordingID := r.URL.Query().Get("recording"); recordingID != "" {
+		rec, err := recording.GetRecording(recordingID)
+		if err != nil || rec == nil {
+			controllers.WriteSimpleResponse(w, false, "recording not found")
+			return
+		}
+
+		end := time.Now()
+		if rec.EndedAt != nil {
+			end = *rec.EndedAt
+		}
+		channelID := models.DefaultChannelID
+		query.From, query.To, query.ChannelID = &rec.StartedAt, &end, &channelID
+		streamStart = rec.StartedAt
+	} else if query.From != nil {
+		streamStart = *query.From
+	} else {
+		controllers.WriteSimpleResponse(w, false, "a recording or a from time is required")
+		return
+	}
+
+	contentTypes := map[string]string{
+		chat.ChatExportJSON:   "application/json",
+		chat.ChatExportCSV:    "text/csv",
+		chat.ChatExportWebVTT: "text/vtt",
+	}
+	w.Header().Set("Content-Type", contentTypes[format])
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"chat-%s.%s\"", streamStart.Format("20060102-150405"), format))
+
+	if err := chat.ExportChatArchive(w, query, format, streamStart); err != nil {
+		log.Errorln("error exporting chat archive", err)
+	}
+}
+
+// getChatArchiveQuery will return the chat archive filters of a request.
+func getChatArchiveQuery(r *http.Request) (models.ChatArchiveQuery, error) {
+	values := r.URL.Query()
+
+	query := models.ChatArchiveQuery{
+		Text:           values.Get("q"),
+		UserID:         values.Get("userId"),
+		Type:           values.Get("type"),
+		IncludeRemoved: values.Get("includeRemoved") == "true",
+	}
+
+	if values.Has("channel") {
+		channelID := values.Get("channel")
+		query.ChannelID = &channelID
+	}
+
+	// Other chat rooms, such as those only for moderators, are only
+	// included when asked for.
+	roomID := values.Get("room")
+	query.RoomID = &roomID
+
+	for name, target := range map[string]**time.Time{"from": &query.From, "to": &query.To} {
+		value := values.Get(name)
+		if value == "" {
+			continue
+		}
+
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return query, errors.New(name + " must be an RFC 3339 time")
+		}
+		*target = &parsed
+	}
+
+	return query, nil
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/owncast/owncast/controllers"
 	"github.com/owncast/owncast/core/chat"
 	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/core/transcoder"
 	"github.com/owncast/owncast/core/user"
 	"github.com/owncast/owncast/core/webhooks"
 	"github.com/owncast/owncast/models"
@@ -76,7 +77,9 @@ func SetStreamTitle(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	if value != "" {
-		sendSystemChatAction(fmt.Sprintf("Stream title changed to **%s**", value), true)
+		if err := chat.SendSystemActionToChannel(models.DefaultChannelID, fmt.Sprintf("Stream title changed to **%s**", value), true); err != nil {
+			log.Errorln(err)
+		}
 		go webhooks.SendStreamStatusEvent(models.StreamTitleUpdated)
 	}
 	controllers.WriteSimpleResponse(w, true, "changed")
@@ -384,6 +387,25 @@ func SetRTMPServerPort(w http.ResponseWriter, r *http.Request) {
 	controllers.WriteSimpleResponse(w, true, "rtmp port set")
 }
 
+// SetSRTServerPort will handle the web config request to set the inbound SRT port.
+func SetSRTServerPort(w http.ResponseWriter, r *http.Request) {
+	if !requirePOST(w, r) {
+		return
+	}
+
+	configValue, success := getValueFromRequest(w, r)
+	if !success {
+		return
+	}
+
+	if err := data.SetSRTPortNumber(configValue.Value.(float64)); err != nil {
+		controllers.WriteSimpleResponse(w, false, err.Error())
+		return
+	}
+
+	controllers.WriteSimpleResponse(w, true, "srt port set")
+}
+
 // SetServerURL will handle the web config request to set the full server URL.
 func SetServerURL(w http.ResponseWriter, r *http.Request) {
 	if !requirePOST(w, r) {
@@ -474,7 +496,13 @@ func SetStreamLatencyLevel(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := data.SetStreamLatencyLevel(configValue.Value.(float64)); err != nil {
+	level := configValue.Value.(float64)
+	if int(level) == models.LowLatencyHLSLevel && data.GetS3Config().Enabled {
+		controllers.WriteSimpleResponse(w, false, "low latency HLS is not available when using external storage")
+		return
+	}
+
+	if err := data.SetStreamLatencyLevel(level); err != nil {
 		controllers.WriteSimpleResponse(w, false, "error setting stream latency "+err.Error())
 		return
 	}
@@ -528,6 +556,42 @@ func SetS3Configuration(w http.ResponseWriter, r *http.Request) {
 	controllers.WriteSimpleResponse(w, true, "storage configuration changed")
 }
 
+// SetRecordingConfiguration will handle the web config request to set the stream recording configuration.
+func SetRecordingConfiguration(w http.ResponseWriter, r *http.Request) {
+	if !requirePOST(w, r) {
+		return
+	}
+
+	type recordingConfigurationRequest struct {
+		Value models.RecordingConfig `json:"value"`
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	var newRecordingConfig recordingConfigurationRequest
+	if err := decoder.Decode(&newRecordingConfig); err != nil {
+		controllers.WriteSimpleResponse(w, false, "unable to update recording config with provided values")
+		return
+	}
+
+	variantCount := len(data.GetStreamOutputVariants())
+	if newRecordingConfig.Value.VariantIndex < 0 || newRecordingConfig.Value.VariantIndex >= variantCount {
+		controllers.WriteSimpleResponse(w, false, "recording requires a valid stream output variant")
+		return
+	}
+
+	if visibility := newRecordingConfig.Value.DefaultVisibility; visibility != "" && !models.IsValidRecordingVisibility(visibility) {
+		controllers.WriteSimpleResponse(w, false, "default visibility must be one of public, unlisted or private")
+		return
+	}
+
+	if err := data.SetRecordingConfig(newRecordingConfig.Value); err != nil {
+		controllers.WriteSimpleResponse(w, false, err.Error())
+		return
+	}
+
+	controllers.WriteSimpleResponse(w, true, "recording configuration changed")
+}
+
 // SetStreamOutputVariants will handle the web config request to set the video output stream variants.
 func SetStreamOutputVariants(w http.ResponseWriter, r *http.Request) {
 	if !requirePOST(w, r) {
@@ -545,6 +609,12 @@ func SetStreamOutputVariants(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	availableCodecs := transcoder.GetCodecs(utils.ValidatedFfmpegPath(data.GetFfMpegPath()))
+	if err := transcoder.ValidateVariantCodecs(videoVariants.Value, data.GetVideoCodec(), availableCodecs); err != nil {
+		controllers.WriteSimpleResponse(w, false, err.Error())
+		return
+	}
+
 	if err := data.SetStreamOutputVariants(videoVariants.Value); err != nil {
 		controllers.WriteSimpleResponse(w, false, "unable to update video config with provided values "+err.Error())
 		return
@@ -616,7 +686,14 @@ func SetVideoCodec(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := data.SetVideoCodec(configValue.Value.(string)); err != nil {
+	codec := configValue.Value.(string)
+	availableCodecs := transcoder.GetCodecs(utils.ValidatedFfmpegPath(data.GetFfMpegPath()))
+	if err := transcoder.ValidateVariantCodecs(data.GetStreamOutputVariants(), codec, availableCodecs); err != nil {
+		controllers.WriteSimpleResponse(w, false, err.Error())
+		return
+	}
+
+	if err := data.SetVideoCodec(codec); err != nil {
 		controllers.WriteSimpleResponse(w, false, "unable to update codec")
 		return
 	}
@@ -624,6 +701,32 @@ func SetVideoCodec(w http.ResponseWriter, r *http.Request) {
 	controllers.WriteSimpleResponse(w, true, "video codec updated")
 }
 
+// SetSegmentFormat will change the container format of video segments.
+func SetSegmentFormat(w http.ResponseWriter, r *http.Request) {
+	if !requirePOST(w, r) {
+		return
+	}
+
+	configValue, success := getValueFromRequest(w, r)
+	if !success {
+		controllers.WriteSimpleResponse(w, false, "unable to change segment format")
+		return
+	}
+
+	format, ok := configValue.Value.(string)
+	if !ok {
+		controllers.WriteSimpleResponse(w, false, "segment format must be a string")
+		return
+	}
+
+	if err := data.SetSegmentFormat(format); err != nil {
+		controllers.WriteSimpleResponse(w, false, err.Error())
+		return
+	}
+
+	controllers.WriteSimpleResponse(w, true, "segment format updated")
+}
+
 // SetExternalActions will set the 3rd party actions for the web interface.
 func SetExternalActions(w http.ResponseWriter, r *http.Request) {
 	type externalActionsRequest struct {
@@ -740,6 +843,60 @@ func SetChatJoinMessagesEnabled(w http.ResponseWriter, r *http.Request) {
 	controllers.WriteSimpleResponse(w, true, "chat join message status updated")
 }
 
+// SetChatMessageEditWindow will set the number of seconds users can edit and
+// delete their own chat messages for.
+func SetChatMessageEditWindow(w http.ResponseWriter, r *http.Request) {
+	if !requirePOST(w, r) {
+		return
+	}
+
+	configValue, success := getValueFromRequest(w, r)
+	if !success {
+		controllers.WriteSimpleResponse(w, false, "unable to update chat message edit window")
+		return
+	}
+
+	seconds, ok := configValue.Value.(float64)
+	if !ok || seconds < 0 {
+		controllers.WriteSimpleResponse(w, false, "chat message edit window must be a number of seconds")
+		return
+	}
+
+	if err := data.SetChatMessageEditWindow(seconds); err != nil {
+		controllers.WriteSimpleResponse(w, false, err.Error())
+		return
+	}
+
+	controllers.WriteSimpleResponse(w, true, "chat message edit window updated")
+}
+
+// SetDirectMessageRetention will set the number of days direct messages
+// between users are kept for.
+func SetDirectMessageRetention(w http.ResponseWriter, r *http.Request) {
+	if !requirePOST(w, r) {
+		return
+	}
+
+	configValue, success := getValueFromRequest(w, r)
+	if !success {
+		controllers.WriteSimpleResponse(w, false, "unable to update direct message retention")
+		return
+	}
+
+	days, ok := configValue.Value.(float64)
+	if !ok || days < 0 {
+		controllers.WriteSimpleResponse(w, false, "direct message retention must be a number of days")
+		return
+	}
+
+	if err := data.SetDirectMessageRetentionDays(days); err != nil {
+		controllers.WriteSimpleResponse(w, false, err.Error())
+		return
+	}
+
+	controllers.WriteSimpleResponse(w, true, "direct message retention updated")
+}
+
 // SetHideViewerCount will enable or disable hiding the viewer count.
 func SetHideViewerCount(w http.ResponseWriter, r *http.Request) {
 	if !requirePOST(w, r) {
@@ -869,6 +1026,11 @@ func SetStreamKeys(w http.ResponseWriter, r *http.Request) {
 			controllers.WriteSimpleResponse(w, false, "stream key cannot be empty")
 			return
 		}
+
+		if _, exists := data.GetChannel(streamKey.Channel); streamKey.Channel != models.DefaultChannelID && !exists {
+			controllers.WriteSimpleResponse(w, false, "stream key is assigned to a channel that does not exist")
+			return
+		}
 	}
 
 	if err := data.SetStreamKeys(streamKeys.Value); err != nil {
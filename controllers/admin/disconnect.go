@@ -4,18 +4,18 @@ import (
 	"net/http"
 
 	"github.com/owncast/owncast/controllers"
-	"github.com/owncast/owncast/core"
 
 	"github.com/owncast/owncast/core/rtmp"
 )
 
-// DisconnectInboundConnection will force-disconnect an inbound stream.
+// DisconnectInboundConnection will force-disconnect the inbound stream of a channel.
 func DisconnectInboundConnection(w http.ResponseWriter, r *http.Request) {
-	if !core.GetStatus().Online {
+	channelID := r.URL.Query().Get("channel")
+	if !rtmp.IsChannelConnected(channelID) {
 		controllers.WriteSimpleResponse(w, false, "no inbound stream connected")
 		return
 	}
 
-	rtmp.Disconnect()
+	rtmp.Disconnect(channelID)
 	controllers.WriteSimpleResponse(w, true, "inbound stream disconnected")
 }
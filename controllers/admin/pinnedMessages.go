@@ -0,0 +1,91 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/owncast/owncast/controllers"
+	"github.com/owncast/owncast/core/chat"
+	"github.com/owncast/owncast/core/user"
+	"github.com/owncast/owncast/models"
+)
+
+// PinChatMessage will pin a chat message or an announcement to the top of
+// chat.
+func PinChatMessage(w http.ResponseWriter, r *http.Request) {
+	// Moderators are recorded by their user ID, the admin as admin.
+	pinnedBy := "admin"
+	if moderator := user.GetUserByToken(r.URL.Query().Get("accessToken")); moderator != nil {
+		pinnedBy = "moderator:" + moderator.ID
+	}
+
+	pinChatMessage(w, r, pinnedBy)
+}
+
+// ExternalPinChatMessage will pin a chat message or an announcement to the
+// top of chat on behalf of an external integration.
+func ExternalPinChatMessage(integration user.ExternalAPIUser, w http.ResponseWriter, r *http.Request) {
+	pinChatMessage(w, r, "integration:"+integration.ID)
+}
+
+func pinChatMessage(w http.ResponseWriter, r *http.Request, pinnedBy string) {
+	if !requirePOST(w, r) {
+		return
+	}
+
+	type pinRequest struct {
+		MessageID string                   `json:"messageId"`
+		Body      string                   `json:"body"`
+		Style     models.AnnouncementStyle `json:"style"`
+		ChannelID string                   `json:"channelId"`
+	}
+
+	var request pinRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		controllers.WriteSimpleResponse(w, false, "a message id or announcement body is required")
+		return
+	}
+
+	var err error
+	if request.MessageID != "" {
+		err = chat.PinMessage(request.MessageID, pinnedBy)
+	} else {
+		err = chat.PinAnnouncement(request.ChannelID, request.Body, request.Style, pinnedBy)
+	}
+	if err != nil {
+		controllers.WriteSimpleResponse(w, false, err.Error())
+		return
+	}
+
+	controllers.WriteSimpleResponse(w, true, "pinned")
+}
+
+// UnpinChatMessage will remove the message pinned to the top of chat.
+func UnpinChatMessage(w http.ResponseWriter, r *http.Request) {
+	if !requirePOST(w, r) {
+		return
+	}
+
+	type unpinRequest struct {
+		ChannelID string `json:"channelId"`
+	}
+
+	var request unpinRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		controllers.WriteSimpleResponse(w, false, "unable to unpin "+err.Error())
+		return
+	}
+
+	if err := chat.UnpinMessage(request.ChannelID); err != nil {
+		controllers.WriteSimpleResponse(w, false, err.Error())
+		return
+	}
+
+	controllers.WriteSimpleResponse(w, true, "unpinned")
+}
+
+// ExternalUnpinChatMessage will remove the message pinned to the top of chat
+// on behalf of an external integration.
+func ExternalUnpinChatMessage(integration user.ExternalAPIUser, w http.ResponseWriter, r *http.Request) {
+	UnpinChatMessage(w, r)
+}
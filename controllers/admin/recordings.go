@@ -0,0 +1,138 @@
+package admin
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/owncast/owncast/controllers"
+	"github.com/owncast/owncast/core/recording"
+	"github.com/owncast/owncast/models"
+	"github.com/owncast/owncast/utils"
+	log "github.com/sirupsen/logrus"
+)
+
+type recordingRequest struct {
+	ID string `json:"id"`
+}
+
+type recordingVisibilityRequest struct {
+	ID         string                     `json:"id"`
+	Visibility models.RecordingVisibility `json:"visibility"`
+}
+
+// GetRecordings will return all stream recordings.
+func GetRecordings(w http.ResponseWriter, r *http.Request) {
+	recordings, err := recording.GetRecordings()
+	if err != nil {
+		controllers.InternalErrorHandler(w, err)
+		return
+	}
+
+	controllers.WriteResponse(w, recordings)
+}
+
+// DownloadRecording will return the video of a single recording as a
+// single MPEG-TS or fragmented MP4 file.
+func DownloadRecording(w http.ResponseWriter, r *http.Request) {
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		controllers.BadRequestHandler(w, errors.New("recording id is required"))
+		return
+	}
+
+	if _, err := recording.GetRecording(id); err != nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	segments, err := recording.GetRecordingSegmentPaths(id)
+	if err != nil {
+		controllers.InternalErrorHandler(w, err)
+		return
+	}
+
+	if len(segments) > 0 && utils.IsHLSInitSegment(segments[0]) {
+		w.Header().Set("Content-Type", "video/mp4")
+		w.Header().Set("Content-Disposition", `attachment; filename="`+id+`.mp4"`)
+	} else {
+		w.Header().Set("Content-Type", "video/mp2t")
+		w.Header().Set("Content-Disposition", `attachment; filename="`+id+`.ts"`)
+	}
+
+	// MPEG-TS segments, and fMP4 segments following their init segment,
+	// can simply be concatenated into a single file.
+	for _, segment := range segments {
+		f, err := os.Open(segment) // nolint: gosec
+		if err != nil {
+			log.Errorln("unable to read recording segment", err)
+			return
+		}
+
+		_, err = io.Copy(w, f)
+		_ = f.Close()
+		if err != nil {
+			log.Debugln("unable to write recording segment", err)
+			return
+		}
+	}
+}
+
+// DeleteRecording will delete a single recording and its video.
+func DeleteRecording(w http.ResponseWriter, r *http.Request) {
+	if !requirePOST(w, r) {
+		return
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	var request recordingRequest
+	if err := decoder.Decode(&request); err != nil {
+		controllers.BadRequestHandler(w, err)
+		return
+	}
+
+	if _, err := recording.GetRecording(request.ID); err != nil {
+		controllers.BadRequestHandler(w, err)
+		return
+	}
+
+	if err := recording.DeleteRecording(request.ID); err != nil {
+		controllers.InternalErrorHandler(w, err)
+		return
+	}
+
+	controllers.WriteSimpleResponse(w, true, "deleted recording")
+}
+
+// SetRecordingVisibility will change who is able to watch a single recording.
+func SetRecordingVisibility(w http.ResponseWriter, r *http.Request) {
+	if !requirePOST(w, r) {
+		return
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	var request recordingVisibilityRequest
+	if err := decoder.Decode(&request); err != nil {
+		controllers.BadRequestHandler(w, err)
+		return
+	}
+
+	if !models.IsValidRecordingVisibility(request.Visibility) {
+		controllers.BadRequestHandler(w, errors.New("visibility must be one of public, unlisted or private"))
+		return
+	}
+
+	if _, err := recording.GetRecording(request.ID); err != nil {
+		controllers.BadRequestHandler(w, err)
+		return
+	}
+
+	if err := recording.SetRecordingVisibility(request.ID, request.Visibility); err != nil {
+		controllers.InternalErrorHandler(w, err)
+		return
+	}
+
+	controllers.WriteSimpleResponse(w, true, "recording visibility changed")
+}
@@ -0,0 +1,109 @@
+package admin
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/teris-io/shortid"
+
+	"github.com/owncast/owncast/controllers"
+	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/core/restream"
+	"github.com/owncast/owncast/models"
+)
+
+// SetRestreamDestinations will set the external RTMP destinations the stream is copied to.
+func SetRestreamDestinations(w http.ResponseWriter, r *http.Request) {
+	if !requirePOST(w, r) {
+		return
+	}
+
+	type restreamDestinationsRequest struct {
+		Value []models.RestreamDestination `json:"value"`
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	var request restreamDestinationsRequest
+	if err := decoder.Decode(&request); err != nil {
+		controllers.WriteSimpleResponse(w, false, "unable to update restream destinations with provided values")
+		return
+	}
+
+	ids := map[string]bool{}
+	for i := range request.Value {
+		destination := &request.Value[i]
+		destination.URL = strings.TrimSpace(destination.URL)
+		if !models.IsValidRestreamURL(destination.URL) {
+			controllers.WriteSimpleResponse(w, false, "restream destination must be an rtmp:// or rtmps:// url")
+			return
+		}
+
+		if destination.ID == "" {
+			destination.ID = shortid.MustGenerate()
+		}
+		if ids[destination.ID] {
+			controllers.WriteSimpleResponse(w, false, "restream destination ids must be unique")
+			return
+		}
+		ids[destination.ID] = true
+
+		// The path is left out of the default name as it contains the stream key.
+		if destination.Name == "" {
+			u, _ := url.Parse(destination.URL)
+			destination.Name = u.Host
+		}
+	}
+
+	if err := data.SetRestreamDestinations(request.Value); err != nil {
+		controllers.WriteSimpleResponse(w, false, err.Error())
+		return
+	}
+
+	restream.Update(request.Value)
+
+	controllers.WriteSimpleResponse(w, true, "restream destinations updated")
+}
+
+// SetRestreamDestinationEnabled will enable or disable a single restream destination.
+func SetRestreamDestinationEnabled(w http.ResponseWriter, r *http.Request) {
+	if !requirePOST(w, r) {
+		return
+	}
+
+	type restreamDestinationEnabledRequest struct {
+		ID    string `json:"id"`
+		Value bool   `json:"value"`
+	}
+
+	decoder := json.NewDecoder(r.Body)
+	var request restreamDestinationEnabledRequest
+	if err := decoder.Decode(&request); err != nil {
+		controllers.WriteSimpleResponse(w, false, "unable to update restream destination with provided values")
+		return
+	}
+
+	destinations := data.GetRestreamDestinations()
+	found := false
+	for i := range destinations {
+		if destinations[i].ID == request.ID {
+			destinations[i].Enabled = request.Value
+			found = true
+		}
+	}
+
+	if !found {
+		controllers.WriteSimpleResponse(w, false, "restream destination not found")
+		return
+	}
+
+	if err := data.SetRestreamDestinations(destinations); err != nil {
+		controllers.WriteSimpleResponse(w, false, err.Error())
+		return
+	}
+
+	restream.Update(destinations)
+
+	controllers.WriteSimpleResponse(w, true, "restream destination updated")
+}
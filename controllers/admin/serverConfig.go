@@ -52,15 +52,26 @@ func GetServerConfig(w http.ResponseWriter, r *http.Request) {
 		FFmpegPath:              ffmpeg,
 		AdminPassword:           data.GetAdminPassword(),
 		StreamKeys:              data.GetStreamKeys(),
+		RestreamDestinations:    data.GetRestreamDestinations(),
+		Channels:                data.GetChannels(),
 		StreamKeyOverridden:     config.TemporaryStreamKey != "",
 		WebServerPort:           config.WebServerPort,
 		WebServerIP:             config.WebServerIP,
 		RTMPServerPort:          data.GetRTMPPortNumber(),
+		SRTServerPort:           data.GetSRTPortNumber(),
 		ChatDisabled:            data.GetChatDisabled(),
 		ChatJoinMessagesEnabled: data.GetChatJoinPartMessagesEnabled(),
 		SocketHostOverride:      data.GetWebsocketOverrideHost(),
 		VideoServingEndpoint:    data.GetVideoServingEndpoint(),
 		ChatEstablishedUserMode: data.GetChatEstbalishedUsersOnlyMode(),
+		ChatModes:               data.GetChatModes(),
+		ChatMessageEditWindow:   data.GetChatMessageEditWindow(),
+		DirectMessageRetention:  data.GetDirectMessageRetentionDays(),
+		AbuseReportConfig:       data.GetAbuseReportConfig(),
+		ChatArchiveConfig:       data.GetChatArchiveConfig(),
+		ChatRooms:               data.GetChatRooms(),
+		ChatRateLimitPolicy:     data.GetChatRateLimitPolicy(),
+		AutomodRules:            data.GetAutomodRules(),
 		HideViewerCount:         data.GetHideViewerCount(),
 		DisableSearchIndexing:   data.GetDisableSearchIndexing(),
 		VideoSettings: videoSettings{
@@ -72,9 +83,11 @@ func GetServerConfig(w http.ResponseWriter, r *http.Request) {
 			InstanceURL: data.GetServerURL(),
 		},
 		S3:                 data.GetS3Config(),
+		Recording:          data.GetRecordingConfig(),
 		ExternalActions:    data.GetExternalActions(),
 		SupportedCodecs:    transcoder.GetCodecs(ffmpeg),
 		VideoCodec:         data.GetVideoCodec(),
+		SegmentFormat:      data.GetSegmentFormat(),
 		ForbiddenUsernames: usernameBlocklist,
 		SuggestedUsernames: usernameSuggestions,
 		Federation: federationConfigResponse{
@@ -100,31 +113,44 @@ func GetServerConfig(w http.ResponseWriter, r *http.Request) {
 }
 
 type serverConfigAdminResponse struct {
-	InstanceDetails         webConfigResponse           `json:"instanceDetails"`
-	Notifications           notificationsConfigResponse `json:"notifications"`
-	YP                      yp                          `json:"yp"`
-	FFmpegPath              string                      `json:"ffmpegPath"`
-	AdminPassword           string                      `json:"adminPassword"`
-	SocketHostOverride      string                      `json:"socketHostOverride,omitempty"`
-	WebServerIP             string                      `json:"webServerIP"`
-	VideoCodec              string                      `json:"videoCodec"`
-	VideoServingEndpoint    string                      `json:"videoServingEndpoint"`
-	S3                      models.S3                   `json:"s3"`
-	Federation              federationConfigResponse    `json:"federation"`
-	SupportedCodecs         []string                    `json:"supportedCodecs"`
-	ExternalActions         []models.ExternalAction     `json:"externalActions"`
-	ForbiddenUsernames      []string                    `json:"forbiddenUsernames"`
-	SuggestedUsernames      []string                    `json:"suggestedUsernames"`
-	StreamKeys              []models.StreamKey          `json:"streamKeys"`
-	VideoSettings           videoSettings               `json:"videoSettings"`
-	RTMPServerPort          int                         `json:"rtmpServerPort"`
-	WebServerPort           int                         `json:"webServerPort"`
-	ChatDisabled            bool                        `json:"chatDisabled"`
-	ChatJoinMessagesEnabled bool                        `json:"chatJoinMessagesEnabled"`
-	ChatEstablishedUserMode bool                        `json:"chatEstablishedUserMode"`
-	DisableSearchIndexing   bool                        `json:"disableSearchIndexing"`
-	StreamKeyOverridden     bool                        `json:"streamKeyOverridden"`
-	HideViewerCount         bool                        `json:"hideViewerCount"`
+	InstanceDetails         webConfigResponse            `json:"instanceDetails"`
+	Notifications           notificationsConfigResponse  `json:"notifications"`
+	YP                      yp                           `json:"yp"`
+	FFmpegPath              string                       `json:"ffmpegPath"`
+	AdminPassword           string                       `json:"adminPassword"`
+	SocketHostOverride      string                       `json:"socketHostOverride,omitempty"`
+	WebServerIP             string                       `json:"webServerIP"`
+	VideoCodec              string                       `json:"videoCodec"`
+	SegmentFormat           string                       `json:"segmentFormat"`
+	VideoServingEndpoint    string                       `json:"videoServingEndpoint"`
+	S3                      models.S3                    `json:"s3"`
+	Recording               models.RecordingConfig       `json:"recording"`
+	Federation              federationConfigResponse     `json:"federation"`
+	SupportedCodecs         []string                     `json:"supportedCodecs"`
+	ExternalActions         []models.ExternalAction      `json:"externalActions"`
+	ForbiddenUsernames      []string                     `json:"forbiddenUsernames"`
+	SuggestedUsernames      []string                     `json:"suggestedUsernames"`
+	StreamKeys              []models.StreamKey           `json:"streamKeys"`
+	RestreamDestinations    []models.RestreamDestination `json:"restreamDestinations"`
+	Channels                []models.Channel             `json:"channels"`
+	VideoSettings           videoSettings                `json:"videoSettings"`
+	RTMPServerPort          int                          `json:"rtmpServerPort"`
+	SRTServerPort           int                          `json:"srtServerPort"`
+	WebServerPort           int                          `json:"webServerPort"`
+	ChatDisabled            bool                         `json:"chatDisabled"`
+	ChatJoinMessagesEnabled bool                         `json:"chatJoinMessagesEnabled"`
+	ChatEstablishedUserMode bool                         `json:"chatEstablishedUserMode"`
+	ChatModes               models.ChatModes             `json:"chatModes"`
+	ChatMessageEditWindow   int                          `json:"chatMessageEditWindow"`
+	DirectMessageRetention  int                          `json:"directMessageRetention"`
+	AbuseReportConfig       models.AbuseReportConfig     `json:"abuseReportConfig"`
+	ChatArchiveConfig       models.ChatArchiveConfig     `json:"chatArchiveConfig"`
+	ChatRooms               []models.ChatRoom            `json:"chatRooms"`
+	ChatRateLimitPolicy     models.ChatRateLimitPolicy   `json:"chatRateLimitPolicy"`
+	AutomodRules            []models.AutomodRule         `json:"automodRules"`
+	DisableSearchIndexing   bool                         `json:"disableSearchIndexing"`
+	StreamKeyOverridden     bool                         `json:"streamKeyOverridden"`
+	HideViewerCount         bool                         `json:"hideViewerCount"`
 }
 
 type videoSettings struct {
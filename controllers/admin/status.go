@@ -6,6 +6,7 @@ import (
 
 	"github.com/owncast/owncast/core"
 	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/core/restream"
 	"github.com/owncast/owncast/metrics"
 	"github.com/owncast/owncast/models"
 	"github.com/owncast/owncast/router/middleware"
@@ -28,6 +29,7 @@ func Status(w http.ResponseWriter, r *http.Request) {
 		SessionPeakViewerCount: status.SessionMaxViewerCount,
 		VersionNumber:          status.VersionNumber,
 		StreamTitle:            data.GetStreamTitle(),
+		Restreams:              restream.GetStatus(),
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -43,6 +45,7 @@ type adminStatusResponse struct {
 	Broadcaster            *models.Broadcaster          `json:"broadcaster"`
 	CurrentBroadcast       *models.CurrentBroadcast     `json:"currentBroadcast"`
 	Health                 *models.StreamHealthOverview `json:"health"`
+	Restreams              []models.RestreamStatus      `json:"restreams"`
 	StreamTitle            string                       `json:"streamTitle"`
 	VersionNumber          string                       `json:"versionNumber"`
 	ViewerCount            int                          `json:"viewerCount"`
@@ -0,0 +1,45 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/owncast/owncast/core"
+	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/router/middleware"
+)
+
+type channelResponse struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	StreamTitle string `json:"streamTitle"`
+	ViewerCount int    `json:"viewerCount,omitempty"`
+	Online      bool   `json:"online"`
+}
+
+// GetChannels will return the channels hosted alongside the default stream
+// and if each is live.
+func GetChannels(w http.ResponseWriter, r *http.Request) {
+	middleware.EnableCors(w)
+	middleware.DisableCache(w)
+	w.Header().Set("Content-Type", "application/json")
+
+	response := []channelResponse{}
+	for _, channel := range data.GetChannels() {
+		status := core.GetChannelStatus(channel.ID)
+		item := channelResponse{
+			ID:          channel.ID,
+			Name:        channel.Name,
+			StreamTitle: channel.StreamTitle,
+			Online:      status.Online,
+		}
+		if !data.GetHideViewerCount() {
+			item.ViewerCount = status.ViewerCount
+		}
+		response = append(response, item)
+	}
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		InternalErrorHandler(w, err)
+	}
+}
@@ -12,6 +12,9 @@ import (
 	log "github.com/sirupsen/logrus"
 )
 
+// maxDirectMessageHistory is the most direct messages returned to a user.
+const maxDirectMessageHistory = 100
+
 // ExternalGetChatMessages gets all of the chat messages.
 func ExternalGetChatMessages(integration user.ExternalAPIUser, w http.ResponseWriter, r *http.Request) {
 	middleware.EnableCors(w)
@@ -29,7 +32,7 @@ func getChatMessages(w http.ResponseWriter, r *http.Request) {
 
 	switch r.Method {
 	case http.MethodGet:
-		messages := chat.GetChatHistory()
+		messages := chat.GetChatHistory(r.URL.Query().Get("channel"))
 
 		if err := json.NewEncoder(w).Encode(messages); err != nil {
 			log.Debugln(err)
@@ -42,6 +45,31 @@ func getChatMessages(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// GetDirectMessages gets the direct messages sent and received by the
+// requesting user.
+func GetDirectMessages(u user.User, w http.ResponseWriter, r *http.Request) {
+	middleware.EnableCors(w)
+
+	if r.Method != http.MethodGet {
+		WriteSimpleResponse(w, false, r.Method+" not supported")
+		return
+	}
+
+	WriteResponse(w, chat.GetDirectMessages(u.ID, r.URL.Query().Get("with"), maxDirectMessageHistory))
+}
+
+// GetChatRooms gets the chat rooms the requesting user is able to join.
+func GetChatRooms(u user.User, w http.ResponseWriter, r *http.Request) {
+	middleware.EnableCors(w)
+
+	if r.Method != http.MethodGet {
+		WriteSimpleResponse(w, false, r.Method+" not supported")
+		return
+	}
+
+	WriteResponse(w, chat.GetChatRoomsForUser(&u))
+}
+
 // RegisterAnonymousChatUser will register a new user.
 func RegisterAnonymousChatUser(w http.ResponseWriter, r *http.Request) {
 	middleware.EnableCors(w)
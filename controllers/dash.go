@@ -0,0 +1,32 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/owncast/owncast/core"
+	"github.com/owncast/owncast/core/dash"
+	"github.com/owncast/owncast/models"
+	"github.com/owncast/owncast/router/middleware"
+	"github.com/owncast/owncast/utils"
+)
+
+// HandleDASHRequest will manage requests for the DASH manifest. The
+// segments themselves are served alongside the HLS video.
+func HandleDASHRequest(w http.ResponseWriter, r *http.Request) {
+	manifestPath := dash.GetManifestPath()
+	if r.URL.Path != "/dash/stream.mpd" || !utils.DoesFileExists(manifestPath) {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	// The manifest should never be cached.
+	middleware.DisableCache(w)
+	w.Header().Set("Content-Type", "application/dash+xml")
+
+	// Use this as an opportunity to mark this viewer as active.
+	viewer := models.GenerateViewerFromRequest(r)
+	core.SetViewerActive(&viewer)
+
+	middleware.EnableCors(w)
+	http.ServeFile(w, r, manifestPath)
+}
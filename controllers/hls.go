@@ -10,6 +10,7 @@ import (
 	"github.com/owncast/owncast/config"
 	"github.com/owncast/owncast/core"
 	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/core/llhls"
 	"github.com/owncast/owncast/models"
 	"github.com/owncast/owncast/router/middleware"
 	"github.com/owncast/owncast/utils"
@@ -18,7 +19,7 @@ import (
 // HandleHLSRequest will manage all requests to HLS content.
 func HandleHLSRequest(w http.ResponseWriter, r *http.Request) {
 	// Sanity check to limit requests to HLS file types.
-	if filepath.Ext(r.URL.Path) != ".m3u8" && filepath.Ext(r.URL.Path) != ".ts" {
+	if filepath.Ext(r.URL.Path) != ".m3u8" && !utils.IsHLSSegment(r.URL.Path) && !utils.IsHLSInitSegment(r.URL.Path) {
 		w.WriteHeader(http.StatusNotFound)
 		return
 	}
@@ -26,10 +27,11 @@ func HandleHLSRequest(w http.ResponseWriter, r *http.Request) {
 	requestedPath := r.URL.Path
 	relativePath := strings.Replace(requestedPath, "/hls/", "", 1)
 	fullPath := filepath.Join(config.HLSStoragePath, relativePath)
+	channelID := models.GetChannelIDFromHLSPath(relativePath)
 
 	// If using external storage then only allow requests for the
 	// master playlist at stream.m3u8, no variants or segments.
-	if data.GetS3Config().Enabled && relativePath != "stream.m3u8" {
+	if data.GetS3Config().Enabled && relativePath != path.Join(channelID, "stream.m3u8") {
 		w.WriteHeader(http.StatusNotFound)
 		return
 	}
@@ -44,12 +46,72 @@ func HandleHLSRequest(w http.ResponseWriter, r *http.Request) {
 
 		// Use this as an opportunity to mark this viewer as active.
 		viewer := models.GenerateViewerFromRequest(r)
-		core.SetViewerActive(&viewer)
+		core.SetChannelViewerActive(channelID, &viewer)
+
+		// Low latency playlists are only available to the default channel.
+		if channelID == models.DefaultChannelID {
+			if handled := handleLowLatencyPlaylistRequest(w, r, relativePath); handled {
+				return
+			}
+		}
 	} else {
 		cacheTime := utils.GetCacheDurationSecondsForPath(relativePath)
 		w.Header().Set("Cache-Control", "public, max-age="+strconv.Itoa(cacheTime))
 	}
 
 	middleware.EnableCors(w)
+
+	// A request for the next part of a low latency stream is held until
+	// the part has been written.
+	if channelID == models.DefaultChannelID && !utils.DoesFileExists(fullPath) {
+		_ = llhls.WaitForPart(r.Context(), path.Dir(relativePath), path.Base(relativePath))
+	}
+
 	http.ServeFile(w, r, fullPath)
 }
+
+// handleLowLatencyPlaylistRequest will serve the LL-HLS playlist of a
+// variant, supporting blocking playlist reloads. Returns false if the
+// stream is not using LL-HLS.
+func handleLowLatencyPlaylistRequest(w http.ResponseWriter, r *http.Request, relativePath string) bool {
+	index := path.Dir(relativePath)
+	if index == "." {
+		return false
+	}
+
+	mediaSequence, partIndex := -1, -1
+	query := r.URL.Query()
+	if value := query.Get("_HLS_msn"); value != "" {
+		v, err := strconv.Atoi(value)
+		if err != nil || v < 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			return true
+		}
+		mediaSequence = v
+	}
+	if value := query.Get("_HLS_part"); value != "" {
+		v, err := strconv.Atoi(value)
+		if err != nil || v < 0 || mediaSequence < 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			return true
+		}
+		partIndex = v
+	}
+
+	playlist, err := llhls.GetVariantPlaylist(r.Context(), index, mediaSequence, partIndex)
+	switch err {
+	case nil:
+	case llhls.ErrNotAvailable:
+		return false
+	case llhls.ErrInvalidRequest:
+		w.WriteHeader(http.StatusBadRequest)
+		return true
+	default:
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return true
+	}
+
+	middleware.EnableCors(w)
+	_, _ = w.Write([]byte(playlist))
+	return true
+}
@@ -67,7 +67,7 @@ func renderIndexHtml(w http.ResponseWriter, nonce string) {
 		Nonce            string
 	}
 
-	status := getStatusResponse()
+	status := getStatusResponse(models.DefaultChannelID)
 	sb, err := json.Marshal(status)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
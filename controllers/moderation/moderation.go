@@ -71,3 +71,48 @@ func GetUserDetails(w http.ResponseWriter, r *http.Request) {
 		controllers.InternalErrorHandler(w, err)
 	}
 }
+
+// GetHeldMessages returns the chat messages waiting for moderator review.
+func GetHeldMessages(w http.ResponseWriter, r *http.Request) {
+	controllers.WriteResponse(w, chat.GetHeldMessages())
+}
+
+// ApproveHeldMessage sends a held chat message to chat.
+func ApproveHeldMessage(w http.ResponseWriter, r *http.Request) {
+	resolveHeldMessage(w, r, chat.ApproveHeldMessage, "message approved")
+}
+
+// RejectHeldMessage discards a held chat message.
+func RejectHeldMessage(w http.ResponseWriter, r *http.Request) {
+	resolveHeldMessage(w, r, chat.RejectHeldMessage, "message rejected")
+}
+
+func resolveHeldMessage(w http.ResponseWriter, r *http.Request, resolve func(string, *user.User) error, success string) {
+	type request struct {
+		ID string `json:"id"`
+	}
+
+	if r.Method != controllers.POST {
+		controllers.WriteSimpleResponse(w, false, r.Method+" not supported")
+		return
+	}
+
+	var req request
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.ID == "" {
+		controllers.WriteSimpleResponse(w, false, "a held message id is required")
+		return
+	}
+
+	moderator := user.GetUserByToken(r.URL.Query().Get("accessToken"))
+	if moderator == nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	if err := resolve(req.ID, moderator); err != nil {
+		controllers.WriteSimpleResponse(w, false, err.Error())
+		return
+	}
+
+	controllers.WriteSimpleResponse(w, true, success)
+}
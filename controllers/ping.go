@@ -7,9 +7,9 @@ import (
 	"github.com/owncast/owncast/models"
 )
 
-// Ping is fired by a client to show they are still an active viewer.
+// Ping is fired by a client to show they are still an active viewer of a channel.
 func Ping(w http.ResponseWriter, r *http.Request) {
 	viewer := models.GenerateViewerFromRequest(r)
-	core.SetViewerActive(&viewer)
+	core.SetChannelViewerActive(r.URL.Query().Get("channel"), &viewer)
 	w.WriteHeader(http.StatusOK)
 }
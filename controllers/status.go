@@ -7,13 +7,20 @@ import (
 
 	"github.com/owncast/owncast/core"
 	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/models"
 	"github.com/owncast/owncast/router/middleware"
 	"github.com/owncast/owncast/utils"
 )
 
-// GetStatus gets the status of the server.
+// GetStatus gets the status of the server, or of a single channel.
 func GetStatus(w http.ResponseWriter, r *http.Request) {
-	response := getStatusResponse()
+	channelID := r.URL.Query().Get("channel")
+	if _, exists := data.GetChannel(channelID); channelID != models.DefaultChannelID && !exists {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	response := getStatusResponse(channelID)
 
 	w.Header().Set("Content-Type", "application/json")
 	middleware.DisableCache(w)
@@ -23,8 +30,8 @@ func GetStatus(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-func getStatusResponse() webStatusResponse {
-	status := core.GetStatus()
+func getStatusResponse(channelID string) webStatusResponse {
+	status := core.GetChannelStatus(channelID)
 	response := webStatusResponse{
 		Online:             status.Online,
 		ServerTime:         time.Now(),
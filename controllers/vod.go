@@ -0,0 +1,128 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/owncast/owncast/core/chat"
+	"github.com/owncast/owncast/core/recording"
+	"github.com/owncast/owncast/models"
+	"github.com/owncast/owncast/router/middleware"
+	"github.com/owncast/owncast/utils"
+)
+
+// GetVODs will return the publicly listed stream recordings.
+func GetVODs(w http.ResponseWriter, r *http.Request) {
+	middleware.EnableCors(w)
+
+	recordings, err := recording.GetPublicRecordings()
+	if err != nil {
+		InternalErrorHandler(w, err)
+		return
+	}
+
+	WriteResponse(w, recordings)
+}
+
+// defaultVODChatWindow is how much chat is returned when a window isn't ended.
+const defaultVODChatWindow = time.Minute
+
+// GetVODChat will return the chat sent during a window of a stream recording
+// in the form of /api/vods/{id}/chat?from=&to=, with offsets in seconds.
+func GetVODChat(w http.ResponseWriter, r *http.Request) {
+	middleware.EnableCors(w)
+
+	pathComponents := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/vods/"), "/")
+	if len(pathComponents) != 2 || pathComponents[1] != "chat" {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	// Private recordings are only available to the admin.
+	vod, err := recording.GetRecording(pathComponents[0])
+	if err != nil || vod.Visibility == models.RecordingVisibilityPrivate {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	from, err := getOffsetFromQuery(r, "from", 0)
+	if err != nil {
+		BadRequestHandler(w, err)
+		return
+	}
+
+	to, err := getOffsetFromQuery(r, "to", from+defaultVODChatWindow)
+	if err != nil {
+		BadRequestHandler(w, err)
+		return
+	}
+
+	if to < from {
+		BadRequestHandler(w, errors.New("to must not be before from"))
+		return
+	}
+
+	messages, err := chat.GetRecordingChat(*vod, from, to)
+	if err != nil {
+		InternalErrorHandler(w, err)
+		return
+	}
+
+	WriteResponse(w, messages)
+}
+
+func getOffsetFromQuery(r *http.Request, key string, defaultValue time.Duration) (time.Duration, error) {
+	value := r.URL.Query().Get(key)
+	if value == "" {
+		return defaultValue, nil
+	}
+
+	seconds, err := strconv.ParseFloat(value, 64)
+	if err != nil || seconds < 0 {
+		return 0, errors.New(key + " must be a number of seconds into the recording")
+	}
+
+	return time.Duration(seconds * float64(time.Second)), nil
+}
+
+// HandleVODRequest will manage all requests to recorded HLS content
+// in the form of /vod/{id}/{file}.
+func HandleVODRequest(w http.ResponseWriter, r *http.Request) {
+	// Sanity check to limit requests to HLS file types.
+	if filepath.Ext(r.URL.Path) != ".m3u8" && !utils.IsHLSSegment(r.URL.Path) && !utils.IsHLSInitSegment(r.URL.Path) {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	pathComponents := strings.Split(strings.TrimPrefix(r.URL.Path, "/vod/"), "/")
+	if len(pathComponents) != 2 {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	id, filename := pathComponents[0], pathComponents[1]
+
+	// Private recordings are only available to the admin.
+	vod, err := recording.GetRecording(id)
+	if err != nil || vod.Visibility == models.RecordingVisibilityPrivate {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	if path.Ext(filename) == ".m3u8" {
+		// The visibility of a recording can change, so don't cache its playlist.
+		middleware.DisableCache(w)
+		w.Header().Set("Content-Type", "application/x-mpegURL")
+	} else {
+		// Recorded segments never change.
+		w.Header().Set("Cache-Control", "public, max-age=31536000")
+	}
+
+	middleware.EnableCors(w)
+	http.ServeFile(w, r, recording.GetRecordingFilePath(id, filename))
+}
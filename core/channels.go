@@ -0,0 +1,233 @@
+package core
+
+import (
+	"io"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/owncast/owncast/config"
+	"github.com/owncast/owncast/core/chat"
+	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/core/rtmp"
+	"github.com/owncast/owncast/core/transcoder"
+	"github.com/owncast/owncast/core/webhooks"
+	"github.com/owncast/owncast/models"
+	"github.com/owncast/owncast/utils"
+)
+
+// Channels other than the default channel are a slimmer version of it. They
+// have their own HLS tree, chat, viewer stats and webhooks, but do not get
+// recordings, low latency playlists, DASH, restreaming, thumbnails, directory
+// listings or notifications. Their stats are not persisted.
+
+// GetUnavailableChannelFeatures will return the enabled features that are
+// not available to channels other than the default channel.
+func GetUnavailableChannelFeatures() []string {
+	// Thumbnails are always generated for the default channel.
+	unavailable := []string{"thumbnails"}
+
+	if data.GetRecordingConfig().Enabled {
+		unavailable = append(unavailable, "recordings")
+	}
+	if data.GetStreamLatencyLevel().IsLowLatencyHLS() {
+		unavailable = append(unavailable, "low latency HLS")
+	}
+	if transcoder.GetSegmentFormat() == models.SegmentFormatFMP4 {
+		unavailable = append(unavailable, "DASH")
+	}
+	for _, destination := range data.GetRestreamDestinations() {
+		if destination.Enabled {
+			unavailable = append(unavailable, "restreaming")
+			break
+		}
+	}
+	if data.GetDirectoryEnabled() {
+		unavailable = append(unavailable, "directory listings")
+	}
+	if data.GetNotificationsEnabled() {
+		unavailable = append(unavailable, "notifications")
+	}
+
+	return unavailable
+}
+
+// channelState is the state of a single channel other than the default channel.
+type channelState struct {
+	stats            *models.Stats
+	transcoder       *transcoder.Transcoder
+	broadcaster      *models.Broadcaster
+	currentBroadcast *models.CurrentBroadcast
+}
+
+// The state of each channel that has streamed, keyed by channel id. Guarded by l.
+var _channels = map[string]*channelState{}
+
+// getChannelStats will return the stats of a channel, or nil if the channel
+// has not streamed. Must be called with l held.
+func getChannelStats(channelID string) *models.Stats {
+	if channelID == models.DefaultChannelID {
+		return _stats
+	}
+
+	if channel, ok := _channels[channelID]; ok {
+		return channel.stats
+	}
+
+	return nil
+}
+
+// hasConnectedChannels will return if any channel, including the default
+// channel, has a stream connected.
+func hasConnectedChannels() bool {
+	l.RLock()
+	defer l.RUnlock()
+
+	if _stats.StreamConnected {
+		return true
+	}
+
+	for _, channel := range _channels {
+		if channel.stats.StreamConnected {
+			return true
+		}
+	}
+
+	return false
+}
+
+// setChannelAsConnected sets a channel other than the default channel as connected.
+func setChannelAsConnected(channelID string, rtmpOut *io.PipeReader) {
+	now := utils.NullTime{Time: time.Now(), Valid: true}
+	currentBroadcast := &models.CurrentBroadcast{
+		LatencyLevel:   data.GetStreamLatencyLevel(),
+		OutputSettings: data.GetStreamOutputVariants(),
+		SegmentFormat:  transcoder.GetSegmentFormat(),
+	}
+	if currentBroadcast.LatencyLevel.IsLowLatencyHLS() {
+		// The transcoder of the channel falls back in the same way.
+		currentBroadcast.LatencyLevel = models.GetLatencyLevel(0)
+	}
+
+	if unavailable := GetUnavailableChannelFeatures(); len(unavailable) > 0 {
+		log.Warnln("Channel", channelID, "is streaming without", strings.Join(unavailable, ", "), "as they are only available to the default channel.")
+	}
+
+	l.Lock()
+	channel, ok := _channels[channelID]
+	if !ok {
+		channel = &channelState{
+			stats: &models.Stats{
+				ChatClients: make(map[string]models.Client),
+				Viewers:     make(map[string]*models.Viewer),
+			},
+		}
+		_channels[channelID] = channel
+	}
+	channel.stats.StreamConnected = true
+	channel.stats.LastDisconnectTime = nil
+	channel.stats.LastConnectTime = &now
+	channel.stats.SessionMaxViewerCount = 0
+	channel.currentBroadcast = currentBroadcast
+	l.Unlock()
+
+	startOnlineCleanupTimer()
+
+	go func() {
+		t := transcoder.NewTranscoder()
+		t.SetChannel(channelID)
+		t.TranscoderCompleted = func(error) {
+			setChannelAsDisconnected(channelID)
+		}
+
+		l.Lock()
+		channel.transcoder = t
+		l.Unlock()
+
+		handler.SetChannelTranscoder(channelID, t)
+		t.SetStdin(rtmpOut)
+		t.Start(true)
+	}()
+
+	go webhooks.SendChannelStreamStatusEvent(channelID, models.StreamStarted)
+
+	_ = chat.SendSystemActionToChannel(channelID, "Stay tuned, the stream is **starting**!", true)
+	chat.SendAllWelcomeMessage(channelID)
+}
+
+// setChannelAsDisconnected sets a channel other than the default channel as disconnected.
+func setChannelAsDisconnected(channelID string) {
+	l.Lock()
+	channel, ok := _channels[channelID]
+	if !ok || !channel.stats.StreamConnected {
+		l.Unlock()
+		return
+	}
+
+	now := utils.NullTime{Time: time.Now(), Valid: true}
+	channel.stats.StreamConnected = false
+	channel.stats.LastDisconnectTime = &now
+	channel.stats.LastConnectTime = nil
+	channel.broadcaster = nil
+	channel.transcoder = nil
+	currentBroadcast := channel.currentBroadcast
+	channel.currentBroadcast = nil
+	l.Unlock()
+
+	_ = chat.SendSystemActionToChannel(channelID, "The stream is ending.", true)
+
+	rtmp.Disconnect(channelID)
+
+	if currentBroadcast != nil {
+		clip, err := getOfflineClip(currentBroadcast.SegmentFormat)
+		if err != nil {
+			log.Errorln(err)
+			return
+		}
+
+		for index := range currentBroadcast.OutputSettings {
+			makeVariantIndexOffline(channelID, index, clip)
+		}
+	}
+
+	stopOnlineCleanupTimer()
+
+	go webhooks.SendChannelStreamStatusEvent(channelID, models.StreamStopped)
+}
+
+// GetChannelStatus gets the status of a single channel.
+func GetChannelStatus(channelID string) models.Status {
+	if channelID == models.DefaultChannelID {
+		return GetStatus()
+	}
+
+	l.RLock()
+	defer l.RUnlock()
+
+	stats := getChannelStats(channelID)
+	if stats == nil {
+		return models.Status{
+			VersionNumber: config.VersionNumber,
+			StreamTitle:   data.GetChannelStreamTitle(channelID),
+		}
+	}
+
+	return getStatusFromStats(stats, data.GetChannelStreamTitle(channelID))
+}
+
+// GetChannelBroadcaster will return the details of the active broadcaster of a channel.
+func GetChannelBroadcaster(channelID string) *models.Broadcaster {
+	if channelID == models.DefaultChannelID {
+		return GetBroadcaster()
+	}
+
+	l.RLock()
+	defer l.RUnlock()
+
+	if channel, ok := _channels[channelID]; ok {
+		return channel.broadcaster
+	}
+
+	return nil
+}
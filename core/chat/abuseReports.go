@@ -0,0 +1,156 @@
+package chat
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/owncast/owncast/core/chat/events"
+	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/core/user"
+	"github.com/owncast/owncast/core/webhooks"
+	"github.com/owncast/owncast/models"
+	"github.com/owncast/owncast/notifications"
+	"github.com/owncast/owncast/utils"
+)
+
+// maxAbuseReportReasonLength is the longest reason a report can give.
+const maxAbuseReportReasonLength = 500
+
+// ErrAbuseReportNotFound is returned when there is no abuse report with the
+// requested ID.
+var ErrAbuseReportNotFound = errors.New("abuse report not found")
+
+func (s *Server) userAbuseReportSent(eventData chatClientEvent) {
+	var event events.AbuseReportEvent
+	if err := json.Unmarshal(eventData.data, &event); err != nil {
+		log.Errorln("error unmarshalling to AbuseReportEvent", err)
+		return
+	}
+
+	event.SetDefaults()
+	event.ChannelID = eventData.client.ChannelID
+
+	event.User = user.GetUserByToken(eventData.client.accessToken)
+	if event.User == nil {
+		return
+	}
+
+	reason := utils.MakeSafeStringOfLength(strings.TrimSpace(event.Reason), maxAbuseReportReasonLength)
+	if reason == "" {
+		s.sendActionToClient(eventData.client, "Please give a reason for your report.")
+		return
+	}
+
+	report := models.AbuseReport{
+		Timestamp:  event.Timestamp,
+		ReporterID: event.User.ID,
+		Reason:     reason,
+		ChannelID:  event.ChannelID,
+	}
+
+	var reportedMessage editableMessage
+	if event.MessageID != "" {
+		message, exists := getEditableMessage(event.MessageID)
+		if !exists || message.channelID != event.ChannelID {
+			s.sendActionToClient(eventData.client, "That message could not be reported.")
+			return
+		}
+		reportedMessage = message
+		report.MessageID = event.MessageID
+		report.UserID = message.userID
+	} else if reported := user.GetUserByID(event.UserID); reported != nil {
+		report.UserID = reported.ID
+	} else {
+		s.sendActionToClient(eventData.client, "That user could not be reported.")
+		return
+	}
+
+	if report.UserID == event.User.ID {
+		return
+	}
+
+	added, err := data.InsertAbuseReport(report)
+	if err != nil {
+		log.Errorln("error saving abuse report", err)
+		s.sendActionToClient(eventData.client, "Your report could not be sent. Please try again later.")
+		return
+	}
+
+	s.sendActionToClient(eventData.client, "Thank you. Your report has been sent to the moderators.")
+
+	if added && report.MessageID != "" {
+		checkAbuseReportThreshold(report.MessageID, reportedMessage, report.ChannelID)
+	}
+}
+
+// checkAbuseReportThreshold will alert the moderators when a message has
+// just reached the configured number of open reports.
+func checkAbuseReportThreshold(messageID string, message editableMessage, channelID string) {
+	threshold := data.GetAbuseReportConfig().Threshold
+	if threshold <= 0 {
+		return
+	}
+
+	count, err := data.GetOpenAbuseReportCount(messageID)
+	if err != nil {
+		log.Errorln("error counting abuse reports", err)
+		return
+	}
+	if count != threshold {
+		return
+	}
+
+	author := user.GetUserByID(message.userID)
+
+	webhooks.SendAbuseReportThresholdReached(webhooks.WebhookAbuseReportAlert{
+		User:        author,
+		MessageID:   messageID,
+		Body:        message.body,
+		ReportCount: count,
+	}, channelID)
+
+	displayName := message.userID
+	if author != nil {
+		displayName = author.DisplayName
+	}
+	alert := fmt.Sprintf("A chat message from %s has been reported %d times:\n> %s", displayName, count, automodPlainText(message.body))
+
+	go func() {
+		if err := notifications.NotifyModerators(alert); err != nil {
+			log.Errorln("error sending abuse report alert to discord", err)
+		}
+	}()
+}
+
+// GetAbuseReports will return the most recent abuse reports, newest first.
+// If status is set only the reports with that status are returned.
+func GetAbuseReports(status string, limit int) []models.AbuseReport {
+	reports, err := data.GetAbuseReports(status, limit)
+	if err != nil {
+		log.Errorln("error fetching abuse reports", err)
+	}
+
+	return reports
+}
+
+// SetAbuseReportStatus will change the status of an abuse report, recording
+// who changed it.
+func SetAbuseReportStatus(id int, status string, resolvedBy string) error {
+	if !models.IsValidAbuseReportStatus(status) {
+		return fmt.Errorf("%s is not a valid abuse report status", status)
+	}
+
+	updated, err := data.SetAbuseReportStatus(id, status, resolvedBy)
+	if err != nil {
+		return err
+	}
+	if !updated {
+		return ErrAbuseReportNotFound
+	}
+
+	return nil
+}
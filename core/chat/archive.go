@@ -0,0 +1,217 @@
+package chat
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/models"
+)
+
+const (
+	// ChatExportJSON exports archived chat as a JSON array.
+	ChatExportJSON = "json"
+	// ChatExportCSV exports archived chat as CSV with a header row.
+	ChatExportCSV = "csv"
+	// ChatExportWebVTT exports archived chat as WebVTT subtitles.
+	ChatExportWebVTT = "vtt"
+)
+
+const (
+	// defaultArchiveSearchLimit is the most search results returned when no
+	// limit is requested.
+	defaultArchiveSearchLimit = 100
+	// chatSubtitleCueDuration is how long each chat message is shown for in
+	// a subtitle export.
+	chatSubtitleCueDuration = 5 * time.Second
+)
+
+// archiveEvent will add a saved chat event to the long-term archive, if it
+// is enabled.
+func archiveEvent(id string, userID *string, body string, eventType string, hidden *time.Time, timestamp time.Time, channelID string, roomID string) {
+	if !data.GetChatArchiveConfig().Enabled {
+		return
+	}
+
+	entry := models.ChatArchiveEntry{
+		ID:        id,
+		Body:      body,
+		Type:      eventType,
+		Timestamp: timestamp,
+		ChannelID: channelID,
+		RoomID:    roomID,
+		HiddenAt:  hidden,
+	}
+	if userID != nil {
+		entry.UserID = *userID
+	}
+
+	if err := data.InsertChatArchiveEntry(entry, automodPlainText(body)); err != nil {
+		log.Errorln("error archiving", eventType, err)
+	}
+}
+
+// archiveMessageEdit will replace the body of an archived chat message after
+// it was edited.
+func archiveMessageEdit(messageID string, body string, editedAt time.Time) {
+	if err := data.UpdateChatArchiveEntryBody(messageID, body, automodPlainText(body), editedAt); err != nil {
+		log.Errorln("error archiving chat message edit", err)
+	}
+}
+
+// archiveMessageDeletion will mark an archived chat message as deleted.
+func archiveMessageDeletion(messageID string, deletedAt time.Time) {
+	if err := data.SetChatArchiveEntryDeleted(messageID, deletedAt); err != nil {
+		log.Errorln("error archiving chat message deletion", err)
+	}
+}
+
+// archiveMessagesVisibility will hide or show archived chat messages.
+func archiveMessagesVisibility(messageIDs []string, visible bool) {
+	if err := data.SetChatArchiveEntriesHidden(messageIDs, getHiddenAt(visible)); err != nil {
+		log.Errorln("error archiving chat message visibility", err)
+	}
+}
+
+// archiveUserMessagesVisibility will hide or show all the archived chat
+// messages of a user, including those no longer in the chat history.
+func archiveUserMessagesVisibility(userID string, visible bool) {
+	if err := data.SetChatArchiveEntriesHiddenForUser(userID, getHiddenAt(visible)); err != nil {
+		log.Errorln("error archiving chat message visibility", err)
+	}
+}
+
+// getHiddenAt will return the time messages are hidden at, or nil if they
+// are visible.
+func getHiddenAt(visible bool) *time.Time {
+	if visible {
+		return nil
+	}
+
+	now := time.Now()
+	return &now
+}
+
+// SearchChatArchive will return the archived chat events matching a query,
+// oldest first.
+func SearchChatArchive(query models.ChatArchiveQuery) ([]models.ChatArchiveEntry, error) {
+	if query.Limit <= 0 {
+		query.Limit = defaultArchiveSearchLimit
+	}
+
+	return data.SearchChatArchive(query)
+}
+
+// IsValidChatExportFormat will return if a format is one of the chat export
+// formats.
+func IsValidChatExportFormat(format string) bool {
+	return format == ChatExportJSON || format == ChatExportCSV || format == ChatExportWebVTT
+}
+
+// ExportChatArchive will write all the archived chat events matching a
+// query in an export format. CSV and WebVTT exports include the time of each
+// event from the start of the stream, and WebVTT exports leave out the
+// events from before it.
+func ExportChatArchive(w io.Writer, query models.ChatArchiveQuery, format string, streamStart time.Time) error {
+	query.Limit = 0
+	entries, err := data.SearchChatArchive(query)
+	if err != nil {
+		return err
+	}
+
+	return writeChatExport(w, entries, format, streamStart)
+}
+
+func writeChatExport(w io.Writer, entries []models.ChatArchiveEntry, format string, streamStart time.Time) error {
+	switch format {
+	case ChatExportJSON:
+		return json.NewEncoder(w).Encode(entries)
+	case ChatExportCSV:
+		return exportChatArchiveCSV(w, entries, streamStart)
+	case ChatExportWebVTT:
+		return exportChatArchiveWebVTT(w, entries, streamStart)
+	}
+
+	return fmt.Errorf("%s is not a valid chat export format", format)
+}
+
+func exportChatArchiveCSV(w io.Writer, entries []models.ChatArchiveEntry, streamStart time.Time) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"id", "timestamp", "offset", "type", "userId", "displayName", "text"}); err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		record := []string{
+			entry.ID,
+			entry.Timestamp.Format(time.RFC3339),
+			formatSubtitleTimestamp(entry.Timestamp.Sub(streamStart)),
+			entry.Type,
+			entry.UserID,
+			entry.DisplayName,
+			automodPlainText(entry.Body),
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+func exportChatArchiveWebVTT(w io.Writer, entries []models.ChatArchiveEntry, streamStart time.Time) error {
+	var b strings.Builder
+	b.WriteString("WEBVTT\n")
+
+	for _, entry := range entries {
+		offset := entry.Timestamp.Sub(streamStart)
+		if offset < 0 {
+			continue
+		}
+
+		text := automodPlainText(entry.Body)
+		if entry.DisplayName != "" {
+			text = entry.DisplayName + ": " + text
+		}
+
+		fmt.Fprintf(&b, "\n%s\n%s --> %s\n%s\n", entry.ID, formatSubtitleTimestamp(offset), formatSubtitleTimestamp(offset+chatSubtitleCueDuration), escapeSubtitleText(text))
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// formatSubtitleTimestamp will return a duration as a WebVTT timestamp,
+// hh:mm:ss.ttt. Negative durations are returned with a leading minus sign.
+func formatSubtitleTimestamp(d time.Duration) string {
+	sign := ""
+	if d < 0 {
+		sign = "-"
+		d = -d
+	}
+
+	milliseconds := d.Milliseconds()
+	return fmt.Sprintf("%s%02d:%02d:%02d.%03d", sign, milliseconds/3600000, milliseconds/60000%60, milliseconds/1000%60, milliseconds%1000)
+}
+
+// escapeSubtitleText will make text safe to use as the payload of a WebVTT
+// cue, which cannot contain markup, blank lines or the cue timing arrow.
+func escapeSubtitleText(text string) string {
+	text = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(text)
+
+	lines := []string{}
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,42 @@
+package chat
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/owncast/owncast/models"
+)
+
+func TestFormatSubtitleTimestamp(t *testing.T) {
+	tests := map[time.Duration]string{
+		0:                       "00:00:00.000",
+		1500 * time.Millisecond: "00:00:01.500",
+		time.Hour + 2*time.Minute + 3*time.Second: "01:02:03.000",
+		-2 * time.Second: "-00:00:02.000",
+	}
+
+	for duration, expected := range tests {
+		if result := formatSubtitleTimestamp(duration); result != expected {
+			t.Errorf("%s: expected %s, got %s", duration, expected, result)
+		}
+	}
+}
+
+func TestWebVTTChatExport(t *testing.T) {
+	start := time.Date(2026, 1, 1, 20, 0, 0, 0, time.UTC)
+	entries := []models.ChatArchiveEntry{
+		{ID: "before", Timestamp: start.Add(-time.Minute), DisplayName: "early", Body: "<p>too soon</p>"},
+		{ID: "first", Timestamp: start.Add(90 * time.Second), DisplayName: "viewer", Body: "<p>hello &lt;world&gt; --&gt; there</p>"},
+	}
+
+	var b bytes.Buffer
+	if err := writeChatExport(&b, entries, ChatExportWebVTT, start); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "WEBVTT\n\nfirst\n00:01:30.000 --> 00:01:35.000\nviewer: hello &lt;world&gt; --&gt; there\n"
+	if b.String() != expected {
+		t.Errorf("unexpected export:\n%q\nexpected:\n%q", b.String(), expected)
+	}
+}
@@ -0,0 +1,310 @@
+package chat
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/teris-io/shortid"
+	"mvdan.cc/xurls"
+
+	"github.com/owncast/owncast/core/chat/events"
+	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/core/user"
+	"github.com/owncast/owncast/models"
+)
+
+const (
+	defaultAutomodCapsThreshold   = 70
+	defaultAutomodEmojiThreshold  = 10
+	defaultAutomodRepeatThreshold = 10
+
+	// automodCapsMinimumLetters is how many letters a message needs before
+	// the caps rule looks at it, so short messages such as "LOL" are allowed.
+	automodCapsMinimumLetters = 10
+
+	automodLogSource = "automod"
+)
+
+var (
+	_automodRegexCache   = map[string]*regexp.Regexp{}
+	_automodRegexCacheMu sync.Mutex
+
+	anchorTagRegex = regexp.MustCompile(`(?i)<a\s`)
+
+	// userHasSentMessages will return if a user has ever had a chat message
+	// saved.
+	userHasSentMessages = func(userID string) bool {
+		sent, err := user.HasSentMessage(userID)
+		if err != nil {
+			log.Errorln("error checking for previous chat messages", err)
+			return true
+		}
+		return sent
+	}
+)
+
+// SetAutomodRules will validate and save the automatic chat moderation rules.
+// Rules without an ID are given one.
+func SetAutomodRules(rules []models.AutomodRule) ([]models.AutomodRule, error) {
+	validated := make([]models.AutomodRule, 0, len(rules))
+
+	for _, rule := range rules {
+		switch rule.Action {
+		case models.AutomodActionDrop, models.AutomodActionHide, models.AutomodActionHold:
+		default:
+			return nil, fmt.Errorf("%q is not a valid automod action", rule.Action)
+		}
+
+		switch rule.Type {
+		case models.AutomodBlockedWords:
+			words := []string{}
+			for _, word := range rule.Words {
+				if word = strings.TrimSpace(word); word != "" {
+					words = append(words, word)
+				}
+			}
+			if len(words) == 0 {
+				return nil, errors.New("blocked words rules need at least one word")
+			}
+			rule.Words = words
+		case models.AutomodRegex:
+			if _, err := getAutomodRegex(rule.Pattern); err != nil {
+				return nil, errors.Wrap(err, "invalid automod pattern")
+			}
+		case models.AutomodLinks, models.AutomodFirstMessage:
+		case models.AutomodCaps:
+			if rule.Threshold <= 0 {
+				rule.Threshold = defaultAutomodCapsThreshold
+			}
+			if rule.Threshold > 100 {
+				return nil, errors.New("caps rules need a threshold between 1 and 100 percent")
+			}
+		case models.AutomodEmoji:
+			if rule.Threshold <= 0 {
+				rule.Threshold = defaultAutomodEmojiThreshold
+			}
+		case models.AutomodRepeat:
+			if rule.Threshold <= 1 {
+				rule.Threshold = defaultAutomodRepeatThreshold
+			}
+		default:
+			return nil, fmt.Errorf("%q is not a valid automod rule type", rule.Type)
+		}
+
+		if rule.ID == "" {
+			rule.ID = shortid.MustGenerate()
+		}
+
+		validated = append(validated, rule)
+	}
+
+	if err := data.SetAutomodRules(validated); err != nil {
+		return nil, err
+	}
+
+	return validated, nil
+}
+
+// checkAutomod will return the first enabled rule a message breaks, and why.
+func checkAutomod(rules []models.AutomodRule, u *user.User, body string) (*models.AutomodRule, string, bool) {
+	text := automodPlainText(body)
+
+	for i := range rules {
+		rule := rules[i]
+		if !rule.Enabled {
+			continue
+		}
+
+		if reason, matched := automodRuleMatches(rule, u, body, text); matched {
+			return &rule, reason, true
+		}
+	}
+
+	return nil, "", false
+}
+
+func automodRuleMatches(rule models.AutomodRule, u *user.User, body, text string) (string, bool) {
+	switch rule.Type {
+	case models.AutomodBlockedWords:
+		for _, word := range rule.Words {
+			pattern := `(?i)(^|\W)` + regexp.QuoteMeta(word) + `($|\W)`
+			if re, err := getAutomodRegex(pattern); err == nil && re.MatchString(text) {
+				return fmt.Sprintf("contains the blocked word %q", word), true
+			}
+		}
+	case models.AutomodRegex:
+		if re, err := getAutomodRegex(rule.Pattern); err == nil && re.MatchString(text) {
+			return fmt.Sprintf("matches the pattern %q", rule.Pattern), true
+		}
+	case models.AutomodLinks:
+		if u != nil && u.IsModerator() {
+			return "", false
+		}
+		if anchorTagRegex.MatchString(body) || xurls.Strict.MatchString(text) {
+			return "contains a link", true
+		}
+	case models.AutomodFirstMessage:
+		if u != nil && !u.IsModerator() && !userHasSentMessages(u.ID) {
+			return "is the first message from this user", true
+		}
+	case models.AutomodCaps:
+		letters, capitals := 0, 0
+		for _, r := range text {
+			if unicode.IsLetter(r) {
+				letters++
+				if unicode.IsUpper(r) {
+					capitals++
+				}
+			}
+		}
+		if letters >= automodCapsMinimumLetters && capitals*100 >= rule.Threshold*letters {
+			return fmt.Sprintf("is %d%% capital letters", capitals*100/letters), true
+		}
+	case models.AutomodEmoji:
+		if count := countAutomodEmoji(body, text); count >= rule.Threshold {
+			return fmt.Sprintf("contains %d emoji", count), true
+		}
+	case models.AutomodRepeat:
+		if count := longestAutomodRepeat(text); count >= rule.Threshold {
+			return fmt.Sprintf("repeats the same thing %d times in a row", count), true
+		}
+	}
+
+	return "", false
+}
+
+// automodPlainText will return the text of a rendered message body without
+// any markup.
+func automodPlainText(body string) string {
+	text := bluemonday.StrictPolicy().Sanitize(body)
+	text = html.UnescapeString(text)
+	return strings.ReplaceAll(text, "\u00a0", " ")
+}
+
+func getAutomodRegex(pattern string) (*regexp.Regexp, error) {
+	_automodRegexCacheMu.Lock()
+	defer _automodRegexCacheMu.Unlock()
+
+	if re, exists := _automodRegexCache[pattern]; exists {
+		return re, nil
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	_automodRegexCache[pattern] = re
+
+	return re, nil
+}
+
+// countAutomodEmoji will return the number of custom emoji images and
+// unicode emoji in a message.
+func countAutomodEmoji(body, text string) int {
+	count := len(customEmojiRegex.FindAllStringIndex(body, -1))
+	for _, r := range text {
+		if unicode.Is(unicode.So, r) {
+			count++
+		}
+	}
+
+	return count
+}
+
+// longestAutomodRepeat will return the longest run of the same character, or
+// of the same word, in a message.
+func longestAutomodRepeat(text string) int {
+	longest := 0
+
+	run := 0
+	var previous rune
+	for _, r := range strings.ToLower(text) {
+		if r == previous && !unicode.IsSpace(r) {
+			run++
+		} else {
+			run = 1
+		}
+		previous = r
+		if run > longest {
+			longest = run
+		}
+	}
+
+	run = 0
+	previousWord := ""
+	for _, word := range strings.Fields(strings.ToLower(text)) {
+		if word == previousWord {
+			run++
+		} else {
+			run = 1
+		}
+		previousWord = word
+		if run > longest {
+			longest = run
+		}
+	}
+
+	return longest
+}
+
+// applyAutomod will run a message through the automod rules and act on the
+// first one it breaks. Returns false if the message should not be sent to
+// chat.
+func (s *Server) applyAutomod(client *Client, event *events.UserMessageEvent) bool {
+	rule, reason, matched := checkAutomod(data.GetAutomodRules(), event.User, event.Body)
+	if !matched {
+		return true
+	}
+
+	logEntry := models.ModerationLogEntry{
+		Timestamp: time.Now(),
+		UserID:    event.User.ID,
+		MessageID: event.ID,
+		Body:      event.Body,
+		Source:    automodLogSource + ":" + rule.ID,
+		Reason:    "Message " + reason,
+		Action:    rule.Action,
+		ChannelID: event.ChannelID,
+	}
+	if err := data.InsertModerationLogEntry(logEntry); err != nil {
+		log.Errorln("error saving automod moderation log entry", err)
+	}
+
+	switch rule.Action {
+	case models.AutomodActionHide:
+		// The sender still sees their message so they are not tipped off.
+		now := time.Now()
+		event.HiddenAt = &now
+		SaveUserMessage(*event)
+		s.Send(event.GetBroadcastPayload(), client)
+	case models.AutomodActionHold:
+		if err := s.holdMessage(event, logEntry.Reason); err != nil {
+			log.Errorln("error holding chat message for review", err)
+			s.sendActionToClient(client, "Your message was not sent because it breaks the chat rules.")
+			return false
+		}
+		s.sendActionToClient(client, "Your message is waiting to be reviewed by a moderator.")
+	default:
+		s.sendActionToClient(client, "Your message was not sent because it breaks the chat rules.")
+	}
+
+	return false
+}
+
+// GetModerationLog will return the most recent moderation actions.
+func GetModerationLog(limit int) []models.ModerationLogEntry {
+	entries, err := data.GetModerationLog(limit)
+	if err != nil {
+		log.Errorln("error fetching moderation log", err)
+	}
+
+	return entries
+}
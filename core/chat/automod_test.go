@@ -0,0 +1,84 @@
+package chat
+
+import (
+	"testing"
+
+	"github.com/owncast/owncast/core/user"
+	"github.com/owncast/owncast/models"
+)
+
+func TestCheckAutomod(t *testing.T) {
+	rules := []models.AutomodRule{
+		{ID: "words", Type: models.AutomodBlockedWords, Action: models.AutomodActionDrop, Words: []string{"badword"}, Enabled: true},
+		{ID: "regex", Type: models.AutomodRegex, Action: models.AutomodActionHide, Pattern: `(?i)buy\s+followers`, Enabled: true},
+		{ID: "links", Type: models.AutomodLinks, Action: models.AutomodActionHold, Enabled: true},
+		{ID: "caps", Type: models.AutomodCaps, Action: models.AutomodActionHide, Threshold: 70, Enabled: true},
+		{ID: "emoji", Type: models.AutomodEmoji, Action: models.AutomodActionDrop, Threshold: 3, Enabled: true},
+		{ID: "repeat", Type: models.AutomodRepeat, Action: models.AutomodActionDrop, Threshold: 5, Enabled: true},
+		{ID: "disabled", Type: models.AutomodBlockedWords, Action: models.AutomodActionDrop, Words: []string{"hello"}, Enabled: false},
+	}
+
+	viewer := &user.User{ID: "viewer"}
+	moderator := &user.User{ID: "moderator", Scopes: []string{"MODERATOR"}}
+
+	tests := []struct {
+		name string
+		user *user.User
+		body string
+		rule string
+	}{
+		{"clean message", viewer, "<p>hello there</p>", ""},
+		{"blocked word", viewer, "<p>what a BadWord to say</p>", "words"},
+		{"blocked word inside another word", viewer, "<p>notbadwordy</p>", ""},
+		{"regex", viewer, "<p>Buy   followers here</p>", "regex"},
+		{"link", viewer, `<p>see <a href="https://owncast.online">this</a></p>`, "links"},
+		{"plain link", viewer, "<p>see https://owncast.online</p>", "links"},
+		{"link from moderator", moderator, "<p>see https://owncast.online</p>", ""},
+		{"caps", viewer, "<p>THIS IS SO EXCITING</p>", "caps"},
+		{"short caps", viewer, "<p>LOL</p>", ""},
+		{"emoji", viewer, `<p><img class="emoji" src="/a.gif"> ✨✨</p>`, "emoji"},
+		{"repeated characters", viewer, "<p>nooooooo</p>", "repeat"},
+		{"repeated words", viewer, "<p>go go go go go</p>", "repeat"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rule, reason, matched := checkAutomod(rules, test.user, test.body)
+			if test.rule == "" {
+				if matched {
+					t.Errorf("expected no rule to match, got %s (%s)", rule.ID, reason)
+				}
+				return
+			}
+
+			if !matched {
+				t.Fatalf("expected rule %s to match", test.rule)
+			}
+			if rule.ID != test.rule {
+				t.Errorf("expected rule %s to match, got %s (%s)", test.rule, rule.ID, reason)
+			}
+		})
+	}
+}
+
+func TestCheckAutomodFirstMessage(t *testing.T) {
+	hasSentMessages := userHasSentMessages
+	defer func() { userHasSentMessages = hasSentMessages }()
+	userHasSentMessages = func(userID string) bool {
+		return userID == "regular"
+	}
+
+	rules := []models.AutomodRule{
+		{ID: "first", Type: models.AutomodFirstMessage, Action: models.AutomodActionHold, Enabled: true},
+	}
+
+	if _, _, matched := checkAutomod(rules, &user.User{ID: "newcomer"}, "<p>hi</p>"); !matched {
+		t.Error("expected the first message from a new user to match")
+	}
+	if _, _, matched := checkAutomod(rules, &user.User{ID: "regular"}, "<p>hi</p>"); matched {
+		t.Error("expected a message from a returning user not to match")
+	}
+	if _, _, matched := checkAutomod(rules, &user.User{ID: "newmod", Scopes: []string{"MODERATOR"}}, "<p>hi</p>"); matched {
+		t.Error("expected a message from a moderator not to match")
+	}
+}
@@ -0,0 +1,396 @@
+package chat
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/owncast/owncast/core/chat/events"
+	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/core/user"
+	"github.com/owncast/owncast/models"
+)
+
+const (
+	defaultCommandTimeoutDuration = 5 * time.Minute
+	maxCommandTimeoutDuration     = 24 * time.Hour
+)
+
+func registerBuiltInCommands() {
+	for _, command := range []*Command{
+		{
+			Name:        "help",
+			Description: "List the commands you can use.",
+			Scope:       CommandScopeUser,
+			Run:         helpCommand,
+		},
+		{
+			Name:        "me",
+			Usage:       "<action>",
+			Description: "Describe something you are doing.",
+			Scope:       CommandScopeUser,
+			Run:         meCommand,
+		},
+		{
+			Name:        "timeout",
+			Usage:       "<name> [seconds]",
+			Description: "Stop a user from sending messages for a while. Defaults to five minutes.",
+			Scope:       CommandScopeModerator,
+			Run:         timeoutCommand,
+		},
+		{
+			Name:        "ban",
+			Usage:       "<name> [seconds]",
+			Description: "Remove a user from chat and ban their IP address. Bans are permanent unless a duration is given.",
+			Scope:       CommandScopeModerator,
+			Run:         banCommand,
+		},
+		{
+			Name:        "unban",
+			Usage:       "<name>",
+			Description: "Allow a banned user back into chat.",
+			Scope:       CommandScopeModerator,
+			Run:         unbanCommand,
+		},
+		{
+			Name:        "clear",
+			Description: "Hide all the messages in this chat.",
+			Scope:       CommandScopeModerator,
+			Run:         clearCommand,
+		},
+		{
+			Name:        "slow",
+			Usage:       "<seconds|off>",
+			Description: "Require users to wait between messages in the chat of every channel.",
+			Scope:       CommandScopeModerator,
+			Run:         slowCommand,
+		},
+		{
+			Name:        "emoteonly",
+			Usage:       "[on|off]",
+			Description: "Only allow messages made up of custom emoji in the chat of every channel.",
+			Scope:       CommandScopeModerator,
+			Run:         emoteOnlyCommand,
+		},
+		{
+			Name:        "authonly",
+			Usage:       "[on|off]",
+			Description: "Only allow authenticated users to send messages in the chat of every channel.",
+			Scope:       CommandScopeModerator,
+			Run:         authenticatedOnlyCommand,
+		},
+	} {
+		if err := RegisterCommand(command); err != nil {
+			log.Errorln(err)
+		}
+	}
+}
+
+func helpCommand(ctx *CommandContext, args []string) {
+	lines := []string{"Available commands:"}
+	for _, command := range GetCommands() {
+		if !ctx.HasScope(command.Scope) {
+			continue
+		}
+
+		usage := "/" + command.Name
+		if command.Usage != "" {
+			usage += " " + command.Usage
+		}
+		lines = append(lines, fmt.Sprintf("`%s` %s", usage, command.Description))
+	}
+
+	ctx.Reply(strings.Join(lines, "<br/>"))
+}
+
+func meCommand(ctx *CommandContext, args []string) {
+	if len(args) == 0 {
+		ctx.Reply("Usage: `/me <action>`")
+		return
+	}
+
+	action := strings.Join(args, " ")
+
+	// Actions from chat clients are held to the same rules as their
+	// messages. Those acted on by automod are hidden or held as messages.
+	if ctx.Client != nil {
+		event := events.UserMessageEvent{
+			MessageEvent: events.MessageEvent{Body: events.RenderAndSanitize(action)},
+		}
+		event.SetDefaults()
+		event.User = ctx.User
+		event.RoomID = ctx.RoomID
+		event.ClientID = ctx.Client.Id
+		event.ChannelID = ctx.ChannelID
+
+		if !_server.canSendUserMessage(ctx.Client, &event) || !_server.applyAutomod(ctx.Client, &event) {
+			return
+		}
+	}
+
+	message := events.ActionEvent{
+		MessageEvent: events.MessageEvent{
+			Body: events.RenderAndSanitize(fmt.Sprintf("**%s** %s", ctx.User.DisplayName, action)),
+		},
+	}
+	message.SetDefaults()
+
+	if err := _server.BroadcastToChatRoom(message.GetBroadcastPayload(), ctx.ChannelID, ctx.RoomID); err != nil {
+		log.Errorln("error sending /me chat action", err)
+		return
+	}
+
+	saveEvent(message.ID, nil, message.Body, message.GetMessageType(), nil, message.Timestamp, nil, nil, nil, nil, nil, ctx.ChannelID, ctx.RoomID)
+}
+
+func timeoutCommand(ctx *CommandContext, args []string) {
+	if len(args) == 0 {
+		ctx.Reply("Usage: `/timeout <name> [seconds]`")
+		return
+	}
+
+	duration := defaultCommandTimeoutDuration
+	if len(args) > 1 {
+		var err error
+		if duration, err = parseCommandDuration(args[1]); err != nil || duration <= 0 || duration > maxCommandTimeoutDuration {
+			ctx.Reply(fmt.Sprintf("**%s** is not a valid timeout. Use a number of seconds up to a day.", args[1]))
+			return
+		}
+	}
+
+	target, clients, ok := findCommandTarget(ctx, args[0])
+	if !ok {
+		return
+	}
+
+	_server.TimeoutUser(target.ID, time.Now().Add(duration))
+	for _, client := range clients {
+		client.sendAction(fmt.Sprintf("You have been timed out from chat for %s.", duration))
+	}
+
+	ctx.Reply(fmt.Sprintf("**%s** has been timed out for %s.", target.DisplayName, duration))
+}
+
+func banCommand(ctx *CommandContext, args []string) {
+	if len(args) == 0 {
+		ctx.Reply("Usage: `/ban <name> [seconds]`")
+		return
+	}
+
+	var duration time.Duration
+	if len(args) > 1 {
+		var err error
+		if duration, err = parseCommandDuration(args[1]); err != nil || duration <= 0 {
+			ctx.Reply(fmt.Sprintf("**%s** is not a valid ban duration. Use a number of seconds.", args[1]))
+			return
+		}
+	}
+
+	target, _, ok := findCommandTarget(ctx, args[0])
+	if !ok {
+		return
+	}
+
+	if err := DisableUser(target.ID, duration); err != nil {
+		log.Errorln("error banning user from chat command", err)
+		ctx.Reply(fmt.Sprintf("Unable to ban **%s**.", target.DisplayName))
+		return
+	}
+
+	if duration > 0 {
+		ctx.Reply(fmt.Sprintf("**%s** has been banned for %s.", target.DisplayName, duration))
+	} else {
+		ctx.Reply(fmt.Sprintf("**%s** has been banned.", target.DisplayName))
+	}
+}
+
+func unbanCommand(ctx *CommandContext, args []string) {
+	if len(args) == 0 {
+		ctx.Reply("Usage: `/unban <name>`")
+		return
+	}
+
+	name := strings.TrimPrefix(strings.Join(args, " "), "@")
+	for _, disabledUser := range user.GetDisabledUsers() {
+		if !strings.EqualFold(disabledUser.DisplayName, name) {
+			continue
+		}
+
+		if err := EnableUser(disabledUser.ID); err != nil {
+			log.Errorln("error unbanning user from chat command", err)
+			ctx.Reply(fmt.Sprintf("Unable to unban **%s**.", disabledUser.DisplayName))
+			return
+		}
+
+		ctx.Reply(fmt.Sprintf("**%s** has been unbanned.", disabledUser.DisplayName))
+		return
+	}
+
+	ctx.Reply(fmt.Sprintf("There is no banned user named **%s**.", name))
+}
+
+func clearCommand(ctx *CommandContext, args []string) {
+	ids := []string{}
+	for _, message := range GetChatRoomHistory(ctx.ChannelID, ctx.RoomID) {
+		if message, ok := message.(events.UserMessageEvent); ok {
+			ids = append(ids, message.ID)
+		}
+	}
+
+	if len(ids) > 0 {
+		if err := SetMessagesVisibility(ids, false); err != nil {
+			log.Errorln("error clearing chat from chat command", err)
+			ctx.Reply("Unable to clear the chat.")
+			return
+		}
+	}
+
+	if err := SendSystemActionToChatRoom(ctx.ChannelID, ctx.RoomID, "The chat has been cleared by a moderator.", true); err != nil {
+		log.Errorln(err)
+	}
+}
+
+func slowCommand(ctx *CommandContext, args []string) {
+	modes := data.GetChatModes()
+
+	if len(args) == 0 {
+		if modes.SlowModeSeconds > 0 {
+			ctx.Reply(fmt.Sprintf("Slow mode is on. Users must wait %d seconds between messages.", modes.SlowModeSeconds))
+		} else {
+			ctx.Reply("Slow mode is off.")
+		}
+		return
+	}
+
+	modes.SlowModeSeconds = 0
+	if !strings.EqualFold(args[0], "off") {
+		duration, err := parseCommandDuration(args[0])
+		if err != nil || duration < 0 || duration > maxSlowModeSeconds*time.Second {
+			ctx.Reply(fmt.Sprintf("**%s** is not a valid slow mode. Use a number of seconds up to an hour, or off.", args[0]))
+			return
+		}
+		modes.SlowModeSeconds = int(duration.Seconds())
+	}
+
+	message := "Slow mode is now off."
+	if modes.SlowModeSeconds > 0 {
+		message = fmt.Sprintf("Slow mode is now on. You can send a message every %d seconds.", modes.SlowModeSeconds)
+	}
+
+	setChatModesFromCommand(ctx, modes, message)
+}
+
+func emoteOnlyCommand(ctx *CommandContext, args []string) {
+	modes := data.GetChatModes()
+
+	enabled, ok := parseCommandToggle(ctx, "emoteonly", modes.EmoteOnly, args)
+	if !ok {
+		return
+	}
+	modes.EmoteOnly = enabled
+
+	message := "Emote-only mode is now off."
+	if enabled {
+		message = "Emote-only mode is now on. Messages can only contain custom emoji."
+	}
+
+	setChatModesFromCommand(ctx, modes, message)
+}
+
+func authenticatedOnlyCommand(ctx *CommandContext, args []string) {
+	modes := data.GetChatModes()
+
+	enabled, ok := parseCommandToggle(ctx, "authonly", modes.AuthenticatedOnly, args)
+	if !ok {
+		return
+	}
+	modes.AuthenticatedOnly = enabled
+
+	message := "Authenticated-only mode is now off."
+	if enabled {
+		message = "Authenticated-only mode is now on. Only authenticated users can send messages."
+	}
+
+	setChatModesFromCommand(ctx, modes, message)
+}
+
+// parseCommandToggle will return the on or off state requested by a command
+// argument, or the opposite of the current state if none was given.
+func parseCommandToggle(ctx *CommandContext, name string, current bool, args []string) (bool, bool) {
+	if len(args) == 0 {
+		return !current, true
+	}
+
+	switch strings.ToLower(args[0]) {
+	case "on":
+		return true, true
+	case "off":
+		return false, true
+	default:
+		ctx.Reply(fmt.Sprintf("Usage: `/%s [on|off]`", name))
+		return false, false
+	}
+}
+
+// setChatModesFromCommand will save chat modes changed by a command. The
+// modes apply to the chat of every channel, but are only announced in the
+// chat of the channel the command was sent from.
+func setChatModesFromCommand(ctx *CommandContext, modes models.ChatModes, message string) {
+	if err := SetChatModes(modes); err != nil {
+		log.Errorln("error setting chat modes from chat command", err)
+		ctx.Reply("Unable to change the chat modes.")
+		return
+	}
+
+	if err := SendSystemActionToChannel(ctx.ChannelID, message, true); err != nil {
+		log.Errorln(err)
+	}
+}
+
+// parseCommandDuration parses either a number of seconds or a duration such
+// as "10m".
+func parseCommandDuration(value string) (time.Duration, error) {
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second, nil
+	}
+
+	return time.ParseDuration(value)
+}
+
+// findCommandTarget will return the connected user named by a moderation
+// command along with their chat clients. Moderators are not able to moderate
+// themselves or each other.
+func findCommandTarget(ctx *CommandContext, name string) (*user.User, []*Client, bool) {
+	name = strings.TrimPrefix(name, "@")
+
+	var target *user.User
+	clients := []*Client{}
+	for _, client := range GetClients() {
+		if client.User == nil || !strings.EqualFold(client.User.DisplayName, name) {
+			continue
+		}
+
+		target = client.User
+		clients = append(clients, client)
+	}
+
+	if target == nil {
+		ctx.Reply(fmt.Sprintf("There is nobody named **%s** in chat.", name))
+		return nil, nil, false
+	}
+
+	if ctx.User != nil && ctx.User.ID == target.ID {
+		ctx.Reply("You cannot moderate yourself.")
+		return nil, nil, false
+	}
+
+	if target.IsModerator() && !ctx.IsAdmin {
+		ctx.Reply(fmt.Sprintf("**%s** is a moderator and cannot be moderated.", target.DisplayName))
+		return nil, nil, false
+	}
+
+	return target, clients, true
+}
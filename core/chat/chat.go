@@ -15,16 +15,17 @@ import (
 )
 
 var (
-	getStatus               func() models.Status
+	getStatus               func(channelID string) models.Status
 	chatMessagesSentCounter prometheus.Gauge
 )
 
 // Start begins the chat server.
-func Start(getStatusFunc func() models.Status) error {
+func Start(getStatusFunc func(channelID string) models.Status) error {
 	setupPersistence()
 
 	getStatus = getStatusFunc
 	_server = NewChat()
+	registerBuiltInCommands()
 
 	go _server.Run()
 
@@ -86,7 +87,8 @@ func GetClients() []*Client {
 	return clients
 }
 
-// SendSystemMessage will send a message string as a system message to all clients.
+// SendSystemMessage will send a message string as a system message to all
+// clients connected to the chat of the default channel.
 func SendSystemMessage(text string, ephemeral bool) error {
 	message := events.SystemMessageEvent{
 		MessageEvent: events.MessageEvent{
@@ -96,12 +98,12 @@ func SendSystemMessage(text string, ephemeral bool) error {
 	message.SetDefaults()
 	message.RenderBody()
 
-	if err := Broadcast(&message); err != nil {
+	if err := _server.BroadcastToChannel(message.GetBroadcastPayload(), models.DefaultChannelID); err != nil {
 		log.Errorln("error sending system message", err)
 	}
 
 	if !ephemeral {
-		saveEvent(message.ID, nil, message.Body, message.GetMessageType(), nil, message.Timestamp, nil, nil, nil, nil)
+		saveEvent(message.ID, nil, message.Body, message.GetMessageType(), nil, message.Timestamp, nil, nil, nil, nil, nil, models.DefaultChannelID, models.DefaultChatRoomID)
 	}
 
 	return nil
@@ -124,7 +126,7 @@ func SendFediverseAction(eventType string, userAccountName string, image *string
 	message.SetDefaults()
 	message.RenderBody()
 
-	if err := Broadcast(&message); err != nil {
+	if err := _server.BroadcastToChannel(message.GetBroadcastPayload(), models.DefaultChannelID); err != nil {
 		log.Errorln("error sending system message", err)
 		return err
 	}
@@ -134,8 +136,21 @@ func SendFediverseAction(eventType string, userAccountName string, image *string
 	return nil
 }
 
-// SendSystemAction will send a system action string as an action event to all clients.
+// SendSystemAction will send a system action string as an action event to
+// all clients connected to the chat of the default channel.
 func SendSystemAction(text string, ephemeral bool) error {
+	return SendSystemActionToChannel(models.DefaultChannelID, text, ephemeral)
+}
+
+// SendSystemActionToChannel will send a system action string as an action
+// event to all clients connected to the chat of a channel.
+func SendSystemActionToChannel(channelID string, text string, ephemeral bool) error {
+	return SendSystemActionToChatRoom(channelID, models.DefaultChatRoomID, text, ephemeral)
+}
+
+// SendSystemActionToChatRoom will send a system action string as an action
+// event to a chat room of a channel.
+func SendSystemActionToChatRoom(channelID string, roomID string, text string, ephemeral bool) error {
 	message := events.ActionEvent{
 		MessageEvent: events.MessageEvent{
 			Body: text,
@@ -145,20 +160,20 @@ func SendSystemAction(text string, ephemeral bool) error {
 	message.SetDefaults()
 	message.RenderBody()
 
-	if err := Broadcast(&message); err != nil {
+	if err := _server.BroadcastToChatRoom(message.GetBroadcastPayload(), channelID, roomID); err != nil {
 		log.Errorln("error sending system chat action")
 	}
 
 	if !ephemeral {
-		saveEvent(message.ID, nil, message.Body, message.GetMessageType(), nil, message.Timestamp, nil, nil, nil, nil)
+		saveEvent(message.ID, nil, message.Body, message.GetMessageType(), nil, message.Timestamp, nil, nil, nil, nil, nil, channelID, roomID)
 	}
 
 	return nil
 }
 
-// SendAllWelcomeMessage will send the chat message to all connected clients.
-func SendAllWelcomeMessage() {
-	_server.sendAllWelcomeMessage()
+// SendAllWelcomeMessage will send the chat message to all clients connected to the chat of a channel.
+func SendAllWelcomeMessage(channelID string) {
+	_server.sendAllWelcomeMessage(channelID)
 }
 
 // SendSystemMessageToClient will send a single message to a single connected chat client.
@@ -168,9 +183,16 @@ func SendSystemMessageToClient(clientID uint, text string) {
 	}
 }
 
-// Broadcast will send all connected clients the outbound object provided.
+// Broadcast will send all clients connected to the chat of the default
+// channel the outbound object provided.
 func Broadcast(event events.OutboundEvent) error {
-	return _server.Broadcast(event.GetBroadcastPayload())
+	return _server.BroadcastToChannel(event.GetBroadcastPayload(), models.DefaultChannelID)
+}
+
+// BroadcastToChatRoom will send all clients in a chat room of a channel the
+// outbound object provided.
+func BroadcastToChatRoom(event events.OutboundEvent, channelID string, roomID string) error {
+	return _server.BroadcastToChatRoom(event.GetBroadcastPayload(), channelID, roomID)
 }
 
 // HandleClientConnection handles a single inbound websocket connection.
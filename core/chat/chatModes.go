@@ -0,0 +1,83 @@
+package chat
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/owncast/owncast/core/chat/events"
+	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/core/user"
+	"github.com/owncast/owncast/models"
+)
+
+// maxSlowModeSeconds is the longest users can be made to wait between messages.
+const maxSlowModeSeconds = 60 * 60
+
+var (
+	customEmojiRegex    = regexp.MustCompile(`<img[^>]*\bclass="emoji"[^>]*>`)
+	emptyParagraphRegex = regexp.MustCompile(`</?p>|<br\s*/?>`)
+)
+
+// SetChatModes will save new chat modes and let every connected client know
+// about the change.
+func SetChatModes(modes models.ChatModes) error {
+	if modes.SlowModeSeconds < 0 || modes.SlowModeSeconds > maxSlowModeSeconds {
+		return fmt.Errorf("slow mode must be between 0 and %d seconds", maxSlowModeSeconds)
+	}
+
+	if err := data.SetChatModes(modes); err != nil {
+		return err
+	}
+
+	event := events.ChatModesChangedEvent{Modes: modes}
+	event.SetDefaults()
+
+	return _server.Broadcast(event.GetBroadcastPayload())
+}
+
+// checkChatModes will return why a message is rejected by the chat modes, if
+// it is.
+func (s *Server) checkChatModes(u *user.User, event *events.UserMessageEvent) (string, bool) {
+	if u.IsModerator() {
+		return "", true
+	}
+
+	modes := data.GetChatModes()
+
+	if modes.AuthenticatedOnly && !u.Authenticated {
+		return "Only authenticated users can send messages right now. Authenticate to take part in chat.", false
+	}
+
+	if modes.EmoteOnly && !isEmoteOnlyMessage(event.Body) {
+		return "Emote-only mode is on. Messages can only contain custom emoji.", false
+	}
+
+	if modes.SlowModeSeconds > 0 {
+		slowModeDuration := time.Duration(modes.SlowModeSeconds) * time.Second
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if wait := slowModeDuration - time.Since(s.userLastMessageAt[u.ID]); wait > 0 {
+			return fmt.Sprintf("Slow mode is on. You can send another message in %d seconds.", events.GetRemainingSeconds(time.Now().Add(wait))), false
+		}
+		s.userLastMessageAt[u.ID] = time.Now()
+	}
+
+	return "", true
+}
+
+// isEmoteOnlyMessage will return if a rendered message body is made up of
+// nothing but custom emoji.
+func isEmoteOnlyMessage(body string) bool {
+	if !customEmojiRegex.MatchString(body) {
+		return false
+	}
+
+	remaining := customEmojiRegex.ReplaceAllString(body, "")
+	remaining = emptyParagraphRegex.ReplaceAllString(remaining, "")
+
+	return strings.TrimSpace(remaining) == ""
+}
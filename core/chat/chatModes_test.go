@@ -0,0 +1,25 @@
+package chat
+
+import (
+	"testing"
+
+	"github.com/owncast/owncast/core/chat/events"
+)
+
+func TestIsEmoteOnlyMessage(t *testing.T) {
+	tests := map[string]bool{
+		`<img class="emoji" src="/img/emoji/bananadance.gif">`:                                                true,
+		`<img class="emoji" src="/img/emoji/bananadance.gif"> <img class="emoji" src="/img/emoji/party.gif">`: true,
+		`<img class="emoji" src="/img/emoji/bananadance.gif"> nice`:                                           false,
+		`hello`:  false,
+		`:smile`: false,
+		``:       false,
+	}
+
+	for raw, expected := range tests {
+		body := events.RenderAndSanitize(raw)
+		if isEmoteOnlyMessage(body) != expected {
+			t.Errorf("%q rendered as %q: expected emote only to be %t", raw, body, expected)
+		}
+	}
+}
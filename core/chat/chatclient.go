@@ -31,10 +31,13 @@ type Client struct {
 	accessToken  string
 	IPAddress    string `json:"-"`
 	UserAgent    string `json:"userAgent"`
+	ChannelID    string `json:"channelId,omitempty"`
 	MessageCount int    `json:"messageCount"`
 	Id           uint   `json:"-"`
 	mu           sync.RWMutex
 	inTimeout    bool
+	// Chat rooms joined alongside the main chat, guarded by mu.
+	rooms map[string]bool
 }
 
 type chatClientEvent struct {
@@ -87,10 +90,6 @@ func (c *Client) sendConnectedClientInfo() {
 }
 
 func (c *Client) readPump() {
-	// Allow 3 messages every two seconds.
-	limit := rate.Every(2 * time.Second / 3)
-	c.rateLimiter = rate.NewLimiter(limit, 1)
-
 	defer func() {
 		c.close()
 	}()
@@ -123,8 +122,7 @@ func (c *Client) readPump() {
 
 		// Guard against floods.
 		if !c.passesRateLimit() {
-			log.Warnln("Client", c.Id, c.User.DisplayName, "has exceeded the messaging rate limiting thresholds and messages are being rejected temporarily.")
-			c.startChatRejectionTimeout()
+			c.server.handleRateLimitExceeded(c)
 
 			continue
 		}
@@ -203,21 +201,34 @@ func (c *Client) passesRateLimit() bool {
 	return c.rateLimiter.Allow() && !c.inTimeout
 }
 
-func (c *Client) startChatRejectionTimeout() {
+// startChatRejectionTimeout will block the client from sending chat
+// messages for a short time after flooding, and return if a new rejection
+// started rather than one already in place.
+func (c *Client) startChatRejectionTimeout(duration time.Duration) bool {
 	if c.timeoutTimer != nil {
-		return
+		return false
 	}
 
-	c.inTimeout = true
-	c.timeoutTimer = time.NewTimer(10 * time.Second)
-	go func(c *Client) {
-		for range c.timeoutTimer.C {
-			c.inTimeout = false
-			c.timeoutTimer = nil
-		}
-	}(c)
-
+	if duration > 0 {
+		c.timeout(duration)
+	}
 	c.sendAction("You are temporarily blocked from sending chat messages due to perceived flooding.")
+
+	return true
+}
+
+// timeout will block the client from sending chat messages for a duration,
+// replacing any timeout already in place.
+func (c *Client) timeout(duration time.Duration) {
+	if c.timeoutTimer != nil {
+		c.timeoutTimer.Stop()
+	}
+
+	c.inTimeout = true
+	c.timeoutTimer = time.AfterFunc(duration, func() {
+		c.inTimeout = false
+		c.timeoutTimer = nil
+	})
 }
 
 func (c *Client) sendPayload(payload interface{}) {
@@ -0,0 +1,221 @@
+package chat
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/microcosm-cc/bluemonday"
+
+	"github.com/owncast/owncast/core/user"
+	"github.com/owncast/owncast/utils"
+)
+
+// CommandScope is the access a chat participant needs to run a command.
+type CommandScope int
+
+const (
+	// CommandScopeUser commands can be run by anybody taking part in chat.
+	CommandScopeUser CommandScope = iota
+	// CommandScopeModerator commands can be run by moderators and admin
+	// integrations.
+	CommandScopeModerator
+	// CommandScopeAdmin commands can only be run by integrations with admin
+	// access.
+	CommandScopeAdmin
+)
+
+// Command is a single slash command that can be sent as a chat message.
+type Command struct {
+	// Run performs the command with the whitespace separated arguments that
+	// followed it.
+	Run         func(ctx *CommandContext, args []string)
+	Name        string
+	Usage       string
+	Description string
+	Scope       CommandScope
+}
+
+// CommandContext is who ran a command and where.
+type CommandContext struct {
+	// Client is the chat client that sent the command. Nil when the command
+	// was sent by an integration.
+	Client    *Client
+	User      *user.User
+	reply     func(text string)
+	ChannelID string
+	// RoomID is the chat room the command was sent from.
+	RoomID  string
+	IsAdmin bool
+}
+
+// Reply will privately send text to whoever ran the command.
+func (c *CommandContext) Reply(text string) {
+	c.reply(text)
+}
+
+// HasScope will return if the command was run by somebody with the access
+// of the provided scope.
+func (c *CommandContext) HasScope(scope CommandScope) bool {
+	switch scope {
+	case CommandScopeUser:
+		return true
+	case CommandScopeModerator:
+		return c.IsAdmin || (c.User != nil && c.User.IsModerator())
+	default:
+		return c.IsAdmin
+	}
+}
+
+var (
+	_commands   = map[string]*Command{}
+	_commandsMu sync.RWMutex
+
+	commandNameRegex = regexp.MustCompile(`^[a-z][a-z0-9_-]*$`)
+)
+
+// RegisterCommand will make a slash command available in chat.
+func RegisterCommand(command *Command) error {
+	name := strings.ToLower(command.Name)
+	if !commandNameRegex.MatchString(name) {
+		return fmt.Errorf("invalid command name %q", command.Name)
+	}
+
+	if command.Run == nil {
+		return errors.New("command " + name + " has nothing to run")
+	}
+
+	_commandsMu.Lock()
+	defer _commandsMu.Unlock()
+
+	if _, exists := _commands[name]; exists {
+		return errors.New("command " + name + " is already registered")
+	}
+	_commands[name] = command
+
+	return nil
+}
+
+// GetCommands will return the registered commands sorted by name.
+func GetCommands() []*Command {
+	_commandsMu.RLock()
+	defer _commandsMu.RUnlock()
+
+	commands := make([]*Command, 0, len(_commands))
+	for _, command := range _commands {
+		commands = append(commands, command)
+	}
+
+	sort.Slice(commands, func(i, j int) bool {
+		return commands[i].Name < commands[j].Name
+	})
+
+	return commands
+}
+
+func getCommand(name string) (*Command, bool) {
+	_commandsMu.RLock()
+	defer _commandsMu.RUnlock()
+
+	command, exists := _commands[name]
+	return command, exists
+}
+
+// parseCommand will return the command name and arguments of a raw chat
+// message body, if it is a slash command. Chat clients may send the body as
+// HTML so markup is stripped before parsing.
+func parseCommand(rawBody string) (string, []string, bool) {
+	text := bluemonday.StrictPolicy().Sanitize(rawBody)
+	text = html.UnescapeString(text)
+	text = strings.TrimSpace(strings.ReplaceAll(text, "\u00a0", " "))
+
+	if !strings.HasPrefix(text, "/") {
+		return "", nil, false
+	}
+
+	fields := strings.Fields(text[1:])
+	if len(fields) == 0 {
+		return "", nil, false
+	}
+
+	// Messages such as "/r/owncast" or "/shrug/" are not commands.
+	name := strings.ToLower(fields[0])
+	if !commandNameRegex.MatchString(name) {
+		return "", nil, false
+	}
+
+	return name, fields[1:], true
+}
+
+// runCommand will run a parsed command if whoever sent it has access to it.
+func runCommand(ctx *CommandContext, name string, args []string) {
+	command, exists := getCommand(name)
+	if !exists {
+		ctx.Reply(fmt.Sprintf("**/%s** is not a command. Type **/help** to see the available commands.", name))
+		return
+	}
+
+	if !ctx.HasScope(command.Scope) {
+		ctx.Reply(fmt.Sprintf("You do not have permission to use **/%s**.", name))
+		return
+	}
+
+	command.Run(ctx, args)
+}
+
+// handleClientCommand will run a command sent as a chat message by a client.
+// Returns false if the message is not a command.
+func (s *Server) handleClientCommand(client *Client, u *user.User, rawBody string, roomID string) bool {
+	name, args, isCommand := parseCommand(rawBody)
+	if !isCommand {
+		return false
+	}
+
+	ctx := &CommandContext{
+		Client:    client,
+		User:      u,
+		ChannelID: client.ChannelID,
+		RoomID:    roomID,
+		reply: func(text string) {
+			s.sendActionToClient(client, text)
+		},
+	}
+	runCommand(ctx, name, args)
+
+	return true
+}
+
+// RunIntegrationCommand will run a command sent as a chat message by an
+// external integration to a chat room of a channel and return the replies
+// to it. Returns false if the message is not a command.
+func RunIntegrationCommand(integration user.ExternalAPIUser, rawBody string, channelID string, roomID string) (string, bool) {
+	name, args, isCommand := parseCommand(rawBody)
+	if !isCommand {
+		return "", false
+	}
+
+	_, isAdmin := utils.FindInSlice(integration.Scopes, user.ScopeHasAdminAccess)
+	replies := []string{}
+	ctx := &CommandContext{
+		User: &user.User{
+			ID:           integration.ID,
+			DisplayName:  integration.DisplayName,
+			DisplayColor: integration.DisplayColor,
+			CreatedAt:    integration.CreatedAt,
+			IsBot:        true,
+		},
+		IsAdmin:   isAdmin,
+		ChannelID: channelID,
+		RoomID:    roomID,
+		reply: func(text string) {
+			replies = append(replies, text)
+		},
+	}
+	runCommand(ctx, name, args)
+
+	return strings.Join(replies, "\n"), true
+}
@@ -0,0 +1,87 @@
+package chat
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/owncast/owncast/core/user"
+)
+
+func TestParseCommand(t *testing.T) {
+	tests := []struct {
+		body      string
+		name      string
+		args      []string
+		isCommand bool
+	}{
+		{body: "/help", name: "help", args: []string{}, isCommand: true},
+		{body: "  /Timeout @bob 60 ", name: "timeout", args: []string{"@bob", "60"}, isCommand: true},
+		{body: "/me&nbsp;waves <b>hello</b>", name: "me", args: []string{"waves", "hello"}, isCommand: true},
+		{body: "<p>/slow off</p>", name: "slow", args: []string{"off"}, isCommand: true},
+		{body: "hello /help", isCommand: false},
+		{body: "/r/owncast is great", isCommand: false},
+		{body: "/", isCommand: false},
+		{body: "", isCommand: false},
+	}
+
+	for _, test := range tests {
+		name, args, isCommand := parseCommand(test.body)
+		if isCommand != test.isCommand {
+			t.Errorf("%q: expected command %t, got %t", test.body, test.isCommand, isCommand)
+			continue
+		}
+
+		if !isCommand {
+			continue
+		}
+
+		if name != test.name || !reflect.DeepEqual(args, test.args) {
+			t.Errorf("%q: expected %s %v, got %s %v", test.body, test.name, test.args, name, args)
+		}
+	}
+}
+
+func TestCommandScopes(t *testing.T) {
+	viewer := &CommandContext{User: &user.User{}}
+	moderator := &CommandContext{User: &user.User{Scopes: []string{"MODERATOR"}}}
+	admin := &CommandContext{IsAdmin: true}
+
+	tests := []struct {
+		ctx      *CommandContext
+		expected []bool
+	}{
+		{ctx: viewer, expected: []bool{true, false, false}},
+		{ctx: moderator, expected: []bool{true, true, false}},
+		{ctx: admin, expected: []bool{true, true, true}},
+	}
+
+	for i, test := range tests {
+		for scope, expected := range test.expected {
+			if test.ctx.HasScope(CommandScope(scope)) != expected {
+				t.Errorf("context %d: expected access to scope %d to be %t", i, scope, expected)
+			}
+		}
+	}
+}
+
+func TestRegisterCommand(t *testing.T) {
+	command := &Command{Name: "testcommand", Run: func(*CommandContext, []string) {}}
+	if err := RegisterCommand(command); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := RegisterCommand(command); err == nil {
+		t.Error("expected registering a command twice to fail")
+	}
+
+	if err := RegisterCommand(&Command{Name: "not a name", Run: command.Run}); err == nil {
+		t.Error("expected registering an invalid command name to fail")
+	}
+
+	replies := []string{}
+	ctx := &CommandContext{User: &user.User{}, reply: func(text string) { replies = append(replies, text) }}
+	runCommand(ctx, "doesnotexist", nil)
+	if len(replies) != 1 {
+		t.Errorf("expected a reply for an unknown command, got %v", replies)
+	}
+}
@@ -0,0 +1,120 @@
+package chat
+
+import (
+	"encoding/json"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/owncast/owncast/core/chat/events"
+	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/core/user"
+	"github.com/owncast/owncast/models"
+)
+
+func (s *Server) userDirectMessageSent(eventData chatClientEvent) {
+	var event events.DirectMessageEvent
+	if err := json.Unmarshal(eventData.data, &event); err != nil {
+		log.Errorln("error unmarshalling to DirectMessageEvent", err)
+		return
+	}
+
+	event.SetDefaults()
+	event.RenderAndSanitizeMessageBody()
+	event.ClientID = eventData.client.Id
+	event.ChannelID = eventData.client.ChannelID
+
+	if event.Empty() {
+		return
+	}
+
+	event.User = user.GetUserByToken(eventData.client.accessToken)
+	if event.User == nil {
+		return
+	}
+
+	if until, timedOut := s.getUserTimeout(event.User.ID); timedOut {
+		s.sendActionToClient(eventData.client, fmt.Sprintf("You have been timed out and can send messages again in %d seconds.", events.GetRemainingSeconds(until)))
+		return
+	}
+
+	recipient := user.GetUserByID(event.RecipientID)
+	if recipient == nil || recipient.ID == event.User.ID || !recipient.IsEnabled() {
+		s.sendActionToClient(eventData.client, "Your message could not be sent to that user.")
+		return
+	}
+
+	// Direct messages are held to the same automod rules as chat messages,
+	// but are never held for review.
+	if _, _, matched := checkAutomod(data.GetAutomodRules(), event.User, event.Body); matched {
+		s.sendActionToClient(eventData.client, "Your message was not sent because it breaks the chat rules.")
+		return
+	}
+
+	if err := s.sendDirectMessage(event); err != nil {
+		log.Errorln("error sending direct message", err)
+		s.sendActionToClient(eventData.client, "Your message could not be sent to that user.")
+	}
+}
+
+// sendDirectMessage will save a direct message and send it to every chat
+// connection of both its sender and recipient.
+func (s *Server) sendDirectMessage(event events.DirectMessageEvent) error {
+	message := models.DirectMessage{
+		ID:          event.ID,
+		SenderID:    event.User.ID,
+		RecipientID: event.RecipientID,
+		Body:        event.Body,
+		Timestamp:   event.Timestamp,
+	}
+	if err := data.InsertDirectMessage(message); err != nil {
+		return err
+	}
+
+	payload := event.GetBroadcastPayload()
+	for _, userID := range []string{event.User.ID, event.RecipientID} {
+		clients, err := GetClientsForUser(userID)
+		if err != nil {
+			continue
+		}
+
+		for _, client := range clients {
+			s.Send(payload, client)
+		}
+	}
+
+	return nil
+}
+
+// GetDirectMessages will return the most recent direct messages sent or
+// received by a user, newest first. If otherUserID is set only the messages
+// between the two users are returned.
+func GetDirectMessages(userID string, otherUserID string, limit int) []events.DirectMessageEvent {
+	messages, err := data.GetDirectMessages(userID, otherUserID, limit)
+	if err != nil {
+		log.Errorln("error fetching direct messages", err)
+	}
+
+	users := map[string]*user.User{}
+	directMessages := make([]events.DirectMessageEvent, 0, len(messages))
+	for _, message := range messages {
+		sender, cached := users[message.SenderID]
+		if !cached {
+			sender = user.GetUserByID(message.SenderID)
+			users[message.SenderID] = sender
+		}
+
+		directMessages = append(directMessages, events.DirectMessageEvent{
+			Event: events.Event{
+				ID:        message.ID,
+				Timestamp: message.Timestamp,
+				Type:      events.DirectMessageSent,
+			},
+			UserEvent:    events.UserEvent{User: sender},
+			MessageEvent: events.MessageEvent{Body: message.Body},
+			RecipientID:  message.RecipientID,
+		})
+	}
+
+	return directMessages
+}
@@ -88,7 +88,7 @@ func (s *Server) userNameChanged(eventData chatClientEvent) {
 	broadcastEvent.User = savedUser
 	broadcastEvent.SetDefaults()
 	payload := broadcastEvent.GetBroadcastPayload()
-	if err := s.Broadcast(payload); err != nil {
+	if err := s.BroadcastToChannel(payload, eventData.client.ChannelID); err != nil {
 		log.Errorln("error broadcasting NameChangeEvent", err)
 		return
 	}
@@ -134,29 +134,52 @@ func (s *Server) userMessageSent(eventData chatClientEvent) {
 
 	event.SetDefaults()
 	event.ClientID = eventData.client.Id
+	event.ChannelID = eventData.client.ChannelID
 
 	// Ignore empty messages
 	if event.Empty() {
 		return
 	}
 
+	event.User = user.GetUserByToken(eventData.client.accessToken)
+
+	// Guard against nil users
+	if event.User == nil {
+		return
+	}
+
+	// Slash commands are run and never sent to chat.
+	if s.handleClientCommand(eventData.client, event.User, event.RawBody, event.RoomID) {
+		return
+	}
+
 	// Ignore if the stream has been offline
-	if !getStatus().Online && getStatus().LastDisconnectTime != nil {
-		disconnectedTime := getStatus().LastDisconnectTime.Time
+	status := getStatus(event.ChannelID)
+	if !status.Online && status.LastDisconnectTime != nil {
+		disconnectedTime := status.LastDisconnectTime.Time
 		if time.Since(disconnectedTime) > 5*time.Minute {
 			return
 		}
 	}
 
-	event.User = user.GetUserByToken(eventData.client.accessToken)
+	if !s.canSendUserMessage(eventData.client, &event) {
+		return
+	}
 
-	// Guard against nil users
-	if event.User == nil {
+	// Replies can only be made to visible messages in the same chat room.
+	if event.ReplyTo != "" {
+		if channelID, roomID, exists := getReactableMessageRoom(event.ReplyTo); !exists || channelID != event.ChannelID || roomID != event.RoomID {
+			event.ReplyTo = ""
+		}
+	}
+
+	// Run the automod rules. Messages they act on are not sent to chat.
+	if !s.applyAutomod(eventData.client, &event) {
 		return
 	}
 
 	payload := event.GetBroadcastPayload()
-	if err := s.Broadcast(payload); err != nil {
+	if err := s.BroadcastToChatRoom(payload, event.ChannelID, event.RoomID); err != nil {
 		log.Errorln("error broadcasting UserMessageEvent payload", err)
 		return
 	}
@@ -169,6 +192,31 @@ func (s *Server) userMessageSent(eventData chatClientEvent) {
 	eventData.client.MessageCount++
 }
 
+// canSendUserMessage will return if a message, or a /me action, from a
+// client passes the timeout, chat room and chat mode rules, letting the
+// client know when it does not.
+func (s *Server) canSendUserMessage(c *Client, event *events.UserMessageEvent) bool {
+	// Ignore messages from users that have been timed out.
+	if until, timedOut := s.getUserTimeout(event.User.ID); timedOut {
+		s.sendActionToClient(c, fmt.Sprintf("You have been timed out and can send messages again in %d seconds.", events.GetRemainingSeconds(until)))
+		return false
+	}
+
+	// Messages can only be sent to the chat rooms the client is in.
+	if !canSendToChatRoom(c, event.RoomID) {
+		s.sendActionToClient(c, "You are not in this chat room.")
+		return false
+	}
+
+	// Enforce the chat modes.
+	if rejection, allowed := s.checkChatModes(event.User, event); !allowed {
+		s.sendActionToClient(c, rejection)
+		return false
+	}
+
+	return true
+}
+
 func logSanitize(userValue string) string {
 	// strip carriage return and newline from user-submitted values to prevent log injection
 	sanitizedValue := strings.ReplaceAll(userValue, "\n", "")
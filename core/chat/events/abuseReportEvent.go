@@ -0,0 +1,16 @@
+package events
+
+// AbuseReportEvent is a user reporting a chat message or another user to
+// the moderators. Only one of MessageID or UserID needs to be set.
+type AbuseReportEvent struct {
+	Event
+	UserEvent
+	MessageID string `json:"messageId,omitempty"`
+	UserID    string `json:"userId,omitempty"`
+	Reason    string `json:"reason"`
+}
+
+// GetMessageType will return the event type for this message.
+func (e *AbuseReportEvent) GetMessageType() EventType {
+	return AbuseReportSent
+}
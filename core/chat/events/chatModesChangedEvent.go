@@ -0,0 +1,24 @@
+package events
+
+import "github.com/owncast/owncast/models"
+
+// ChatModesChangedEvent is the event fired when the chat modes are changed.
+type ChatModesChangedEvent struct {
+	Event
+	Modes models.ChatModes `json:"modes"`
+}
+
+// GetBroadcastPayload will return the object to send to all chat users.
+func (e *ChatModesChangedEvent) GetBroadcastPayload() EventPayload {
+	return EventPayload{
+		"type":      ChatModesChanged,
+		"id":        e.ID,
+		"timestamp": e.Timestamp,
+		"modes":     e.Modes,
+	}
+}
+
+// GetMessageType will return the event type for this message.
+func (e *ChatModesChangedEvent) GetMessageType() EventType {
+	return ChatModesChanged
+}
@@ -0,0 +1,54 @@
+package events
+
+import "github.com/owncast/owncast/models"
+
+// ChatRoomEvent is a user asking to join or leave a chat room.
+type ChatRoomEvent struct {
+	Event
+	RoomID string `json:"roomId"`
+}
+
+// ChatRoomJoinedEvent lets a user know they have joined a chat room and
+// gives them its recent history.
+type ChatRoomJoinedEvent struct {
+	Event
+	Room    models.ChatRoom `json:"room"`
+	History []interface{}   `json:"history"`
+}
+
+// GetBroadcastPayload will return the object to send to the user that joined.
+func (e *ChatRoomJoinedEvent) GetBroadcastPayload() EventPayload {
+	return EventPayload{
+		"id":        e.ID,
+		"timestamp": e.Timestamp,
+		"room":      e.Room,
+		"history":   e.History,
+		"type":      ChatRoomJoined,
+	}
+}
+
+// GetMessageType will return the event type for this message.
+func (e *ChatRoomJoinedEvent) GetMessageType() EventType {
+	return ChatRoomJoined
+}
+
+// ChatRoomLeftEvent lets a user know they are no longer in a chat room.
+type ChatRoomLeftEvent struct {
+	Event
+	RoomID string `json:"roomId"`
+}
+
+// GetBroadcastPayload will return the object to send to the user that left.
+func (e *ChatRoomLeftEvent) GetBroadcastPayload() EventPayload {
+	return EventPayload{
+		"id":        e.ID,
+		"timestamp": e.Timestamp,
+		"roomId":    e.RoomID,
+		"type":      ChatRoomLeft,
+	}
+}
+
+// GetMessageType will return the event type for this message.
+func (e *ChatRoomLeftEvent) GetMessageType() EventType {
+	return ChatRoomLeft
+}
@@ -0,0 +1,27 @@
+package events
+
+// DirectMessageEvent is a private message from one chat user to another.
+type DirectMessageEvent struct {
+	Event
+	UserEvent
+	MessageEvent
+	RecipientID string `json:"recipientId"`
+}
+
+// GetBroadcastPayload will return the object to send to the sender and
+// recipient of the message.
+func (e *DirectMessageEvent) GetBroadcastPayload() EventPayload {
+	return EventPayload{
+		"id":          e.ID,
+		"timestamp":   e.Timestamp,
+		"body":        e.Body,
+		"user":        e.User,
+		"recipientId": e.RecipientID,
+		"type":        DirectMessageSent,
+	}
+}
+
+// GetMessageType will return the event type for this message.
+func (e *DirectMessageEvent) GetMessageType() EventType {
+	return DirectMessageSent
+}
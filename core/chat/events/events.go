@@ -45,6 +45,12 @@ type UserEvent struct {
 	User     *user.User `json:"user"`
 	HiddenAt *time.Time `json:"hiddenAt,omitempty"`
 	ClientID uint       `json:"clientId,omitempty"`
+	// ChannelID is the channel the event took place in. Empty for the
+	// default channel.
+	ChannelID string `json:"channelId,omitempty"`
+	// RoomID is the chat room of the channel the event took place in. Empty
+	// for the main chat.
+	RoomID string `json:"roomId,omitempty"`
 }
 
 // MessageEvent is an event that has a message body.
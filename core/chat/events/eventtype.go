@@ -32,6 +32,34 @@ const (
 	ChatDisabled EventType = "CHAT_DISABLED"
 	// ConnectedUserInfo is a private event to a user letting them know their user details.
 	ConnectedUserInfo EventType = "CONNECTED_USER_INFO"
+	// ChatModesChanged is the event sent when the chat modes are changed.
+	ChatModesChanged EventType = "CHAT_MODES_CHANGED"
+	// MessageReaction is the event sent when a user adds or removes an emoji reaction to a chat message.
+	MessageReaction EventType = "CHAT_REACTION"
+	// MessageEdited is the event sent when a user edits one of their own chat messages.
+	MessageEdited EventType = "MESSAGE_EDITED"
+	// MessageDeleted is the event sent when a user deletes one of their own chat messages.
+	MessageDeleted EventType = "MESSAGE_DELETED"
+	// DirectMessageSent is the event sent when a user sends a private message to another user.
+	DirectMessageSent EventType = "DIRECT_MESSAGE"
+	// AbuseReportSent is the event sent when a user reports a chat message or another user to the moderators.
+	AbuseReportSent EventType = "REPORT"
+	// JoinChatRoom is the event sent when a user asks to join a chat room.
+	JoinChatRoom EventType = "JOIN_ROOM"
+	// LeaveChatRoom is the event sent when a user asks to leave a chat room.
+	LeaveChatRoom EventType = "LEAVE_ROOM"
+	// ChatRoomJoined is a private event to a user with the history of a chat room they joined.
+	ChatRoomJoined EventType = "ROOM_JOINED"
+	// ChatRoomLeft is a private event to a user letting them know they are no longer in a chat room.
+	ChatRoomLeft EventType = "ROOM_LEFT"
+	// MessagePinned is the event sent when a chat message or announcement is pinned to the top of chat.
+	MessagePinned EventType = "MESSAGE_PINNED"
+	// MessageUnpinned is the event sent when the pinned message is removed from the top of chat.
+	MessageUnpinned EventType = "MESSAGE_UNPINNED"
+	// MessageHeld is the event sent to moderators when a message is held for review.
+	MessageHeld EventType = "MESSAGE_HELD"
+	// HeldMessageResolved is the event sent to moderators when a held message is approved or rejected.
+	HeldMessageResolved EventType = "HELD_MESSAGE_RESOLVED"
 	// ChatActionSent is a generic chat action that can be used for anything that doesn't need specific handling or formatting.
 	ChatActionSent EventType = "CHAT_ACTION"
 	// ErrorNeedsRegistration is an error returned when the client needs to perform registration.
@@ -0,0 +1,55 @@
+package events
+
+// HeldMessageEvent is a user message kept from chat until a moderator
+// reviews it.
+type HeldMessageEvent struct {
+	Event
+	UserEvent
+	MessageEvent
+	Reason  string `json:"reason"`
+	ReplyTo string `json:"replyTo,omitempty"`
+}
+
+// GetBroadcastPayload will return the object to send to moderators.
+func (e *HeldMessageEvent) GetBroadcastPayload() EventPayload {
+	return EventPayload{
+		"id":        e.ID,
+		"timestamp": e.Timestamp,
+		"body":      e.Body,
+		"user":      e.User,
+		"reason":    e.Reason,
+		"channelId": e.ChannelID,
+		"roomId":    e.RoomID,
+		"replyTo":   e.ReplyTo,
+		"type":      MessageHeld,
+	}
+}
+
+// GetMessageType will return the event type for this message.
+func (e *HeldMessageEvent) GetMessageType() EventType {
+	return MessageHeld
+}
+
+// HeldMessageResolvedEvent is sent to moderators when a held message has
+// been approved or rejected.
+type HeldMessageResolvedEvent struct {
+	Event
+	MessageID string `json:"messageId"`
+	Approved  bool   `json:"approved"`
+}
+
+// GetBroadcastPayload will return the object to send to moderators.
+func (e *HeldMessageResolvedEvent) GetBroadcastPayload() EventPayload {
+	return EventPayload{
+		"id":        e.ID,
+		"timestamp": e.Timestamp,
+		"messageId": e.MessageID,
+		"approved":  e.Approved,
+		"type":      HeldMessageResolved,
+	}
+}
+
+// GetMessageType will return the event type for this message.
+func (e *HeldMessageResolvedEvent) GetMessageType() EventType {
+	return HeldMessageResolved
+}
@@ -0,0 +1,52 @@
+package events
+
+// MessageEditedEvent is a user changing the text of one of their own chat
+// messages.
+type MessageEditedEvent struct {
+	Event
+	UserEvent
+	MessageEvent
+	MessageID string `json:"messageId"`
+}
+
+// GetBroadcastPayload will return the object to send to all chat users.
+func (e *MessageEditedEvent) GetBroadcastPayload() EventPayload {
+	return EventPayload{
+		"id":        e.ID,
+		"timestamp": e.Timestamp,
+		"user":      e.User,
+		"messageId": e.MessageID,
+		"body":      e.Body,
+		"roomId":    e.RoomID,
+		"type":      MessageEdited,
+	}
+}
+
+// GetMessageType will return the event type for this message.
+func (e *MessageEditedEvent) GetMessageType() EventType {
+	return MessageEdited
+}
+
+// MessageDeletedEvent is a user deleting one of their own chat messages.
+type MessageDeletedEvent struct {
+	Event
+	UserEvent
+	MessageID string `json:"messageId"`
+}
+
+// GetBroadcastPayload will return the object to send to all chat users.
+func (e *MessageDeletedEvent) GetBroadcastPayload() EventPayload {
+	return EventPayload{
+		"id":        e.ID,
+		"timestamp": e.Timestamp,
+		"user":      e.User,
+		"messageId": e.MessageID,
+		"roomId":    e.RoomID,
+		"type":      MessageDeleted,
+	}
+}
+
+// GetMessageType will return the event type for this message.
+func (e *MessageDeletedEvent) GetMessageType() EventType {
+	return MessageDeleted
+}
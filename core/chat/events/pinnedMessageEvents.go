@@ -0,0 +1,51 @@
+package events
+
+import "github.com/owncast/owncast/models"
+
+// MessagePinnedEvent is a chat message or announcement being pinned to the
+// top of chat.
+type MessagePinnedEvent struct {
+	Event
+	UserEvent
+	MessageEvent
+	MessageID string                   `json:"messageId,omitempty"`
+	Style     models.AnnouncementStyle `json:"style,omitempty"`
+}
+
+// GetBroadcastPayload will return the object to send to all chat users.
+func (e *MessagePinnedEvent) GetBroadcastPayload() EventPayload {
+	return EventPayload{
+		"id":        e.ID,
+		"timestamp": e.Timestamp,
+		"user":      e.User,
+		"messageId": e.MessageID,
+		"body":      e.Body,
+		"style":     e.Style,
+		"type":      MessagePinned,
+	}
+}
+
+// GetMessageType will return the event type for this message.
+func (e *MessagePinnedEvent) GetMessageType() EventType {
+	return MessagePinned
+}
+
+// MessageUnpinnedEvent is the pinned message being removed from the top of
+// chat.
+type MessageUnpinnedEvent struct {
+	Event
+}
+
+// GetBroadcastPayload will return the object to send to all chat users.
+func (e *MessageUnpinnedEvent) GetBroadcastPayload() EventPayload {
+	return EventPayload{
+		"id":        e.ID,
+		"timestamp": e.Timestamp,
+		"type":      MessageUnpinned,
+	}
+}
+
+// GetMessageType will return the event type for this message.
+func (e *MessageUnpinnedEvent) GetMessageType() EventType {
+	return MessageUnpinned
+}
@@ -0,0 +1,36 @@
+package events
+
+import "github.com/owncast/owncast/models"
+
+// ReactionEvent is a user adding or removing an emoji reaction to a chat
+// message.
+type ReactionEvent struct {
+	Event
+	UserEvent
+	MessageID string `json:"messageId"`
+	// Emoji is either a unicode emoji or the name of a custom emoji.
+	Emoji   string `json:"emoji"`
+	Removed bool   `json:"removed,omitempty"`
+	// Reactions are all the reactions to the message after this one.
+	Reactions []models.ChatReaction `json:"reactions,omitempty"`
+}
+
+// GetBroadcastPayload will return the object to send to all chat users.
+func (e *ReactionEvent) GetBroadcastPayload() EventPayload {
+	return EventPayload{
+		"id":        e.ID,
+		"timestamp": e.Timestamp,
+		"user":      e.User,
+		"messageId": e.MessageID,
+		"emoji":     e.Emoji,
+		"removed":   e.Removed,
+		"reactions": e.Reactions,
+		"roomId":    e.RoomID,
+		"type":      MessageReaction,
+	}
+}
+
+// GetMessageType will return the event type for this message.
+func (e *ReactionEvent) GetMessageType() EventType {
+	return MessageReaction
+}
@@ -1,17 +1,40 @@
 package events
 
+import "time"
+
 // UserDisabledEvent is the event fired when a user is banned/blocked and disconnected from chat.
 type UserDisabledEvent struct {
+	// DisabledUntil is when the user is allowed back into chat. Nil when
+	// they are banned permanently.
+	DisabledUntil *time.Time `json:"disabledUntil,omitempty"`
 	Event
 	UserEvent
 }
 
 // GetBroadcastPayload will return the object to send to all chat users.
 func (e *UserDisabledEvent) GetBroadcastPayload() EventPayload {
-	return EventPayload{
+	payload := EventPayload{
 		"type":      ErrorUserDisabled,
 		"id":        e.ID,
 		"timestamp": e.Timestamp,
 		"user":      e.User,
 	}
+
+	if e.DisabledUntil != nil {
+		payload["disabledUntil"] = e.DisabledUntil
+		payload["remainingSeconds"] = GetRemainingSeconds(*e.DisabledUntil)
+	}
+
+	return payload
+}
+
+// GetRemainingSeconds will return the whole number of seconds, rounded up,
+// until a point in time.
+func GetRemainingSeconds(until time.Time) int {
+	remaining := time.Until(until)
+	if remaining <= 0 {
+		return 0
+	}
+
+	return int((remaining + time.Second - 1) / time.Second)
 }
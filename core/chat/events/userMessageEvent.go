@@ -1,10 +1,24 @@
 package events
 
+import (
+	"time"
+
+	"github.com/owncast/owncast/models"
+)
+
 // UserMessageEvent is an inbound message from a user.
 type UserMessageEvent struct {
 	Event
 	UserEvent
 	MessageEvent
+	// ReplyTo is the ID of the message this message is a reply to.
+	ReplyTo   string                `json:"replyTo,omitempty"`
+	Reactions []models.ChatReaction `json:"reactions,omitempty"`
+	EditedAt  *time.Time            `json:"editedAt,omitempty"`
+	DeletedAt *time.Time            `json:"deletedAt,omitempty"`
+	// EditHistory is the previous text of an edited message. It is only
+	// included for moderators.
+	EditHistory []models.ChatMessageEdit `json:"editHistory,omitempty"`
 }
 
 // GetBroadcastPayload will return the object to send to all chat users.
@@ -16,6 +30,8 @@ func (e *UserMessageEvent) GetBroadcastPayload() EventPayload {
 		"user":      e.User,
 		"type":      MessageSent,
 		"visible":   e.HiddenAt == nil,
+		"replyTo":   e.ReplyTo,
+		"roomId":    e.RoomID,
 	}
 }
 
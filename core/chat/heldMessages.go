@@ -0,0 +1,209 @@
+package chat
+
+import (
+	"errors"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/owncast/owncast/core/chat/events"
+	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/core/user"
+	"github.com/owncast/owncast/core/webhooks"
+	"github.com/owncast/owncast/models"
+)
+
+const (
+	heldMessageActionApprove = "APPROVE"
+	heldMessageActionReject  = "REJECT"
+)
+
+// ErrHeldMessageNotFound is returned when there is no held message with the
+// requested ID.
+var ErrHeldMessageNotFound = errors.New("held message not found")
+
+// holdMessage will add a message to the moderator review queue and let the
+// connected moderators know about it.
+func (s *Server) holdMessage(event *events.UserMessageEvent, reason string) error {
+	held := models.HeldMessage{
+		ID:        event.ID,
+		UserID:    event.User.ID,
+		Body:      event.Body,
+		Reason:    reason,
+		ChannelID: event.ChannelID,
+		RoomID:    event.RoomID,
+		Timestamp: event.Timestamp,
+		ReplyTo:   event.ReplyTo,
+	}
+	if err := data.InsertHeldMessage(held); err != nil {
+		return err
+	}
+
+	heldEvent := events.HeldMessageEvent{
+		Event: events.Event{ID: event.ID, Timestamp: event.Timestamp},
+		UserEvent: events.UserEvent{
+			User:      event.User,
+			ChannelID: event.ChannelID,
+			RoomID:    event.RoomID,
+		},
+		MessageEvent: events.MessageEvent{Body: event.Body},
+		Reason:       reason,
+		ReplyTo:      event.ReplyTo,
+	}
+	s.sendToModerators(heldEvent.GetBroadcastPayload())
+
+	return nil
+}
+
+// GetHeldMessages will return the messages waiting for moderator review.
+func GetHeldMessages() []events.HeldMessageEvent {
+	held, err := data.GetHeldMessages()
+	if err != nil {
+		log.Errorln("error fetching held messages", err)
+	}
+
+	messages := make([]events.HeldMessageEvent, 0, len(held))
+	for _, message := range held {
+		messages = append(messages, events.HeldMessageEvent{
+			Event: events.Event{ID: message.ID, Timestamp: message.Timestamp},
+			UserEvent: events.UserEvent{
+				User:      user.GetUserByID(message.UserID),
+				ChannelID: message.ChannelID,
+				RoomID:    message.RoomID,
+			},
+			MessageEvent: events.MessageEvent{Body: message.Body},
+			Reason:       message.Reason,
+			ReplyTo:      message.ReplyTo,
+		})
+	}
+
+	return messages
+}
+
+// ApproveHeldMessage will send a held message to chat on behalf of the user
+// that wrote it. Messages from users that have since been banned or timed
+// out can only be rejected.
+func ApproveHeldMessage(messageID string, moderator *user.User) error {
+	held, err := data.GetHeldMessage(messageID)
+	if err != nil {
+		return err
+	}
+	if held == nil {
+		return ErrHeldMessageNotFound
+	}
+
+	sender := user.GetUserByID(held.UserID)
+	if sender == nil {
+		return errors.New("the user that sent this message no longer exists")
+	}
+	if !sender.IsEnabled() {
+		return errors.New("the user that sent this message has been banned")
+	}
+	if _, timedOut := _server.getUserTimeout(sender.ID); timedOut {
+		return errors.New("the user that sent this message has been timed out")
+	}
+
+	if held, err = resolveHeldMessage(messageID, moderator, heldMessageActionApprove, true); err != nil {
+		return err
+	}
+
+	// Replies can only be made to visible messages in the same chat room.
+	replyTo := held.ReplyTo
+	if replyTo != "" {
+		if channelID, roomID, exists := getReactableMessageRoom(replyTo); !exists || channelID != held.ChannelID || roomID != held.RoomID {
+			replyTo = ""
+		}
+	}
+
+	event := events.UserMessageEvent{
+		Event: events.Event{
+			ID:        held.ID,
+			Timestamp: time.Now(),
+			Type:      events.MessageSent,
+		},
+		UserEvent: events.UserEvent{
+			User:      sender,
+			ChannelID: held.ChannelID,
+			RoomID:    held.RoomID,
+		},
+		MessageEvent: events.MessageEvent{Body: held.Body},
+		ReplyTo:      replyTo,
+	}
+
+	if err := _server.BroadcastToChatRoom(event.GetBroadcastPayload(), event.ChannelID, event.RoomID); err != nil {
+		return err
+	}
+
+	webhooks.SendChatEvent(&event)
+	chatMessagesSentCounter.Inc()
+	SaveUserMessage(event)
+
+	return nil
+}
+
+// RejectHeldMessage will discard a held message and let the user that wrote
+// it know.
+func RejectHeldMessage(messageID string, moderator *user.User) error {
+	held, err := resolveHeldMessage(messageID, moderator, heldMessageActionReject, false)
+	if err != nil {
+		return err
+	}
+
+	clients, _ := GetClientsForUser(held.UserID)
+	for _, client := range clients {
+		client.sendAction("Your message was not approved by a moderator.")
+	}
+
+	return nil
+}
+
+// resolveHeldMessage will take a message out of the review queue, record the
+// moderator's decision and let the other moderators know it was handled.
+func resolveHeldMessage(messageID string, moderator *user.User, action string, approved bool) (*models.HeldMessage, error) {
+	held, err := data.RemoveHeldMessage(messageID)
+	if err != nil {
+		return nil, err
+	}
+	if held == nil {
+		return nil, ErrHeldMessageNotFound
+	}
+
+	logEntry := models.ModerationLogEntry{
+		Timestamp: time.Now(),
+		UserID:    held.UserID,
+		MessageID: held.ID,
+		Body:      held.Body,
+		Source:    "moderator:" + moderator.ID,
+		Reason:    held.Reason,
+		Action:    action,
+		ChannelID: held.ChannelID,
+	}
+	if err := data.InsertModerationLogEntry(logEntry); err != nil {
+		log.Errorln("error saving held message moderation log entry", err)
+	}
+
+	resolvedEvent := events.HeldMessageResolvedEvent{
+		MessageID: held.ID,
+		Approved:  approved,
+	}
+	resolvedEvent.SetDefaults()
+	_server.sendToModerators(resolvedEvent.GetBroadcastPayload())
+
+	return held, nil
+}
+
+// sendToModerators will send a payload to every connected moderator.
+func (s *Server) sendToModerators(payload events.EventPayload) {
+	s.mu.Lock()
+	moderators := []*Client{}
+	for _, client := range s.clients {
+		if client.User != nil && client.User.IsModerator() {
+			moderators = append(moderators, client)
+		}
+	}
+	s.mu.Unlock()
+
+	for _, client := range moderators {
+		s.Send(payload, client)
+	}
+}
@@ -0,0 +1,179 @@
+package chat
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/owncast/owncast/core/chat/events"
+	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/core/user"
+	"github.com/owncast/owncast/core/webhooks"
+)
+
+// editableMessage is a visible user message that may be edited or deleted by
+// its author.
+type editableMessage struct {
+	timestamp time.Time
+	userID    string
+	body      string
+	channelID string
+	roomID    string
+}
+
+func (s *Server) userMessageEdited(eventData chatClientEvent) {
+	var event events.MessageEditedEvent
+	if err := json.Unmarshal(eventData.data, &event); err != nil {
+		log.Errorln("error unmarshalling to MessageEditedEvent", err)
+		return
+	}
+
+	event.SetDefaults()
+	event.RenderAndSanitizeMessageBody()
+	event.ClientID = eventData.client.Id
+	event.ChannelID = eventData.client.ChannelID
+
+	// Messages cannot be edited to be empty. They should be deleted instead.
+	if event.Empty() {
+		return
+	}
+
+	event.User = user.GetUserByToken(eventData.client.accessToken)
+	if event.User == nil {
+		return
+	}
+
+	if until, timedOut := s.getUserTimeout(event.User.ID); timedOut {
+		s.sendActionToClient(eventData.client, fmt.Sprintf("You have been timed out and can edit messages again in %d seconds.", events.GetRemainingSeconds(until)))
+		return
+	}
+
+	message, exists := getEditableMessage(event.MessageID)
+	if !exists || !canEditMessage(message, event.User.ID, event.ChannelID, data.GetChatMessageEditWindow(), time.Now()) {
+		s.sendActionToClient(eventData.client, "This message can no longer be edited.")
+		return
+	}
+	event.RoomID = message.roomID
+
+	if message.body == event.Body {
+		return
+	}
+
+	// Edits are held to the same automod rules as new messages.
+	if _, _, matched := checkAutomod(data.GetAutomodRules(), event.User, event.Body); matched {
+		s.sendActionToClient(eventData.client, "Your edit was not saved because it breaks the chat rules.")
+		return
+	}
+
+	if err := saveMessageEdit(event.MessageID, message.body, event.Body, event.Timestamp); err != nil {
+		log.Errorln("error saving chat message edit", err)
+		return
+	}
+	archiveMessageEdit(event.MessageID, event.Body, event.Timestamp)
+
+	if err := data.UpdatePinnedChatMessageBody(event.MessageID, event.Body); err != nil {
+		log.Errorln("error updating pinned chat message", err)
+	}
+
+	if err := s.BroadcastToChatRoom(event.GetBroadcastPayload(), event.ChannelID, event.RoomID); err != nil {
+		log.Errorln("error broadcasting MessageEditedEvent payload", err)
+		return
+	}
+
+	webhooks.SendChatEventMessageEdited(event)
+}
+
+func (s *Server) userMessageDeleted(eventData chatClientEvent) {
+	var event events.MessageDeletedEvent
+	if err := json.Unmarshal(eventData.data, &event); err != nil {
+		log.Errorln("error unmarshalling to MessageDeletedEvent", err)
+		return
+	}
+
+	event.SetDefaults()
+	event.ClientID = eventData.client.Id
+	event.ChannelID = eventData.client.ChannelID
+
+	event.User = user.GetUserByToken(eventData.client.accessToken)
+	if event.User == nil {
+		return
+	}
+
+	message, exists := getEditableMessage(event.MessageID)
+	if !exists || !canEditMessage(message, event.User.ID, event.ChannelID, data.GetChatMessageEditWindow(), time.Now()) {
+		s.sendActionToClient(eventData.client, "This message can no longer be deleted.")
+		return
+	}
+	event.RoomID = message.roomID
+
+	if err := saveMessageDeletion(event.MessageID, event.Timestamp); err != nil {
+		log.Errorln("error saving chat message deletion", err)
+		return
+	}
+	archiveMessageDeletion(event.MessageID, event.Timestamp)
+
+	unpinChatMessages([]string{event.MessageID})
+
+	if err := s.BroadcastToChatRoom(event.GetBroadcastPayload(), event.ChannelID, event.RoomID); err != nil {
+		log.Errorln("error broadcasting MessageDeletedEvent payload", err)
+		return
+	}
+
+	webhooks.SendChatEventMessageDeleted(event)
+}
+
+// canEditMessage will return if a user can edit or delete a message at a
+// point in time. Users can only change their own messages, and only for the
+// number of seconds in the edit window after sending them.
+func canEditMessage(message editableMessage, userID string, channelID string, windowSeconds int, now time.Time) bool {
+	if windowSeconds <= 0 || message.userID != userID || message.channelID != channelID {
+		return false
+	}
+
+	return now.Sub(message.timestamp) <= time.Duration(windowSeconds)*time.Second
+}
+
+// getEditableMessage will return a visible user message.
+func getEditableMessage(messageID string) (editableMessage, bool) {
+	var message editableMessage
+	if messageID == "" {
+		return message, false
+	}
+
+	row := _datastore.DB.QueryRow("SELECT user_id, body, channel, room, timestamp FROM messages WHERE id = ? AND hidden_at IS NULL AND eventType = ?", messageID, events.MessageSent)
+	if err := row.Scan(&message.userID, &message.body, &message.channelID, &message.roomID, &message.timestamp); err != nil {
+		if err != sql.ErrNoRows {
+			log.Errorln("error fetching chat message", err)
+		}
+		return message, false
+	}
+
+	return message, true
+}
+
+// addEditHistoryToMessages will add the previous text of each edited user
+// message.
+func addEditHistoryToMessages(history []interface{}) {
+	ids := []string{}
+	for _, item := range history {
+		if message, ok := item.(events.UserMessageEvent); ok && message.EditedAt != nil {
+			ids = append(ids, message.ID)
+		}
+	}
+
+	edits, err := data.GetMessageEdits(ids)
+	if err != nil {
+		log.Errorln("error fetching chat message edits", err)
+		return
+	}
+
+	for i, item := range history {
+		if message, ok := item.(events.UserMessageEvent); ok && message.EditedAt != nil {
+			message.EditHistory = edits[message.ID]
+			history[i] = message
+		}
+	}
+}
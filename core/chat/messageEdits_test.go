@@ -0,0 +1,35 @@
+package chat
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCanEditMessage(t *testing.T) {
+	now := time.Now()
+	message := editableMessage{
+		timestamp: now.Add(-time.Minute),
+		userID:    "user-1",
+		channelID: "",
+	}
+
+	tests := []struct {
+		name          string
+		userID        string
+		channelID     string
+		windowSeconds int
+		expected      bool
+	}{
+		{"own message within window", "user-1", "", 120, true},
+		{"own message outside window", "user-1", "", 30, false},
+		{"editing disabled", "user-1", "", 0, false},
+		{"another user's message", "user-2", "", 120, false},
+		{"message in another channel", "user-1", "second", 120, false},
+	}
+
+	for _, test := range tests {
+		if result := canEditMessage(message, test.userID, test.channelID, test.windowSeconds, now); result != test.expected {
+			t.Errorf("%s: expected %t, got %t", test.name, test.expected, result)
+		}
+	}
+}
@@ -15,6 +15,7 @@ func SetMessagesVisibility(messageIDs []string, visibility bool) error {
 		log.Errorln(err)
 		return err
 	}
+	archiveMessagesVisibility(messageIDs, visibility)
 
 	// Send an event letting the chat clients know to hide or show
 	// the messages.
@@ -31,5 +32,10 @@ func SetMessagesVisibility(messageIDs []string, visibility bool) error {
 
 	webhooks.SendChatEventSetMessageVisibility(event)
 
+	// Hidden messages can no longer stay pinned.
+	if !visibility {
+		unpinChatMessages(messageIDs)
+	}
+
 	return nil
 }
@@ -0,0 +1,92 @@
+package chat
+
+import (
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/core/user"
+)
+
+// DisableUser will disable a user, hide their chat messages, disconnect them
+// from chat and ban the IP addresses they are connected from. A duration of
+// zero disables the user permanently, otherwise they are re-enabled and
+// their IP address bans lifted once it has passed.
+func DisableUser(userID string, duration time.Duration) error {
+	var disabledUntil *time.Time
+	if duration > 0 {
+		until := time.Now().Add(duration)
+		disabledUntil = &until
+		if err := user.SetDisabledUntil(userID, until); err != nil {
+			return err
+		}
+	} else if err := user.SetEnabled(userID, false); err != nil {
+		return err
+	}
+
+	// Hide the user's chat messages.
+	if err := SetMessageVisibilityForUserID(userID, false); err != nil {
+		return err
+	}
+
+	// Forcefully disconnect the user from the chat
+	clients, err := GetClientsForUser(userID)
+	if len(clients) == 0 || err != nil {
+		// Nothing to do
+		return nil
+	}
+
+	for _, client := range clients {
+		client.User.DisabledUntil = disabledUntil
+	}
+
+	DisconnectClients(clients)
+	disconnectedUser := user.GetUserByID(userID)
+	_ = SendSystemAction(fmt.Sprintf("**%s** has been removed from chat.", disconnectedUser.DisplayName), true)
+
+	localIP4Address := "127.0.0.1"
+	localIP6Address := "::1"
+
+	// Ban this user's IP address.
+	for _, client := range clients {
+		ipAddress := client.IPAddress
+		if ipAddress != localIP4Address && ipAddress != localIP6Address {
+			reason := fmt.Sprintf("Banning of %s", disconnectedUser.DisplayName)
+			if err := data.BanIPAddress(ipAddress, reason, userID, disabledUntil); err != nil {
+				log.Errorln("error banning IP address: ", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// EnableUser will re-enable a previously disabled user and lift the IP
+// address bans that were added when they were disabled.
+func EnableUser(userID string) error {
+	if err := user.SetEnabled(userID, true); err != nil {
+		return err
+	}
+
+	return data.RemoveIPAddressBansForUser(userID)
+}
+
+// removeExpiredBans will re-enable the users and lift the IP address bans
+// whose time has passed.
+func removeExpiredBans() {
+	if enabledUserIDs, err := user.EnableExpiredUsers(); err != nil {
+		log.Errorln("error re-enabling users whose ban expired", err)
+	} else if len(enabledUserIDs) > 0 {
+		log.Debugln("Re-enabled", len(enabledUserIDs), "users whose ban expired")
+	}
+
+	if err := data.RemoveExpiredIPAddressBans(); err != nil {
+		log.Errorln("error removing expired IP address bans", err)
+	}
+
+	if _server != nil {
+		_server.removeStaleRateLimitViolations(data.GetChatRateLimitPolicy(), time.Now())
+	}
+}
@@ -28,23 +28,46 @@ func setupPersistence() {
 	chatDataPruner := time.NewTicker(5 * time.Minute)
 	go func() {
 		runPruner()
+		runDirectMessagePruner()
+		runChatArchivePruner()
 		for range chatDataPruner.C {
 			runPruner()
+			runDirectMessagePruner()
+			runChatArchivePruner()
+		}
+	}()
+
+	expiredBanSweeper := time.NewTicker(30 * time.Second)
+	go func() {
+		removeExpiredBans()
+		for range expiredBanSweeper.C {
+			removeExpiredBans()
 		}
 	}()
 }
 
 // SaveUserMessage will save a single chat event to the messages database.
 func SaveUserMessage(event events.UserMessageEvent) {
-	saveEvent(event.ID, &event.User.ID, event.Body, event.Type, event.HiddenAt, event.Timestamp, nil, nil, nil, nil)
+	var replyTo *string
+	if event.ReplyTo != "" {
+		replyTo = &event.ReplyTo
+	}
+
+	saveEvent(event.ID, &event.User.ID, event.Body, event.Type, event.HiddenAt, event.Timestamp, nil, nil, nil, nil, replyTo, event.ChannelID, event.RoomID)
+
+	// Saved messages are pruned, so the first one is kept track of for
+	// first message moderation.
+	if err := user.SetFirstMessageSent(event.User.ID, event.Timestamp); err != nil {
+		log.Errorln("error saving first chat message time", err)
+	}
 }
 
 func saveFederatedAction(event events.FediverseEngagementEvent) {
-	saveEvent(event.ID, nil, event.Body, event.Type, nil, event.Timestamp, event.Image, &event.Link, &event.UserAccountName, nil)
+	saveEvent(event.ID, nil, event.Body, event.Type, nil, event.Timestamp, event.Image, &event.Link, &event.UserAccountName, nil, nil, models.DefaultChannelID, models.DefaultChatRoomID)
 }
 
 // nolint: unparam
-func saveEvent(id string, userID *string, body string, eventType string, hidden *time.Time, timestamp time.Time, image *string, link *string, title *string, subtitle *string) {
+func saveEvent(id string, userID *string, body string, eventType string, hidden *time.Time, timestamp time.Time, image *string, link *string, title *string, subtitle *string, replyTo *string, channelID string, roomID string) {
 	defer func() {
 		_historyCache = nil
 	}()
@@ -57,7 +80,7 @@ func saveEvent(id string, userID *string, body string, eventType string, hidden
 
 	defer tx.Rollback() // nolint
 
-	stmt, err := tx.Prepare("INSERT INTO messages(id, user_id, body, eventType, hidden_at, timestamp, image, link, title, subtitle) values(?, ?, ?, ?, ?, ?, ?, ?, ?, ?)")
+	stmt, err := tx.Prepare("INSERT INTO messages(id, user_id, body, eventType, hidden_at, timestamp, image, link, title, subtitle, reply_to, channel, room, stream_offset) values(?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)")
 	if err != nil {
 		log.Errorln("error saving", eventType, err)
 		return
@@ -65,7 +88,7 @@ func saveEvent(id string, userID *string, body string, eventType string, hidden
 
 	defer stmt.Close()
 
-	if _, err = stmt.Exec(id, userID, body, eventType, hidden, timestamp, image, link, title, subtitle); err != nil {
+	if _, err = stmt.Exec(id, userID, body, eventType, hidden, timestamp, image, link, title, subtitle, replyTo, channelID, roomID, getStreamOffset(channelID, timestamp)); err != nil {
 		log.Errorln("error saving", eventType, err)
 		return
 	}
@@ -73,6 +96,8 @@ func saveEvent(id string, userID *string, body string, eventType string, hidden
 		log.Errorln("error saving", eventType, err)
 		return
 	}
+
+	archiveEvent(id, userID, body, eventType, hidden, timestamp, channelID, roomID)
 }
 
 func makeUserMessageEventFromRowData(row rowData) events.UserMessageEvent {
@@ -127,11 +152,18 @@ func makeUserMessageEventFromRowData(row rowData) events.UserMessageEvent {
 		UserEvent: events.UserEvent{
 			User:     &u,
 			HiddenAt: row.hiddenAt,
+			RoomID:   row.roomID,
 		},
 		MessageEvent: events.MessageEvent{
 			Body:    row.body,
 			RawBody: row.body,
 		},
+		EditedAt:  row.editedAt,
+		DeletedAt: row.deletedAt,
+	}
+
+	if row.replyTo != nil {
+		message.ReplyTo = *row.replyTo
 	}
 
 	return message
@@ -196,6 +228,9 @@ type rowData struct {
 	title            *string
 	subtitle         *string
 	link             *string
+	replyTo          *string
+	editedAt         *time.Time
+	deletedAt        *time.Time
 
 	userType            *string
 	userScopes          *string
@@ -207,6 +242,56 @@ type rowData struct {
 	body                string
 	eventType           models.EventType
 	id                  string
+	roomID              string
+}
+
+// scanDestinations returns where the columns of a chat query are scanned to,
+// in the order they are selected.
+func (row *rowData) scanDestinations() []interface{} {
+	return []interface{}{
+		&row.id,
+		&row.userID,
+		&row.body,
+		&row.title,
+		&row.subtitle,
+		&row.image,
+		&row.link,
+		&row.eventType,
+		&row.hiddenAt,
+		&row.timestamp,
+		&row.replyTo,
+		&row.editedAt,
+		&row.deletedAt,
+		&row.roomID,
+		&row.userDisplayName,
+		&row.userDisplayColor,
+		&row.userCreatedAt,
+		&row.userDisabledAt,
+		&row.previousUsernames,
+		&row.userNameChangedAt,
+		&row.userAuthenticatedAt,
+		&row.userScopes,
+		&row.userType,
+	}
+}
+
+func makeChatEventFromRowData(row rowData) interface{} {
+	switch row.eventType {
+	case events.MessageSent:
+		return makeUserMessageEventFromRowData(row)
+	case events.SystemMessageSent:
+		return makeSystemMessageChatEventFromRowData(row)
+	case events.ChatActionSent:
+		return makeActionMessageChatEventFromRowData(row)
+	case events.FediverseEngagementFollow:
+		return makeFederatedActionChatEventFromRowData(row)
+	case events.FediverseEngagementLike:
+		return makeFederatedActionChatEventFromRowData(row)
+	case events.FediverseEngagementRepost:
+		return makeFederatedActionChatEventFromRowData(row)
+	}
+
+	return nil
 }
 
 func getChat(rows *sql.Rows) ([]interface{}, error) {
@@ -216,48 +301,11 @@ func getChat(rows *sql.Rows) ([]interface{}, error) {
 		row := rowData{}
 
 		// Convert a database row into a chat event
-		if err := rows.Scan(
-			&row.id,
-			&row.userID,
-			&row.body,
-			&row.title,
-			&row.subtitle,
-			&row.image,
-			&row.link,
-			&row.eventType,
-			&row.hiddenAt,
-			&row.timestamp,
-			&row.userDisplayName,
-			&row.userDisplayColor,
-			&row.userCreatedAt,
-			&row.userDisabledAt,
-			&row.previousUsernames,
-			&row.userNameChangedAt,
-			&row.userAuthenticatedAt,
-			&row.userScopes,
-			&row.userType,
-		); err != nil {
+		if err := rows.Scan(row.scanDestinations()...); err != nil {
 			return nil, err
 		}
 
-		var message interface{}
-
-		switch row.eventType {
-		case events.MessageSent:
-			message = makeUserMessageEventFromRowData(row)
-		case events.SystemMessageSent:
-			message = makeSystemMessageChatEventFromRowData(row)
-		case events.ChatActionSent:
-			message = makeActionMessageChatEventFromRowData(row)
-		case events.FediverseEngagementFollow:
-			message = makeFederatedActionChatEventFromRowData(row)
-		case events.FediverseEngagementLike:
-			message = makeFederatedActionChatEventFromRowData(row)
-		case events.FediverseEngagementRepost:
-			message = makeFederatedActionChatEventFromRowData(row)
-		}
-
-		history = append(history, message)
+		history = append(history, makeChatEventFromRowData(row))
 	}
 
 	return history, nil
@@ -280,7 +328,7 @@ func GetChatModerationHistory() []interface{} {
 	defer tx.Rollback() // nolint
 
 	// Get all messages regardless of visibility
-	query := "SELECT messages.id, user_id, body, title, subtitle, image, link, eventType, hidden_at, timestamp, display_name, display_color, created_at, disabled_at, previous_names, namechanged_at, authenticated_at, scopes, type FROM messages INNER JOIN users ON messages.user_id = users.id ORDER BY timestamp DESC"
+	query := "SELECT messages.id, user_id, body, title, subtitle, image, link, eventType, hidden_at, timestamp, reply_to, edited_at, deleted_at, room, display_name, display_color, created_at, disabled_at, previous_names, namechanged_at, authenticated_at, scopes, type FROM messages INNER JOIN users ON messages.user_id = users.id ORDER BY timestamp DESC"
 	stmt, err := tx.Prepare(query)
 	if err != nil {
 		log.Errorln("error fetching chat moderation history", err)
@@ -303,6 +351,8 @@ func GetChatModerationHistory() []interface{} {
 		return nil
 	}
 
+	addEditHistoryToMessages(result)
+
 	_historyCache = &result
 
 	if err = tx.Commit(); err != nil {
@@ -313,8 +363,14 @@ func GetChatModerationHistory() []interface{} {
 	return result
 }
 
-// GetChatHistory will return all the chat messages suitable for returning as user-facing chat history.
-func GetChatHistory() []interface{} {
+// GetChatHistory will return all the chat messages of a channel suitable for returning as user-facing chat history.
+func GetChatHistory(channelID string) []interface{} {
+	return GetChatRoomHistory(channelID, models.DefaultChatRoomID)
+}
+
+// GetChatRoomHistory will return the chat messages of a single chat room of a
+// channel suitable for returning as user-facing chat history.
+func GetChatRoomHistory(channelID string, roomID string) []interface{} {
 	tx, err := _datastore.DB.Begin()
 	if err != nil {
 		log.Errorln("error fetching chat history", err)
@@ -324,7 +380,7 @@ func GetChatHistory() []interface{} {
 	defer tx.Rollback() // nolint
 
 	// Get all visible messages
-	query := "SELECT messages.id, messages.user_id, messages.body, messages.title, messages.subtitle, messages.image, messages.link, messages.eventType, messages.hidden_at, messages.timestamp, users.display_name, users.display_color, users.created_at, users.disabled_at, users.previous_names, users.namechanged_at, users.authenticated_at, users.scopes, users.type FROM users JOIN messages ON users.id = messages.user_id WHERE hidden_at IS NULL AND disabled_at IS NULL ORDER BY timestamp DESC LIMIT ?"
+	query := "SELECT messages.id, messages.user_id, messages.body, messages.title, messages.subtitle, messages.image, messages.link, messages.eventType, messages.hidden_at, messages.timestamp, messages.reply_to, messages.edited_at, messages.deleted_at, messages.room, users.display_name, users.display_color, users.created_at, users.disabled_at, users.previous_names, users.namechanged_at, users.authenticated_at, users.scopes, users.type FROM users JOIN messages ON users.id = messages.user_id WHERE hidden_at IS NULL AND disabled_at IS NULL AND messages.channel = ? AND messages.room = ? ORDER BY timestamp DESC LIMIT ?"
 
 	stmt, err := tx.Prepare(query)
 	if err != nil {
@@ -332,7 +388,7 @@ func GetChatHistory() []interface{} {
 		return nil
 	}
 
-	rows, err := stmt.Query(maxBacklogNumber)
+	rows, err := stmt.Query(channelID, roomID, maxBacklogNumber)
 	if err != nil {
 		log.Errorln("error fetching chat history", err)
 		return nil
@@ -358,6 +414,8 @@ func GetChatHistory() []interface{} {
 		m[i], m[j] = m[j], m[i]
 	}
 
+	addReactionsToHistory(m)
+
 	return m
 }
 
@@ -398,7 +456,7 @@ func SetMessageVisibilityForUserID(userID string, visible bool) error {
 	}
 
 	defer tx.Rollback() // nolint
-	query := "SELECT messages.id, user_id, body, title, subtitle, image, link, eventType, hidden_at, timestamp, display_name, display_color, created_at, disabled_at,  previous_names, namechanged_at, authenticated_at, scopes, type FROM messages INNER JOIN users ON messages.user_id = users.id WHERE user_id IS ?"
+	query := "SELECT messages.id, user_id, body, title, subtitle, image, link, eventType, hidden_at, timestamp, reply_to, edited_at, deleted_at, room, display_name, display_color, created_at, disabled_at,  previous_names, namechanged_at, authenticated_at, scopes, type FROM messages INNER JOIN users ON messages.user_id = users.id WHERE user_id IS ?"
 
 	stmt, err := tx.Prepare(query)
 	if err != nil {
@@ -415,6 +473,9 @@ func SetMessageVisibilityForUserID(userID string, visible bool) error {
 	defer stmt.Close()
 	defer rows.Close()
 
+	// Archived messages are kept for longer than the chat history.
+	archiveUserMessagesVisibility(userID, visible)
+
 	// Get a list of IDs to send to the connected clients to hide
 	ids := make([]string, 0)
 
@@ -486,3 +547,43 @@ func saveMessageVisibility(messageIDs []string, visible bool) error {
 
 	return nil
 }
+
+func saveMessageEdit(messageID string, previousBody string, body string, editedAt time.Time) error {
+	defer func() {
+		_historyCache = nil
+	}()
+
+	_datastore.DbLock.Lock()
+	defer _datastore.DbLock.Unlock()
+
+	tx, err := _datastore.DB.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback() // nolint
+
+	// Keep the previous text so moderators can see what was changed.
+	if _, err = tx.Exec("INSERT INTO message_edits(message_id, body, edited_at) values(?, ?, ?)", messageID, previousBody, editedAt); err != nil {
+		return err
+	}
+
+	if _, err = tx.Exec("UPDATE messages SET body = ?, edited_at = ? WHERE id = ?", body, editedAt, messageID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func saveMessageDeletion(messageID string, deletedAt time.Time) error {
+	defer func() {
+		_historyCache = nil
+	}()
+
+	_datastore.DbLock.Lock()
+	defer _datastore.DbLock.Unlock()
+
+	// Deleted messages are hidden, but kept for moderators.
+	_, err := _datastore.DB.Exec("UPDATE messages SET hidden_at = ?, deleted_at = ? WHERE id = ?", deletedAt, deletedAt, messageID)
+	return err
+}
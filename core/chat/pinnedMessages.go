@@ -0,0 +1,155 @@
+package chat
+
+import (
+	"errors"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/owncast/owncast/core/chat/events"
+	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/core/user"
+	"github.com/owncast/owncast/models"
+)
+
+var (
+	// ErrPinnedMessageNotFound is returned when nothing is pinned to the chat
+	// of a channel.
+	ErrPinnedMessageNotFound = errors.New("there is no pinned message")
+	// ErrMessageNotPinnable is returned when a chat message can not be
+	// pinned.
+	ErrMessageNotPinnable = errors.New("only visible chat messages in the main chat can be pinned")
+)
+
+// PinMessage will pin a chat message to the top of the chat of its channel,
+// replacing anything already pinned there.
+func PinMessage(messageID string, pinnedBy string) error {
+	message, exists := getEditableMessage(messageID)
+	if !exists || message.roomID != models.DefaultChatRoomID {
+		return ErrMessageNotPinnable
+	}
+
+	return setPinnedMessage(models.PinnedMessage{
+		PinnedAt:  time.Now(),
+		MessageID: messageID,
+		UserID:    message.userID,
+		Body:      message.body,
+		PinnedBy:  pinnedBy,
+		ChannelID: message.channelID,
+	})
+}
+
+// PinAnnouncement will pin an announcement to the top of the chat of a
+// channel, replacing anything already pinned there.
+func PinAnnouncement(channelID string, body string, style models.AnnouncementStyle, pinnedBy string) error {
+	if style == "" {
+		style = models.AnnouncementStyleInfo
+	}
+	if !models.IsValidAnnouncementStyle(style) {
+		return errors.New("invalid announcement style " + style)
+	}
+
+	if channelID != models.DefaultChannelID {
+		if _, exists := data.GetChannel(channelID); !exists {
+			return errors.New("unknown channel " + channelID)
+		}
+	}
+
+	body = events.RenderAndSanitize(body)
+	if body == "" {
+		return errors.New("announcements cannot be empty")
+	}
+
+	return setPinnedMessage(models.PinnedMessage{
+		PinnedAt:  time.Now(),
+		Body:      body,
+		Style:     style,
+		PinnedBy:  pinnedBy,
+		ChannelID: channelID,
+	})
+}
+
+func setPinnedMessage(pinned models.PinnedMessage) error {
+	if err := data.SetPinnedMessage(pinned); err != nil {
+		return err
+	}
+
+	event := makeMessagePinnedEvent(pinned)
+	return _server.BroadcastToChannel(event.GetBroadcastPayload(), pinned.ChannelID)
+}
+
+// UnpinMessage will remove the message pinned to the top of the chat of a
+// channel.
+func UnpinMessage(channelID string) error {
+	removed, err := data.RemovePinnedMessage(channelID)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return ErrPinnedMessageNotFound
+	}
+
+	sendMessageUnpinned(channelID)
+
+	return nil
+}
+
+// GetPinnedMessage will return the message pinned to the chat of a channel.
+// Returns nil if nothing is pinned there.
+func GetPinnedMessage(channelID string) *models.PinnedMessage {
+	pinned, err := data.GetPinnedMessage(channelID)
+	if err != nil {
+		log.Errorln("error fetching pinned message", err)
+	}
+
+	return pinned
+}
+
+// unpinChatMessages will unpin any of the chat messages that are no longer
+// visible.
+func unpinChatMessages(messageIDs []string) {
+	channels, err := data.RemovePinnedChatMessages(messageIDs)
+	if err != nil {
+		log.Errorln("error unpinning chat messages", err)
+	}
+
+	for _, channelID := range channels {
+		sendMessageUnpinned(channelID)
+	}
+}
+
+func sendMessageUnpinned(channelID string) {
+	event := events.MessageUnpinnedEvent{}
+	event.SetDefaults()
+	if err := _server.BroadcastToChannel(event.GetBroadcastPayload(), channelID); err != nil {
+		log.Errorln("error broadcasting MessageUnpinnedEvent payload", err)
+	}
+}
+
+// sendPinnedMessageToClient will let a newly connected client know what is
+// pinned to the chat of its channel.
+func (s *Server) sendPinnedMessageToClient(c *Client) {
+	pinned := GetPinnedMessage(c.ChannelID)
+	if pinned == nil {
+		return
+	}
+
+	event := makeMessagePinnedEvent(*pinned)
+	c.sendPayload(event.GetBroadcastPayload())
+}
+
+func makeMessagePinnedEvent(pinned models.PinnedMessage) events.MessagePinnedEvent {
+	event := events.MessagePinnedEvent{
+		MessageEvent: events.MessageEvent{Body: pinned.Body},
+		MessageID:    pinned.MessageID,
+		Style:        pinned.Style,
+	}
+	event.SetDefaults()
+	event.Timestamp = pinned.PinnedAt
+	event.ChannelID = pinned.ChannelID
+	if pinned.UserID != "" {
+		event.User = user.GetUserByID(pinned.UserID)
+	}
+
+	return event
+}
@@ -2,19 +2,24 @@ package chat
 
 import (
 	"fmt"
+	"time"
 
+	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/core/recording"
+	"github.com/owncast/owncast/models"
 	log "github.com/sirupsen/logrus"
 )
 
 // Only keep recent messages so we don't keep more chat data than needed
-// for privacy and efficiency reasons.
+// for privacy and efficiency reasons. Messages sent during a stream recording
+// are kept for as long as the recording is so they can be replayed with it.
 func runPruner() {
 	_datastore.DbLock.Lock()
 	defer _datastore.DbLock.Unlock()
 
 	log.Traceln("Removing chat messages older than", maxBacklogHours, "hours")
 
-	deleteStatement := `DELETE FROM messages WHERE timestamp <= datetime('now', 'localtime', ?)`
+	deleteStatement := `DELETE FROM messages WHERE timestamp <= datetime('now', 'localtime', ?) AND NOT (stream_offset IS NOT NULL AND channel = ? AND ` + recording.DuringRecordingCondition("messages.timestamp") + `)` //nolint:gosec
 	tx, err := _datastore.DB.Begin()
 	if err != nil {
 		log.Debugln(err)
@@ -28,12 +33,51 @@ func runPruner() {
 	}
 	defer stmt.Close()
 
-	if _, err = stmt.Exec(fmt.Sprintf("-%d hours", maxBacklogHours)); err != nil {
+	if _, err = stmt.Exec(fmt.Sprintf("-%d hours", maxBacklogHours), models.DefaultChannelID); err != nil {
 		log.Debugln(err)
 		return
 	}
+
+	// Reactions to and edits of the removed messages are no longer needed.
+	for _, table := range []string{"message_reactions", "message_edits"} {
+		if _, err = tx.Exec(`DELETE FROM ` + table + ` WHERE message_id NOT IN (SELECT id FROM messages)`); err != nil { //nolint:gosec
+			log.Debugln(err)
+			return
+		}
+	}
+
 	if err = tx.Commit(); err != nil {
 		log.Debugln(err)
 		return
 	}
 }
+
+// Direct messages are kept for longer than chat messages so moderators can
+// refer to them when handling abuse, but not forever unless configured to.
+func runDirectMessagePruner() {
+	retentionDays := data.GetDirectMessageRetentionDays()
+	if retentionDays <= 0 {
+		return
+	}
+
+	log.Traceln("Removing direct messages older than", retentionDays, "days")
+
+	if err := data.RemoveDirectMessagesBefore(time.Now().AddDate(0, 0, -retentionDays)); err != nil {
+		log.Debugln(err)
+	}
+}
+
+// The chat archive is kept for as long as it is configured to, independently
+// of the recent chat history.
+func runChatArchivePruner() {
+	archiveConfig := data.GetChatArchiveConfig()
+	if archiveConfig.RetentionDays <= 0 {
+		return
+	}
+
+	log.Traceln("Removing archived chat older than", archiveConfig.RetentionDays, "days")
+
+	if err := data.RemoveChatArchiveEntriesBefore(time.Now().AddDate(0, 0, -archiveConfig.RetentionDays)); err != nil {
+		log.Debugln(err)
+	}
+}
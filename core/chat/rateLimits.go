@@ -0,0 +1,175 @@
+package chat
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/time/rate"
+
+	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/core/user"
+	"github.com/owncast/owncast/models"
+)
+
+var (
+	// Chat integrations send messages over the external API instead of a
+	// websocket, so they are rate limited by their ID.
+	integrationRateLimiters     = map[string]*rate.Limiter{}
+	integrationRateLimitersLock sync.Mutex
+)
+
+// getChatRateLimit will return the rate limit of the role of a user.
+func getChatRateLimit(policy models.ChatRateLimitPolicy, u *user.User) models.ChatRateLimit {
+	switch {
+	case u == nil:
+		return policy.Anonymous
+	case u.IsModerator():
+		return policy.Moderator
+	case u.IsBot:
+		return policy.Bot
+	case u.Authenticated:
+		return policy.Authenticated
+	default:
+		return policy.Anonymous
+	}
+}
+
+func getRateLimiterLimits(limit models.ChatRateLimit) (rate.Limit, int) {
+	if limit.EventsPerSecond <= 0 {
+		return rate.Inf, 0
+	}
+
+	burst := limit.Burst
+	if burst < 1 {
+		burst = 1
+	}
+
+	return rate.Limit(limit.EventsPerSecond), burst
+}
+
+func newRateLimiter(limit models.ChatRateLimit) *rate.Limiter {
+	return rate.NewLimiter(getRateLimiterLimits(limit))
+}
+
+// updateRateLimit will apply the rate limit of the client's user's current
+// role.
+func (c *Client) updateRateLimit(policy models.ChatRateLimitPolicy) {
+	limit, burst := getRateLimiterLimits(getChatRateLimit(policy, c.User))
+	c.rateLimiter.SetLimit(limit)
+	c.rateLimiter.SetBurst(burst)
+}
+
+// ApplyChatRateLimitPolicy will apply a changed rate limit policy to the
+// connected clients and chat integrations.
+func ApplyChatRateLimitPolicy() {
+	policy := data.GetChatRateLimitPolicy()
+
+	_server.mu.RLock()
+	for _, client := range _server.clients {
+		client.updateRateLimit(policy)
+	}
+	_server.mu.RUnlock()
+
+	integrationRateLimitersLock.Lock()
+	integrationRateLimiters = map[string]*rate.Limiter{}
+	integrationRateLimitersLock.Unlock()
+}
+
+// AllowIntegrationMessage will return if a chat integration is within its
+// rate limit.
+func AllowIntegrationMessage(integrationID string) bool {
+	integrationRateLimitersLock.Lock()
+	defer integrationRateLimitersLock.Unlock()
+
+	limiter, exists := integrationRateLimiters[integrationID]
+	if !exists {
+		limiter = newRateLimiter(data.GetChatRateLimitPolicy().Bot)
+		integrationRateLimiters[integrationID] = limiter
+	}
+
+	return limiter.Allow()
+}
+
+// handleRateLimitExceeded will block a client that exceeded its rate limit
+// for a short time, and time out its user if they keep doing so.
+func (s *Server) handleRateLimitExceeded(c *Client) {
+	policy := data.GetChatRateLimitPolicy()
+
+	if !s.rejectRateLimitedClient(c, policy, time.Now()) {
+		return
+	}
+
+	timeout := time.Duration(policy.EscalationTimeoutSeconds) * time.Second
+	s.TimeoutUser(c.User.ID, time.Now().Add(timeout))
+	c.sendAction(fmt.Sprintf("You have been timed out from chat for %s for repeatedly flooding chat.", timeout))
+}
+
+// rejectRateLimitedClient will start blocking a client that exceeded its
+// rate limit, and return if its user has done so often enough to be timed
+// out. Messages sent while the client is already blocked are not counted
+// again.
+func (s *Server) rejectRateLimitedClient(c *Client, policy models.ChatRateLimitPolicy, now time.Time) bool {
+	if c.inTimeout {
+		return false
+	}
+
+	if !c.startChatRejectionTimeout(time.Duration(policy.RejectionSeconds) * time.Second) {
+		return false
+	}
+
+	log.Warnln("Client", c.Id, c.User.DisplayName, "has exceeded the messaging rate limiting thresholds and messages are being rejected temporarily.")
+
+	return s.recordRateLimitViolation(c.User.ID, policy, now)
+}
+
+// recordRateLimitViolation will keep track of a user exceeding their rate
+// limit, and return if they have done so often enough to be timed out.
+func (s *Server) recordRateLimitViolation(userID string, policy models.ChatRateLimitPolicy, now time.Time) bool {
+	if policy.EscalationThreshold <= 0 || policy.EscalationTimeoutSeconds <= 0 {
+		return false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	window := time.Duration(policy.EscalationWindowSeconds) * time.Second
+	violations := []time.Time{now}
+	for _, violation := range s.userRateLimitViolations[userID] {
+		if now.Sub(violation) < window {
+			violations = append(violations, violation)
+		}
+	}
+
+	if len(violations) >= policy.EscalationThreshold {
+		delete(s.userRateLimitViolations, userID)
+		return true
+	}
+
+	s.userRateLimitViolations[userID] = violations
+	return false
+}
+
+// removeStaleRateLimitViolations will forget the rate limit violations of
+// users that have all left the escalation window, or all of them when
+// escalation is turned off.
+func (s *Server) removeStaleRateLimitViolations(policy models.ChatRateLimitPolicy, now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	window := time.Duration(policy.EscalationWindowSeconds) * time.Second
+	for userID, violations := range s.userRateLimitViolations {
+		stale := true
+		for _, violation := range violations {
+			if now.Sub(violation) < window {
+				stale = false
+				break
+			}
+		}
+
+		if stale || policy.EscalationThreshold <= 0 || policy.EscalationTimeoutSeconds <= 0 {
+			delete(s.userRateLimitViolations, userID)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package chat
+
+import (
+	"testing"
+	"time"
+
+	"github.com/owncast/owncast/core/user"
+	"github.com/owncast/owncast/models"
+)
+
+func TestGetChatRateLimit(t *testing.T) {
+	policy := models.ChatRateLimitPolicy{
+		Anonymous:     models.ChatRateLimit{EventsPerSecond: 1},
+		Authenticated: models.ChatRateLimit{EventsPerSecond: 2},
+		Moderator:     models.ChatRateLimit{EventsPerSecond: 3},
+		Bot:           models.ChatRateLimit{EventsPerSecond: 4},
+	}
+
+	tests := []struct {
+		user     *user.User
+		expected float64
+	}{
+		{nil, 1},
+		{&user.User{}, 1},
+		{&user.User{Authenticated: true}, 2},
+		{&user.User{Authenticated: true, Scopes: []string{"MODERATOR"}}, 3},
+		{&user.User{IsBot: true}, 4},
+	}
+
+	for _, test := range tests {
+		if limit := getChatRateLimit(policy, test.user); limit.EventsPerSecond != test.expected {
+			t.Errorf("%+v: expected %v events per second, got %v", test.user, test.expected, limit.EventsPerSecond)
+		}
+	}
+}
+
+func TestRecordRateLimitViolation(t *testing.T) {
+	s := &Server{userRateLimitViolations: map[string][]time.Time{}}
+	policy := models.ChatRateLimitPolicy{
+		EscalationThreshold:      3,
+		EscalationWindowSeconds:  60,
+		EscalationTimeoutSeconds: 300,
+	}
+	now := time.Now()
+
+	if s.recordRateLimitViolation("user", policy, now) || s.recordRateLimitViolation("user", policy, now.Add(10*time.Second)) {
+		t.Error("expected no escalation below the threshold")
+	}
+
+	// The first violation has left the window.
+	if s.recordRateLimitViolation("user", policy, now.Add(65*time.Second)) {
+		t.Error("expected violations outside the window not to count")
+	}
+
+	if !s.recordRateLimitViolation("user", policy, now.Add(66*time.Second)) {
+		t.Error("expected an escalation at the threshold")
+	}
+
+	if s.recordRateLimitViolation("user", policy, now.Add(67*time.Second)) {
+		t.Error("expected violations to be forgotten after an escalation")
+	}
+}
+
+func TestRemoveStaleRateLimitViolations(t *testing.T) {
+	s := &Server{userRateLimitViolations: map[string][]time.Time{}}
+	policy := models.ChatRateLimitPolicy{
+		EscalationThreshold:      3,
+		EscalationWindowSeconds:  60,
+		EscalationTimeoutSeconds: 300,
+	}
+	now := time.Now()
+
+	s.recordRateLimitViolation("stale", policy, now)
+	s.recordRateLimitViolation("recent", policy, now.Add(30*time.Second))
+
+	s.removeStaleRateLimitViolations(policy, now.Add(70*time.Second))
+	if _, exists := s.userRateLimitViolations["stale"]; exists {
+		t.Error("expected violations outside the window to be removed")
+	}
+	if _, exists := s.userRateLimitViolations["recent"]; !exists {
+		t.Error("expected violations inside the window to be kept")
+	}
+
+	policy.EscalationThreshold = 0
+	s.removeStaleRateLimitViolations(policy, now.Add(70*time.Second))
+	if len(s.userRateLimitViolations) != 0 {
+		t.Error("expected all violations to be removed when escalation is off")
+	}
+}
+
+func TestRejectRateLimitedClientOncePerRejection(t *testing.T) {
+	s := &Server{userRateLimitViolations: map[string][]time.Time{}}
+	policy := models.ChatRateLimitPolicy{
+		RejectionSeconds:         10,
+		EscalationThreshold:      3,
+		EscalationWindowSeconds:  60,
+		EscalationTimeoutSeconds: 300,
+	}
+	c := &Client{User: &user.User{ID: "flooder"}}
+	now := time.Now()
+
+	for i := 0; i < 5; i++ {
+		if s.rejectRateLimitedClient(c, policy, now.Add(time.Duration(i)*time.Second)) {
+			t.Fatal("expected no escalation from a single flood")
+		}
+	}
+
+	if count := len(s.userRateLimitViolations["flooder"]); count != 1 {
+		t.Errorf("expected 1 violation for a single rejection, got %d", count)
+	}
+}
@@ -0,0 +1,168 @@
+package chat
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/owncast/owncast/core/chat/events"
+	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/core/user"
+	"github.com/owncast/owncast/core/webhooks"
+)
+
+// maxReactionEmojiRunes is the most runes a unicode emoji reaction can be
+// made of, allowing for skin tones and joined sequences.
+const maxReactionEmojiRunes = 10
+
+func (s *Server) userReactionSent(eventData chatClientEvent) {
+	var event events.ReactionEvent
+	if err := json.Unmarshal(eventData.data, &event); err != nil {
+		log.Errorln("error unmarshalling to ReactionEvent", err)
+		return
+	}
+
+	event.SetDefaults()
+	event.ClientID = eventData.client.Id
+	event.ChannelID = eventData.client.ChannelID
+
+	event.User = user.GetUserByToken(eventData.client.accessToken)
+	if event.User == nil {
+		return
+	}
+
+	if until, timedOut := s.getUserTimeout(event.User.ID); timedOut {
+		s.sendActionToClient(eventData.client, fmt.Sprintf("You have been timed out and can react to messages again in %d seconds.", events.GetRemainingSeconds(until)))
+		return
+	}
+
+	emoji, valid := normalizeReactionEmoji(event.Emoji)
+	if !valid {
+		return
+	}
+	event.Emoji = emoji
+
+	// Only visible user messages in the same channel, and in a chat room the
+	// client is in, can be reacted to.
+	channelID, roomID, exists := getReactableMessageRoom(event.MessageID)
+	if !exists || channelID != event.ChannelID || !canSendToChatRoom(eventData.client, roomID) {
+		return
+	}
+	event.RoomID = roomID
+
+	var changed bool
+	var err error
+	if event.Removed {
+		changed, err = data.RemoveMessageReaction(event.MessageID, event.User.ID, event.Emoji)
+	} else {
+		changed, err = data.AddMessageReaction(event.MessageID, event.User.ID, event.Emoji)
+	}
+	if err != nil {
+		log.Errorln("error saving chat message reaction", err)
+		return
+	}
+	if !changed {
+		return
+	}
+
+	reactions, err := data.GetMessageReactions([]string{event.MessageID})
+	if err != nil {
+		log.Errorln("error fetching chat message reactions", err)
+	}
+	event.Reactions = reactions[event.MessageID]
+
+	if err := s.BroadcastToChatRoom(event.GetBroadcastPayload(), event.ChannelID, event.RoomID); err != nil {
+		log.Errorln("error broadcasting ReactionEvent payload", err)
+		return
+	}
+
+	webhooks.SendChatEventReaction(event)
+}
+
+// normalizeReactionEmoji will return the emoji to save for a reaction if it
+// is a unicode emoji or one of the custom emoji. Custom emoji may be given
+// with or without surrounding colons.
+func normalizeReactionEmoji(emoji string) (string, bool) {
+	emoji = strings.TrimSpace(emoji)
+	if emoji == "" {
+		return "", false
+	}
+
+	name := strings.Trim(emoji, ":")
+	for _, customEmoji := range data.GetEmojiList() {
+		if strings.EqualFold(customEmoji.Name, name) {
+			return customEmoji.Name, true
+		}
+	}
+
+	return emoji, isUnicodeEmoji(emoji)
+}
+
+// isUnicodeEmoji will return if a string is a single unicode emoji, including
+// modifiers such as skin tones and zero width joined sequences.
+func isUnicodeEmoji(value string) bool {
+	runes := []rune(value)
+	if len(runes) == 0 || len(runes) > maxReactionEmojiRunes {
+		return false
+	}
+
+	hasSymbol := false
+	for _, r := range runes {
+		switch {
+		case unicode.Is(unicode.So, r):
+			hasSymbol = true
+		case unicode.Is(unicode.Sk, r), unicode.Is(unicode.Mn, r), unicode.Is(unicode.Me, r), r == '\u200d':
+		default:
+			return false
+		}
+	}
+
+	return hasSymbol
+}
+
+// getReactableMessageRoom will return the channel and chat room of a visible
+// user message.
+func getReactableMessageRoom(messageID string) (string, string, bool) {
+	if messageID == "" {
+		return "", "", false
+	}
+
+	var channelID, roomID string
+	row := _datastore.DB.QueryRow("SELECT channel, room FROM messages WHERE id = ? AND hidden_at IS NULL AND eventType = ?", messageID, events.MessageSent)
+	if err := row.Scan(&channelID, &roomID); err != nil {
+		if err != sql.ErrNoRows {
+			log.Errorln("error fetching chat message", err)
+		}
+		return "", "", false
+	}
+
+	return channelID, roomID, true
+}
+
+// addReactionsToHistory will add the aggregated reactions to each user
+// message in a chat history.
+func addReactionsToHistory(history []interface{}) {
+	ids := []string{}
+	for _, item := range history {
+		if message, ok := item.(events.UserMessageEvent); ok {
+			ids = append(ids, message.ID)
+		}
+	}
+
+	reactions, err := data.GetMessageReactions(ids)
+	if err != nil {
+		log.Errorln("error fetching chat message reactions", err)
+		return
+	}
+
+	for i, item := range history {
+		if message, ok := item.(events.UserMessageEvent); ok {
+			message.Reactions = reactions[message.ID]
+			history[i] = message
+		}
+	}
+}
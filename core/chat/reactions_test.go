@@ -0,0 +1,24 @@
+package chat
+
+import "testing"
+
+func TestIsUnicodeEmoji(t *testing.T) {
+	tests := map[string]bool{
+		"👍":           true,
+		"❤️":          true,
+		"👍🏽":          true,
+		"👩‍💻":         true,
+		"🇨🇦":          true,
+		"":            false,
+		"a":           false,
+		"👍 nice":      false,
+		":smile:":     false,
+		"<img src=x>": false,
+	}
+
+	for value, expected := range tests {
+		if result := isUnicodeEmoji(value); result != expected {
+			t.Errorf("%q: expected %t, got %t", value, expected, result)
+		}
+	}
+}
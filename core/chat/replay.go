@@ -0,0 +1,92 @@
+package chat
+
+import (
+	"time"
+
+	"github.com/owncast/owncast/models"
+	"github.com/pkg/errors"
+)
+
+// maxReplayMessages is the most messages returned for a single window of a
+// recorded stream so a busy chat can't be fetched all at once.
+const maxReplayMessages = 500
+
+// ReplayMessage is a chat event sent during a recorded stream.
+type ReplayMessage struct {
+	Message interface{} `json:"message"`
+	// Offset is the number of seconds into the stream the event was sent at.
+	Offset float64 `json:"offset"`
+}
+
+// getStreamOffset returns how long after the channel's stream started an event
+// was sent, or nil if the channel is not streaming.
+func getStreamOffset(channelID string, timestamp time.Time) *int64 {
+	if getStatus == nil {
+		return nil
+	}
+
+	return streamOffset(getStatus(channelID), timestamp)
+}
+
+// streamOffset returns the number of milliseconds between the stream
+// connecting and the timestamp.
+func streamOffset(status models.Status, timestamp time.Time) *int64 {
+	if !status.Online || status.LastConnectTime == nil || !status.LastConnectTime.Valid {
+		return nil
+	}
+
+	offset := timestamp.Sub(status.LastConnectTime.Time).Milliseconds()
+	if offset < 0 {
+		offset = 0
+	}
+
+	return &offset
+}
+
+// GetRecordingChat will return the visible chat events sent between the from
+// and to offsets of a recorded stream, in the order they were sent.
+func GetRecordingChat(recording models.Recording, from, to time.Duration) ([]ReplayMessage, error) {
+	endedAt := time.Now()
+	if recording.EndedAt != nil {
+		endedAt = *recording.EndedAt
+	}
+
+	query := "SELECT messages.id, messages.user_id, messages.body, messages.title, messages.subtitle, messages.image, messages.link, messages.eventType, messages.hidden_at, messages.timestamp, messages.reply_to, messages.edited_at, messages.deleted_at, messages.room, users.display_name, users.display_color, users.created_at, users.disabled_at, users.previous_names, users.namechanged_at, users.authenticated_at, users.scopes, users.type, messages.stream_offset FROM messages LEFT JOIN users ON users.id = messages.user_id WHERE messages.hidden_at IS NULL AND users.disabled_at IS NULL AND messages.channel = ? AND messages.room = ? AND messages.timestamp >= ? AND messages.timestamp <= ? AND messages.stream_offset >= ? AND messages.stream_offset <= ? ORDER BY messages.stream_offset ASC LIMIT ?"
+
+	rows, err := _datastore.DB.Query(query, models.DefaultChannelID, models.DefaultChatRoomID, recording.StartedAt, endedAt, from.Milliseconds(), to.Milliseconds(), maxReplayMessages)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to query chat of recording "+recording.ID)
+	}
+	defer rows.Close()
+
+	history := []interface{}{}
+	offsets := []int64{}
+	for rows.Next() {
+		row := rowData{}
+		var offset int64
+		if err := rows.Scan(append(row.scanDestinations(), &offset)...); err != nil {
+			return nil, errors.Wrap(err, "unable to read chat of recording "+recording.ID)
+		}
+
+		if message := makeChatEventFromRowData(row); message != nil {
+			history = append(history, message)
+			offsets = append(offsets, offset)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, errors.Wrap(err, "unable to read chat of recording "+recording.ID)
+	}
+
+	addReactionsToHistory(history)
+
+	replay := make([]ReplayMessage, len(history))
+	for i, message := range history {
+		replay[i] = ReplayMessage{
+			Message: message,
+			Offset:  float64(offsets[i]) / 1000,
+		}
+	}
+
+	return replay, nil
+}
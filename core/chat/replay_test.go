@@ -0,0 +1,29 @@
+package chat
+
+import (
+	"testing"
+	"time"
+
+	"github.com/owncast/owncast/models"
+	"github.com/owncast/owncast/utils"
+)
+
+func TestStreamOffset(t *testing.T) {
+	connectedAt := time.Now().Add(-time.Hour)
+	status := models.Status{
+		Online:          true,
+		LastConnectTime: &utils.NullTime{Time: connectedAt, Valid: true},
+	}
+
+	if offset := streamOffset(status, connectedAt.Add(90*time.Second)); offset == nil || *offset != 90000 {
+		t.Errorf("expected an offset of 90000ms, got %v", offset)
+	}
+
+	if offset := streamOffset(status, connectedAt.Add(-time.Second)); offset == nil || *offset != 0 {
+		t.Errorf("expected events before the stream connected to be at the start, got %v", offset)
+	}
+
+	if offset := streamOffset(models.Status{}, connectedAt); offset != nil {
+		t.Errorf("expected no offset while offline, got %d", *offset)
+	}
+}
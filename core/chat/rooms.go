@@ -0,0 +1,161 @@
+package chat
+
+import (
+	"encoding/json"
+	"errors"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/owncast/owncast/core/chat/events"
+	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/core/user"
+	"github.com/owncast/owncast/models"
+	"github.com/owncast/owncast/utils"
+)
+
+func (s *Server) userJoinedChatRoom(eventData chatClientEvent) {
+	var event events.ChatRoomEvent
+	if err := json.Unmarshal(eventData.data, &event); err != nil {
+		log.Errorln("error unmarshalling to ChatRoomEvent", err)
+		return
+	}
+
+	c := eventData.client
+	room, exists := data.GetChatRoom(event.RoomID)
+	if !exists || event.RoomID == models.DefaultChatRoomID || !canJoinChatRoom(room, c.User) {
+		s.sendActionToClient(c, "You are not able to join this chat room.")
+		return
+	}
+
+	c.mu.Lock()
+	c.rooms[room.ID] = true
+	c.mu.Unlock()
+
+	joinedEvent := events.ChatRoomJoinedEvent{
+		Room:    room,
+		History: GetChatRoomHistory(c.ChannelID, room.ID),
+	}
+	joinedEvent.SetDefaults()
+	s.Send(joinedEvent.GetBroadcastPayload(), c)
+}
+
+func (s *Server) userLeftChatRoom(eventData chatClientEvent) {
+	var event events.ChatRoomEvent
+	if err := json.Unmarshal(eventData.data, &event); err != nil {
+		log.Errorln("error unmarshalling to ChatRoomEvent", err)
+		return
+	}
+
+	c := eventData.client
+	c.mu.Lock()
+	_, joined := c.rooms[event.RoomID]
+	delete(c.rooms, event.RoomID)
+	c.mu.Unlock()
+
+	if !joined {
+		return
+	}
+
+	leftEvent := events.ChatRoomLeftEvent{RoomID: event.RoomID}
+	leftEvent.SetDefaults()
+	s.Send(leftEvent.GetBroadcastPayload(), c)
+}
+
+// isInChatRoom will return if a client has joined a chat room. Every client
+// is in the main chat.
+func (c *Client) isInChatRoom(roomID string) bool {
+	if roomID == models.DefaultChatRoomID {
+		return true
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.rooms[roomID]
+}
+
+// canSendToChatRoom will return if a client is in a chat room and is still
+// allowed to be.
+func canSendToChatRoom(c *Client, roomID string) bool {
+	if roomID == models.DefaultChatRoomID {
+		return true
+	}
+
+	room, exists := data.GetChatRoom(roomID)
+	return exists && c.isInChatRoom(roomID) && canJoinChatRoom(room, c.User)
+}
+
+// canJoinChatRoom will return if a user is allowed into a chat room.
+func canJoinChatRoom(room models.ChatRoom, u *user.User) bool {
+	if !room.ModeratorsOnly {
+		return true
+	}
+
+	if u == nil {
+		return false
+	}
+
+	if u.IsModerator() {
+		return true
+	}
+
+	_, isGuest := utils.FindInSlice(room.Guests, u.ID)
+	return isGuest
+}
+
+// ValidateChatRoom will return an error if a channel or its chat room does
+// not exist, or the user is not allowed into the chat room.
+func ValidateChatRoom(channelID string, roomID string, u *user.User) error {
+	if channelID != models.DefaultChannelID {
+		if _, exists := data.GetChannel(channelID); !exists {
+			return errors.New("unknown channel " + channelID)
+		}
+	}
+
+	if roomID == models.DefaultChatRoomID {
+		return nil
+	}
+
+	room, exists := data.GetChatRoom(roomID)
+	if !exists {
+		return errors.New("unknown chat room " + roomID)
+	}
+	if !canJoinChatRoom(room, u) {
+		return errors.New("not allowed in chat room " + roomID)
+	}
+
+	return nil
+}
+
+// GetChatRoomsForUser will return the chat rooms a user is allowed to join.
+func GetChatRoomsForUser(u *user.User) []models.ChatRoom {
+	rooms := []models.ChatRoom{}
+	for _, room := range data.GetChatRooms() {
+		if canJoinChatRoom(room, u) {
+			// Only moderators need to know who the guests are.
+			if u == nil || !u.IsModerator() {
+				room.Guests = nil
+			}
+			rooms = append(rooms, room)
+		}
+	}
+
+	return rooms
+}
+
+// BroadcastToChatRoom sends message to all clients in a chat room of a
+// channel.
+func (s *Server) BroadcastToChatRoom(payload events.EventPayload, channelID string, roomID string) error {
+	if roomID == models.DefaultChatRoomID {
+		return s.BroadcastToChannel(payload, channelID)
+	}
+
+	room, exists := data.GetChatRoom(roomID)
+	if !exists {
+		return nil
+	}
+
+	return s.broadcast(payload, func(client *Client) bool {
+		return client.ChannelID == channelID && client.isInChatRoom(roomID) && canJoinChatRoom(room, client.User)
+	})
+}
@@ -0,0 +1,31 @@
+package chat
+
+import (
+	"testing"
+
+	"github.com/owncast/owncast/core/user"
+	"github.com/owncast/owncast/models"
+)
+
+func TestCanJoinChatRoom(t *testing.T) {
+	backstage := models.ChatRoom{ID: "backstage", ModeratorsOnly: true, Guests: []string{"guest"}}
+	moderator := &user.User{ID: "moderator", Scopes: []string{"MODERATOR"}}
+	guest := &user.User{ID: "guest"}
+	viewer := &user.User{ID: "viewer"}
+
+	if !canJoinChatRoom(models.ChatRoom{ID: "es"}, viewer) {
+		t.Error("expected anybody to be able to join an open room")
+	}
+	if !canJoinChatRoom(backstage, moderator) {
+		t.Error("expected moderators to be able to join a moderator only room")
+	}
+	if !canJoinChatRoom(backstage, guest) {
+		t.Error("expected guests to be able to join a moderator only room")
+	}
+	if canJoinChatRoom(backstage, viewer) {
+		t.Error("expected other users not to be able to join a moderator only room")
+	}
+	if canJoinChatRoom(backstage, nil) {
+		t.Error("expected a missing user not to be able to join a moderator only room")
+	}
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/owncast/owncast/core/user"
 	"github.com/owncast/owncast/core/webhooks"
 	"github.com/owncast/owncast/geoip"
+	"github.com/owncast/owncast/models"
 	"github.com/owncast/owncast/utils"
 )
 
@@ -38,7 +39,13 @@ type Server struct {
 	geoipClient *geoip.Client
 
 	// a map of user IDs and timers that fire for chat part messages.
-	userPartedTimers         map[string]*time.Ticker
+	userPartedTimers map[string]*time.Ticker
+	// a map of user IDs and when they are allowed to send messages again.
+	userTimeouts map[string]time.Time
+	// a map of user IDs and when they last sent a message, for slow mode.
+	userLastMessageAt map[string]time.Time
+	// a map of user IDs and when they recently exceeded their rate limit.
+	userRateLimitViolations  map[string][]time.Time
 	seq                      uint
 	maxSocketConnectionLimit int64
 
@@ -58,6 +65,16 @@ func NewChat() *Server {
 		maxSocketConnectionLimit: maximumConcurrentConnectionLimit,
 		geoipClient:              geoip.NewClient(),
 		userPartedTimers:         map[string]*time.Ticker{},
+		userTimeouts:             map[string]time.Time{},
+		userLastMessageAt:        map[string]time.Time{},
+		userRateLimitViolations:  map[string][]time.Time{},
+	}
+
+	// Timeouts are kept across restarts.
+	if timeouts, err := user.GetTimedOutUsers(); err != nil {
+		log.Errorln("error loading timed out chat users", err)
+	} else {
+		server.userTimeouts = timeouts
 	}
 
 	return server
@@ -82,14 +99,17 @@ func (s *Server) Run() {
 }
 
 // Addclient registers new connection as a User.
-func (s *Server) Addclient(conn *websocket.Conn, user *user.User, accessToken string, userAgent string, ipAddress string) *Client {
+func (s *Server) Addclient(conn *websocket.Conn, user *user.User, accessToken string, userAgent string, ipAddress string, channelID string) *Client {
 	client := &Client{
+		ChannelID:   channelID,
 		server:      s,
 		conn:        conn,
 		User:        user,
 		IPAddress:   ipAddress,
 		accessToken: accessToken,
 		send:        make(chan []byte, 256),
+		rooms:       map[string]bool{},
+		rateLimiter: newRateLimiter(getChatRateLimit(data.GetChatRateLimitPolicy(), user)),
 		UserAgent:   userAgent,
 		ConnectedAt: time.Now(),
 	}
@@ -119,8 +139,9 @@ func (s *Server) Addclient(conn *websocket.Conn, user *user.User, accessToken st
 	go client.readPump()
 
 	client.sendConnectedClientInfo()
+	s.sendPinnedMessageToClient(client)
 
-	if getStatus().Online {
+	if getStatus(channelID).Online {
 		if shouldSendJoinedMessages {
 			s.sendUserJoinedMessage(client)
 		}
@@ -140,8 +161,9 @@ func (s *Server) sendUserJoinedMessage(c *Client) {
 	userJoinedEvent.SetDefaults()
 	userJoinedEvent.User = c.User
 	userJoinedEvent.ClientID = c.Id
+	userJoinedEvent.ChannelID = c.ChannelID
 
-	if err := s.Broadcast(userJoinedEvent.GetBroadcastPayload()); err != nil {
+	if err := s.BroadcastToChannel(userJoinedEvent.GetBroadcastPayload(), c.ChannelID); err != nil {
 		log.Errorln("error adding client to chat server", err)
 	}
 
@@ -177,10 +199,11 @@ func (s *Server) sendUserPartedMessage(c *Client) {
 	userPartEvent.SetDefaults()
 	userPartEvent.User = c.User
 	userPartEvent.ClientID = c.Id
+	userPartEvent.ChannelID = c.ChannelID
 
 	// If part messages are disabled.
 	if data.GetChatJoinPartMessagesEnabled() {
-		if err := s.Broadcast(userPartEvent.GetBroadcastPayload()); err != nil {
+		if err := s.BroadcastToChannel(userPartEvent.GetBroadcastPayload(), c.ChannelID); err != nil {
 			log.Errorln("error sending chat part message", err)
 		}
 	}
@@ -206,6 +229,14 @@ func (s *Server) HandleClientConnection(w http.ResponseWriter, r *http.Request)
 		log.Errorln("error determining if IP address is blocked: ", err)
 	}
 
+	// Each channel has its own chat.
+	channelID := r.URL.Query().Get("channel")
+	if _, exists := data.GetChannel(channelID); channelID != models.DefaultChannelID && !exists {
+		log.Debugln("Rejecting client connection to unknown channel", logSanitize(channelID))
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
 	// Limit concurrent chat connections
 	if int64(len(s.clients)) >= s.maxSocketConnectionLimit {
 		log.Warnln("rejecting incoming client connection as it exceeds the max client count of", s.maxSocketConnectionLimit)
@@ -244,22 +275,36 @@ func (s *Server) HandleClientConnection(w http.ResponseWriter, r *http.Request)
 	}
 
 	// User is disabled therefore we should disconnect.
-	if user.DisabledAt != nil {
+	if !user.IsEnabled() {
 		log.Traceln("Disabled user", user.ID, user.DisplayName, "rejected")
-		_ = conn.WriteJSON(events.EventPayload{
+		payload := events.EventPayload{
 			"type": events.ErrorUserDisabled,
-		})
+		}
+		if user.DisabledUntil != nil {
+			payload["disabledUntil"] = user.DisabledUntil
+			payload["remainingSeconds"] = events.GetRemainingSeconds(*user.DisabledUntil)
+		}
+		_ = conn.WriteJSON(payload)
 		_ = conn.Close()
 		return
 	}
 
 	userAgent := r.UserAgent()
 
-	s.Addclient(conn, user, accessToken, userAgent, ipAddress)
+	s.Addclient(conn, user, accessToken, userAgent, ipAddress, channelID)
 }
 
 // Broadcast sends message to all connected clients.
 func (s *Server) Broadcast(payload events.EventPayload) error {
+	return s.broadcast(payload, func(*Client) bool { return true })
+}
+
+// BroadcastToChannel sends message to all clients connected to the chat of a channel.
+func (s *Server) BroadcastToChannel(payload events.EventPayload, channelID string) error {
+	return s.broadcast(payload, func(client *Client) bool { return client.ChannelID == channelID })
+}
+
+func (s *Server) broadcast(payload events.EventPayload, shouldSend func(*Client) bool) error {
 	data, err := json.Marshal(payload)
 	if err != nil {
 		return err
@@ -269,7 +314,7 @@ func (s *Server) Broadcast(payload events.EventPayload) error {
 	defer s.mu.RUnlock()
 
 	for _, client := range s.clients {
-		if client == nil {
+		if client == nil || !shouldSend(client) {
 			continue
 		}
 
@@ -302,6 +347,8 @@ func (s *Server) DisconnectClients(clients []*Client) {
 		go func(client *Client) {
 			event := events.UserDisabledEvent{}
 			event.SetDefaults()
+			event.User = client.User
+			event.DisabledUntil = client.User.DisabledUntil
 
 			// Send this disabled event specifically to this single connected client
 			// to let them know they've been banned.
@@ -338,9 +385,12 @@ func SendConnectedClientInfoToUser(userID string) error {
 		return err
 	}
 
+	policy := data.GetChatRateLimitPolicy()
 	for _, client := range clients {
 		// Update the client's reference to its user.
 		client.User = user
+		// Their role, and so their rate limit, may have changed.
+		client.updateRateLimit(policy)
 		// Send the update to the client.
 		client.sendConnectedClientInfo()
 	}
@@ -385,6 +435,27 @@ func (s *Server) eventReceived(event chatClientEvent) {
 	case events.MessageSent:
 		s.userMessageSent(event)
 
+	case events.MessageReaction:
+		s.userReactionSent(event)
+
+	case events.MessageEdited:
+		s.userMessageEdited(event)
+
+	case events.MessageDeleted:
+		s.userMessageDeleted(event)
+
+	case events.JoinChatRoom:
+		s.userJoinedChatRoom(event)
+
+	case events.LeaveChatRoom:
+		s.userLeftChatRoom(event)
+
+	case events.DirectMessageSent:
+		s.userDirectMessageSent(event)
+
+	case events.AbuseReportSent:
+		s.userAbuseReportSent(event)
+
 	case events.UserNameChanged:
 		s.userNameChanged(event)
 
@@ -406,7 +477,7 @@ func (s *Server) sendWelcomeMessageToClient(c *Client) {
 	}
 }
 
-func (s *Server) sendAllWelcomeMessage() {
+func (s *Server) sendAllWelcomeMessage(channelID string) {
 	welcomeMessage := utils.RenderSimpleMarkdown(data.GetServerWelcomeMessage())
 
 	if welcomeMessage != "" {
@@ -417,7 +488,7 @@ func (s *Server) sendAllWelcomeMessage() {
 			},
 		}
 		clientMessage.SetDefaults()
-		_ = s.Broadcast(clientMessage.GetBroadcastPayload())
+		_ = s.BroadcastToChannel(clientMessage.GetBroadcastPayload(), channelID)
 	}
 }
 
@@ -446,3 +517,30 @@ func (s *Server) sendActionToClient(c *Client, message string) {
 	clientMessage.RenderBody()
 	s.Send(clientMessage.GetBroadcastPayload(), c)
 }
+
+// TimeoutUser will stop a user from sending chat messages until a point in
+// time, including from clients that connect later.
+func (s *Server) TimeoutUser(userID string, until time.Time) {
+	s.mu.Lock()
+	s.userTimeouts[userID] = until
+	s.mu.Unlock()
+
+	if err := user.SetTimedOutUntil(userID, until); err != nil {
+		log.Errorln("error saving chat user timeout", err)
+	}
+}
+
+// getUserTimeout will return when a timed out user is allowed to send
+// messages again.
+func (s *Server) getUserTimeout(userID string) (time.Time, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	until, timedOut := s.userTimeouts[userID]
+	if timedOut && time.Now().After(until) {
+		delete(s.userTimeouts, userID)
+		return until, false
+	}
+
+	return until, timedOut
+}
@@ -11,6 +11,8 @@ import (
 	"github.com/owncast/owncast/config"
 	"github.com/owncast/owncast/core/chat"
 	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/core/recording"
+	"github.com/owncast/owncast/core/restream"
 	"github.com/owncast/owncast/core/rtmp"
 	"github.com/owncast/owncast/core/transcoder"
 	"github.com/owncast/owncast/core/user"
@@ -58,6 +60,8 @@ func Start() error {
 
 	user.SetupUsers()
 	auth.Setup(data.GetDatastore())
+	recording.Setup(data.GetDatastore())
+	restream.Update(data.GetRestreamDestinations())
 
 	fileWriter.SetupFileWriterReceiverService(&handler)
 
@@ -68,7 +72,7 @@ func Start() error {
 
 	_yp = yp.NewYP(GetStatus)
 
-	if err := chat.Start(GetStatus); err != nil {
+	if err := chat.Start(GetChannelStatus); err != nil {
 		log.Errorln(err)
 	}
 
@@ -80,7 +84,13 @@ func Start() error {
 		log.Infof("RTMP is accepting inbound streams on port %d.", rtmpPort)
 	}
 
-	webhooks.SetupWebhooks(GetStatus)
+	// start the srt server if enabled
+	if srtPort := data.GetSRTPortNumber(); srtPort > 0 {
+		go rtmp.StartSRT(setStreamAsConnected, setBroadcaster)
+		log.Infof("SRT is accepting inbound streams on port %d.", srtPort)
+	}
+
+	webhooks.SetupWebhooks(GetChannelStatus)
 
 	notifications.Setup(data.GetStore())
 
@@ -110,6 +120,7 @@ func transitionToOfflineVideoStreamContent() {
 	}
 
 	_transcoder.SetInput(offlineFilePath)
+	handler.SetChannelTranscoder(models.DefaultChannelID, _transcoder)
 	go _transcoder.Start(false)
 
 	// Copy the logo to be the thumbnail
@@ -126,8 +137,9 @@ func transitionToOfflineVideoStreamContent() {
 func resetDirectories() {
 	log.Trace("Resetting file directories to a clean slate.")
 
-	// Wipe hls data directory
-	utils.CleanupDirectory(config.HLSStoragePath)
+	// Wipe hls data directory, leaving the directories of other channels
+	// that may still be live.
+	transcoder.CleanupHLSDirectory(models.DefaultChannelID)
 
 	// Remove the previous thumbnail
 	logo := data.GetLogoPath()
@@ -0,0 +1,421 @@
+package dash
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/grafov/m3u8"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/owncast/owncast/config"
+	"github.com/owncast/owncast/core/playlist"
+	"github.com/owncast/owncast/models"
+	"github.com/owncast/owncast/utils"
+)
+
+// The MPEG-DASH manifest is built from the fMP4 (CMAF) segments listed in
+// the HLS variant playlists, so DASH players share the same segments as HLS
+// players. It is rewritten every time a variant playlist changes.
+
+const (
+	manifestFilename = "stream.mpd"
+	// Segment times in the manifest are in milliseconds.
+	timescale = 1000
+)
+
+// segment is a single segment of a variant in the manifest timeline.
+type segment struct {
+	start    int64
+	duration int64
+}
+
+// variantTimeline is the timeline of the segments of a single variant.
+type variantTimeline struct {
+	identifier  string
+	initSegment string
+	// Segments known by their sequence number.
+	segments map[int]segment
+	// The sequence numbers currently listed in the variant playlist.
+	current []int
+}
+
+var (
+	_outputVariants []models.StreamOutputVariant
+	_latencyLevel   models.LatencyLevel
+	_timelines      map[string]*variantTimeline
+	_codecs         map[string]string
+	// When the first segment of the stream became available.
+	_availabilityStartTime time.Time
+	_lock                  sync.Mutex
+)
+
+// GetManifestPath will return the local path of the DASH manifest.
+func GetManifestPath() string {
+	return filepath.Join(config.HLSStoragePath, manifestFilename)
+}
+
+// Start will start generating a DASH manifest for the stream variants.
+func Start(outputVariants []models.StreamOutputVariant, latencyLevel models.LatencyLevel) {
+	_lock.Lock()
+	defer _lock.Unlock()
+
+	_outputVariants = outputVariants
+	_latencyLevel = latencyLevel
+	_timelines = make(map[string]*variantTimeline)
+	_codecs = nil
+	_availabilityStartTime = time.Time{}
+}
+
+// Stop will stop generating the DASH manifest and remove it.
+func Stop() {
+	_lock.Lock()
+	defer _lock.Unlock()
+
+	_timelines = nil
+
+	if err := os.Remove(GetManifestPath()); err != nil && !os.IsNotExist(err) {
+		log.Warnln("unable to remove DASH manifest", err)
+	}
+}
+
+// VariantPlaylistWritten will update the timeline of a variant and rewrite
+// the DASH manifest. Returns the path of the manifest if it was written.
+func VariantPlaylistWritten(localFilePath string) (string, bool) {
+	_lock.Lock()
+	defer _lock.Unlock()
+
+	if _timelines == nil {
+		return "", false
+	}
+
+	mediaPlaylist, err := readMediaPlaylist(localFilePath)
+	if err != nil {
+		log.Debugln("unable to read variant playlist for DASH manifest", err)
+		return "", false
+	}
+
+	// DASH is only able to use fMP4 segments.
+	if mediaPlaylist.Map == nil {
+		return "", false
+	}
+
+	index := utils.GetIndexFromFilePath(localFilePath)
+	if !updateTimeline(index, mediaPlaylist) {
+		return "", false
+	}
+
+	if _codecs == nil {
+		_codecs = readCodecs(filepath.Join(config.HLSStoragePath, "stream.m3u8"))
+	}
+
+	manifest, err := buildManifest(time.Now())
+	if err != nil {
+		log.Errorln("unable to build DASH manifest", err)
+		return "", false
+	}
+
+	if err := playlist.WritePlaylist(manifest, GetManifestPath()); err != nil {
+		log.Errorln("unable to write DASH manifest", err)
+		return "", false
+	}
+
+	return GetManifestPath(), true
+}
+
+func readMediaPlaylist(playlistPath string) (*m3u8.MediaPlaylist, error) {
+	f, err := os.Open(playlistPath) // nolint: gosec
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	p, listType, err := m3u8.DecodeFrom(bufio.NewReader(f), false)
+	if err != nil {
+		return nil, err
+	}
+
+	mediaPlaylist, ok := p.(*m3u8.MediaPlaylist)
+	if listType != m3u8.MEDIA || !ok {
+		return nil, errors.New("not a media playlist: " + playlistPath)
+	}
+
+	return mediaPlaylist, nil
+}
+
+// readCodecs will return the codecs of each variant listed in the master playlist.
+func readCodecs(masterPlaylistPath string) map[string]string {
+	codecs := make(map[string]string)
+
+	f, err := os.Open(masterPlaylistPath) // nolint: gosec
+	if err != nil {
+		return codecs
+	}
+	defer f.Close()
+
+	p := m3u8.NewMasterPlaylist()
+	if err := p.DecodeFrom(bufio.NewReader(f), false); err != nil {
+		return codecs
+	}
+
+	for _, variant := range p.Variants {
+		if variant.Codecs != "" {
+			codecs[utils.GetIndexFromFilePath(variant.URI)] = variant.Codecs
+		}
+	}
+
+	return codecs
+}
+
+// parseSegmentFilename will return the segment identifier and sequence
+// number of a segment in the form of stream-{identifier}-{sequence}.m4s.
+func parseSegmentFilename(filename string) (string, int, bool) {
+	name := strings.TrimSuffix(strings.TrimPrefix(filename, "stream-"), ".m4s")
+	separator := strings.LastIndex(name, "-")
+	if separator < 1 || name == filename {
+		return "", 0, false
+	}
+
+	sequence, err := strconv.Atoi(name[separator+1:])
+	if err != nil {
+		return "", 0, false
+	}
+
+	return name[:separator], sequence, true
+}
+
+// updateTimeline will add any new segments of a variant to its timeline.
+// Returns false if the variant has no segments.
+func updateTimeline(index string, mediaPlaylist *m3u8.MediaPlaylist) bool {
+	segments := mediaPlaylist.GetAllSegments()
+	if len(segments) == 0 {
+		return false
+	}
+
+	identifier, _, ok := parseSegmentFilename(filepath.Base(segments[len(segments)-1].URI))
+	if !ok {
+		return false
+	}
+
+	timeline := _timelines[index]
+	if timeline == nil || timeline.identifier != identifier {
+		timeline = &variantTimeline{
+			identifier: identifier,
+			segments:   make(map[int]segment),
+		}
+		_timelines[index] = timeline
+	}
+	timeline.initSegment = filepath.Base(mediaPlaylist.Map.URI)
+	timeline.current = nil
+
+	for _, s := range segments {
+		_, sequence, ok := parseSegmentFilename(filepath.Base(s.URI))
+		if !ok {
+			continue
+		}
+
+		timeline.current = append(timeline.current, sequence)
+		if _, exists := timeline.segments[sequence]; exists {
+			continue
+		}
+
+		// Segments follow on from the one before them.
+		start := int64(0)
+		if previous, exists := timeline.segments[sequence-1]; exists {
+			start = previous.start + previous.duration
+		}
+		duration := int64(s.Duration * timescale)
+		timeline.segments[sequence] = segment{start: start, duration: duration}
+
+		// The stream became available once its first segment was complete.
+		if _availabilityStartTime.IsZero() {
+			_availabilityStartTime = time.Now().Add(-time.Duration(start+duration) * time.Millisecond)
+		}
+	}
+
+	// Forget about segments no longer in the variant playlist.
+	if len(timeline.current) > 0 {
+		for sequence := range timeline.segments {
+			if sequence < timeline.current[0]-1 {
+				delete(timeline.segments, sequence)
+			}
+		}
+	}
+
+	return len(timeline.current) > 0
+}
+
+type mpd struct {
+	XMLName                    xml.Name `xml:"urn:mpeg:dash:schema:mpd:2011 MPD"`
+	Profiles                   string   `xml:"profiles,attr"`
+	Type                       string   `xml:"type,attr"`
+	AvailabilityStartTime      string   `xml:"availabilityStartTime,attr"`
+	PublishTime                string   `xml:"publishTime,attr"`
+	MinimumUpdatePeriod        string   `xml:"minimumUpdatePeriod,attr"`
+	MinBufferTime              string   `xml:"minBufferTime,attr"`
+	TimeShiftBufferDepth       string   `xml:"timeShiftBufferDepth,attr"`
+	SuggestedPresentationDelay string   `xml:"suggestedPresentationDelay,attr"`
+	BaseURL                    string   `xml:"BaseURL"`
+	Period                     period   `xml:"Period"`
+}
+
+type period struct {
+	ID            string        `xml:"id,attr"`
+	Start         string        `xml:"start,attr"`
+	AdaptationSet adaptationSet `xml:"AdaptationSet"`
+}
+
+type adaptationSet struct {
+	MimeType         string           `xml:"mimeType,attr"`
+	SegmentAlignment bool             `xml:"segmentAlignment,attr"`
+	StartWithSAP     int              `xml:"startWithSAP,attr"`
+	Representations  []representation `xml:"Representation"`
+}
+
+type representation struct {
+	ID              string          `xml:"id,attr"`
+	Bandwidth       int             `xml:"bandwidth,attr"`
+	Codecs          string          `xml:"codecs,attr,omitempty"`
+	Width           int             `xml:"width,attr,omitempty"`
+	Height          int             `xml:"height,attr,omitempty"`
+	FrameRate       int             `xml:"frameRate,attr,omitempty"`
+	SegmentTemplate segmentTemplate `xml:"SegmentTemplate"`
+}
+
+type segmentTemplate struct {
+	Timescale       int             `xml:"timescale,attr"`
+	Initialization  string          `xml:"initialization,attr"`
+	Media           string          `xml:"media,attr"`
+	StartNumber     int             `xml:"startNumber,attr"`
+	SegmentTimeline segmentTimeline `xml:"SegmentTimeline"`
+}
+
+type segmentTimeline struct {
+	Segments []timelineSegment `xml:"S"`
+}
+
+type timelineSegment struct {
+	T int64 `xml:"t,attr"`
+	D int64 `xml:"d,attr"`
+}
+
+func formatDuration(d time.Duration) string {
+	return fmt.Sprintf("PT%.3fS", d.Seconds())
+}
+
+// buildManifest will return the DASH manifest of all the variants.
+func buildManifest(now time.Time) (string, error) {
+	segmentLength := time.Duration(_latencyLevel.SecondsPerSegment) * time.Second
+	if _latencyLevel.IsLowLatencyHLS() {
+		segmentLength = time.Duration(_latencyLevel.GetSecondsPerPart() * float64(time.Second))
+	}
+
+	manifest := mpd{
+		Profiles:                   "urn:mpeg:dash:profile:isoff-live:2011",
+		Type:                       "dynamic",
+		AvailabilityStartTime:      _availabilityStartTime.UTC().Format(time.RFC3339Nano),
+		PublishTime:                now.UTC().Format(time.RFC3339Nano),
+		MinimumUpdatePeriod:        formatDuration(segmentLength),
+		MinBufferTime:              formatDuration(segmentLength * 2),
+		TimeShiftBufferDepth:       formatDuration(segmentLength * time.Duration(_latencyLevel.GetMaxFilesPerVariant())),
+		SuggestedPresentationDelay: formatDuration(segmentLength * 3),
+		BaseURL:                    "/hls/",
+		Period: period{
+			ID:    "0",
+			Start: formatDuration(0),
+			AdaptationSet: adaptationSet{
+				// Audio and video are muxed together in each segment.
+				MimeType:         "video/mp4",
+				SegmentAlignment: true,
+				StartWithSAP:     1,
+			},
+		},
+	}
+
+	indexes := []string{}
+	for index := range _timelines {
+		indexes = append(indexes, index)
+	}
+	sort.Strings(indexes)
+
+	for _, index := range indexes {
+		timeline := _timelines[index]
+		variantIndex, err := strconv.Atoi(index)
+		if err != nil || variantIndex >= len(_outputVariants) || len(timeline.current) == 0 {
+			continue
+		}
+
+		manifest.Period.AdaptationSet.Representations = append(manifest.Period.AdaptationSet.Representations, getRepresentation(index, _outputVariants[variantIndex], timeline))
+	}
+
+	if len(manifest.Period.AdaptationSet.Representations) == 0 {
+		return "", errors.New("no variants have segments")
+	}
+
+	content, err := xml.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	return xml.Header + string(content) + "\n", nil
+}
+
+func getRepresentation(index string, variant models.StreamOutputVariant, timeline *variantTimeline) representation {
+	videoBitrate := variant.VideoBitrate
+	if videoBitrate == 0 {
+		videoBitrate = 1200
+	}
+
+	r := representation{
+		ID:        index,
+		Bandwidth: (videoBitrate + variant.AudioBitrate) * 1000,
+		Codecs:    _codecs[index],
+		Width:     variant.ScaledWidth,
+		Height:    variant.ScaledHeight,
+		FrameRate: variant.GetFramerate(),
+		SegmentTemplate: segmentTemplate{
+			Timescale:      timescale,
+			Initialization: index + "/" + timeline.initSegment,
+			Media:          index + "/stream-" + timeline.identifier + "-$Number$.m4s",
+			StartNumber:    timeline.current[0],
+		},
+	}
+
+	for _, sequence := range timeline.current {
+		s := timeline.segments[sequence]
+		r.SegmentTemplate.SegmentTimeline.Segments = append(r.SegmentTemplate.SegmentTimeline.Segments, timelineSegment{T: s.start, D: s.duration})
+	}
+
+	return r
+}
+
+// RewriteBaseURL will change where the segments listed in a DASH manifest
+// are loaded from.
+func RewriteBaseURL(localFilePath, baseURL string) error {
+	content, err := os.ReadFile(localFilePath) // nolint: gosec
+	if err != nil {
+		return err
+	}
+
+	var manifest mpd
+	if err := xml.Unmarshal(content, &manifest); err != nil {
+		return errors.Wrap(err, "unable to read DASH manifest")
+	}
+
+	manifest.BaseURL = baseURL
+
+	rewritten, err := xml.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return playlist.WritePlaylist(xml.Header+string(rewritten)+"\n", localFilePath)
+}
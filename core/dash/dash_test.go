@@ -0,0 +1,91 @@
+package dash
+
+import (
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/owncast/owncast/config"
+	"github.com/owncast/owncast/models"
+)
+
+func TestManifestFromVariantPlaylists(t *testing.T) {
+	config.HLSStoragePath = t.TempDir()
+	if err := os.MkdirAll(filepath.Join(config.HLSStoragePath, "0"), 0o750); err != nil {
+		t.Fatal(err)
+	}
+
+	writeFile := func(path, content string) {
+		if err := os.WriteFile(filepath.Join(config.HLSStoragePath, path), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	writeFile("stream.m3u8", "#EXTM3U\n#EXT-X-VERSION:7\n#EXT-X-STREAM-INF:BANDWIDTH=1400000,CODECS=\"avc1.64001f,mp4a.40.2\"\n0/stream.m3u8\n")
+
+	Start([]models.StreamOutputVariant{{VideoBitrate: 1200, AudioBitrate: 128, ScaledWidth: 1280, Framerate: 30}}, models.GetLatencyLevel(2))
+	defer Stop()
+
+	playlistHeader := "#EXTM3U\n#EXT-X-VERSION:7\n#EXT-X-TARGETDURATION:3\n#EXT-X-MEDIA-SEQUENCE:0\n#EXT-X-MAP:URI=\"init-abc-0.mp4\"\n"
+
+	// MPEG-TS segments are not able to be used for DASH.
+	writeFile("0/stream.m3u8", "#EXTM3U\n#EXT-X-VERSION:3\n#EXT-X-TARGETDURATION:3\n#EXT-X-MEDIA-SEQUENCE:0\n#EXTINF:3.000000,\nstream-abc-0.ts\n")
+	if _, written := VariantPlaylistWritten(filepath.Join(config.HLSStoragePath, "0", "stream.m3u8")); written {
+		t.Fatal("manifest should not be written for MPEG-TS segments")
+	}
+
+	writeFile("0/stream.m3u8", playlistHeader+"#EXTINF:3.000000,\nstream-abc-0.m4s\n")
+	VariantPlaylistWritten(filepath.Join(config.HLSStoragePath, "0", "stream.m3u8"))
+
+	// The first segment is no longer listed once the playlist moves on.
+	writeFile("0/stream.m3u8", playlistHeader+"#EXTINF:3.000000,\nstream-abc-1.m4s\n#EXTINF:2.500000,\nstream-abc-2.m4s\n")
+	manifestPath, written := VariantPlaylistWritten(filepath.Join(config.HLSStoragePath, "0", "stream.m3u8"))
+	if !written {
+		t.Fatal("manifest was not written")
+	}
+
+	content, err := os.ReadFile(manifestPath) // nolint: gosec
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var manifest mpd
+	if err := xml.Unmarshal(content, &manifest); err != nil {
+		t.Fatal(err)
+	}
+
+	representations := manifest.Period.AdaptationSet.Representations
+	if len(representations) != 1 {
+		t.Fatalf("manifest has %d representations, want 1:\n%s", len(representations), content)
+	}
+
+	r := representations[0]
+	if r.Bandwidth != 1328000 || r.Codecs != "avc1.64001f,mp4a.40.2" || r.Width != 1280 || r.FrameRate != 30 {
+		t.Errorf("representation = %+v, want the variant settings and codecs", r)
+	}
+
+	template := r.SegmentTemplate
+	if template.Initialization != "0/init-abc-0.mp4" || template.Media != "0/stream-abc-$Number$.m4s" || template.StartNumber != 1 {
+		t.Errorf("segment template = %+v", template)
+	}
+
+	want := []timelineSegment{{T: 3000, D: 3000}, {T: 6000, D: 2500}}
+	if len(template.SegmentTimeline.Segments) != len(want) {
+		t.Fatalf("timeline = %+v, want %+v", template.SegmentTimeline.Segments, want)
+	}
+	for i, s := range template.SegmentTimeline.Segments {
+		if s != want[i] {
+			t.Errorf("timeline = %+v, want %+v", template.SegmentTimeline.Segments, want)
+		}
+	}
+
+	if err := RewriteBaseURL(manifestPath, "https://cdn.example.com/hls/"); err != nil {
+		t.Fatal(err)
+	}
+
+	content, _ = os.ReadFile(manifestPath) // nolint: gosec
+	if err := xml.Unmarshal(content, &manifest); err != nil || manifest.BaseURL != "https://cdn.example.com/hls/" {
+		t.Errorf("rewritten base URL = %q, %v", manifest.BaseURL, err)
+	}
+}
@@ -0,0 +1,111 @@
+package data
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/owncast/owncast/models"
+)
+
+func createAbuseReportsTable(db *sql.DB) {
+	MustExec(`CREATE TABLE IF NOT EXISTS abuse_reports (
+		"id" INTEGER PRIMARY KEY AUTOINCREMENT,
+		"timestamp" DATETIME NOT NULL,
+		"reporter_id" TEXT NOT NULL,
+		"message_id" TEXT NOT NULL DEFAULT '',
+		"user_id" TEXT NOT NULL,
+		"reason" TEXT NOT NULL,
+		"status" TEXT NOT NULL,
+		"resolved_by" TEXT NOT NULL DEFAULT '',
+		"resolved_at" DATETIME,
+		"channel" TEXT NOT NULL DEFAULT ''
+	);`, db)
+	MustExec(`CREATE INDEX IF NOT EXISTS idx_abuse_reports_status ON abuse_reports (status);`, db)
+	MustExec(`CREATE INDEX IF NOT EXISTS idx_abuse_reports_message_id ON abuse_reports (message_id);`, db)
+}
+
+// InsertAbuseReport will save a new report. Returns false without saving it
+// if the reporter already has an open report of the same message or user.
+func InsertAbuseReport(report models.AbuseReport) (bool, error) {
+	_datastore.DbLock.Lock()
+	defer _datastore.DbLock.Unlock()
+
+	var existing int
+	row := _db.QueryRow("SELECT COUNT(*) FROM abuse_reports WHERE reporter_id = ? AND message_id = ? AND user_id = ? AND status = ?", report.ReporterID, report.MessageID, report.UserID, models.AbuseReportOpen)
+	if err := row.Scan(&existing); err != nil {
+		return false, err
+	}
+	if existing > 0 {
+		return false, nil
+	}
+
+	stmt, err := _db.Prepare("INSERT INTO abuse_reports(timestamp, reporter_id, message_id, user_id, reason, status, channel) values(?, ?, ?, ?, ?, ?, ?)")
+	if err != nil {
+		return false, err
+	}
+	defer stmt.Close()
+
+	if _, err := stmt.Exec(report.Timestamp, report.ReporterID, report.MessageID, report.UserID, report.Reason, models.AbuseReportOpen, report.ChannelID); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// GetAbuseReports will return the most recent reports, newest first. If
+// status is set only the reports with that status are returned.
+func GetAbuseReports(status string, limit int) ([]models.AbuseReport, error) {
+	reports := make([]models.AbuseReport, 0)
+
+	rows, err := _db.Query("SELECT id, timestamp, reporter_id, message_id, user_id, reason, status, resolved_by, resolved_at, channel FROM abuse_reports WHERE ? = '' OR status = ? ORDER BY timestamp DESC LIMIT ?", status, status, limit)
+	if err != nil {
+		return reports, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var report models.AbuseReport
+		var resolvedAt sql.NullTime
+		if err := rows.Scan(&report.ID, &report.Timestamp, &report.ReporterID, &report.MessageID, &report.UserID, &report.Reason, &report.Status, &report.ResolvedBy, &resolvedAt, &report.ChannelID); err != nil {
+			return reports, err
+		}
+		if resolvedAt.Valid {
+			report.ResolvedAt = &resolvedAt.Time
+		}
+		reports = append(reports, report)
+	}
+
+	return reports, rows.Err()
+}
+
+// GetOpenAbuseReportCount will return the number of open reports of a chat
+// message.
+func GetOpenAbuseReportCount(messageID string) (int, error) {
+	var count int
+	row := _db.QueryRow("SELECT COUNT(*) FROM abuse_reports WHERE message_id = ? AND status = ?", messageID, models.AbuseReportOpen)
+	err := row.Scan(&count)
+	return count, err
+}
+
+// SetAbuseReportStatus will change the status of a report. Returns false if
+// there is no report with the ID.
+func SetAbuseReportStatus(id int, status string, resolvedBy string) (bool, error) {
+	_datastore.DbLock.Lock()
+	defer _datastore.DbLock.Unlock()
+
+	var resolvedAt *time.Time
+	if status != models.AbuseReportOpen {
+		now := time.Now()
+		resolvedAt = &now
+	} else {
+		resolvedBy = ""
+	}
+
+	result, err := _db.Exec("UPDATE abuse_reports SET status = ?, resolved_by = ?, resolved_at = ? WHERE id = ?", status, resolvedBy, resolvedAt, id)
+	if err != nil {
+		return false, err
+	}
+
+	updated, err := result.RowsAffected()
+	return updated > 0, err
+}
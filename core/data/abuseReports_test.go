@@ -0,0 +1,49 @@
+package data
+
+import (
+	"testing"
+	"time"
+
+	"github.com/owncast/owncast/models"
+)
+
+func TestAbuseReports(t *testing.T) {
+	createAbuseReportsTable(_datastore.DB)
+
+	for _, reporterID := range []string{"reporter-1", "reporter-2"} {
+		report := models.AbuseReport{Timestamp: time.Now(), ReporterID: reporterID, MessageID: "reported-message", UserID: "reported-user", Reason: "spam"}
+		if added, err := InsertAbuseReport(report); err != nil || !added {
+			t.Fatalf("expected report to be added, got %t %v", added, err)
+		}
+	}
+
+	duplicate := models.AbuseReport{Timestamp: time.Now(), ReporterID: "reporter-1", MessageID: "reported-message", UserID: "reported-user", Reason: "spam again"}
+	if added, err := InsertAbuseReport(duplicate); err != nil || added {
+		t.Errorf("expected duplicate report not to be added, got %t %v", added, err)
+	}
+
+	if count, err := GetOpenAbuseReportCount("reported-message"); err != nil || count != 2 {
+		t.Errorf("expected 2 open reports, got %d %v", count, err)
+	}
+
+	reports, err := GetAbuseReports(models.AbuseReportOpen, 10)
+	if err != nil || len(reports) != 2 {
+		t.Fatalf("expected 2 open reports, got %d %v", len(reports), err)
+	}
+
+	if updated, err := SetAbuseReportStatus(reports[0].ID, models.AbuseReportDismissed, "moderator:mod-1"); err != nil || !updated {
+		t.Fatalf("expected report to be dismissed, got %t %v", updated, err)
+	}
+	if updated, err := SetAbuseReportStatus(-1, models.AbuseReportResolved, "admin"); err != nil || updated {
+		t.Errorf("expected missing report not to be updated, got %t %v", updated, err)
+	}
+
+	if count, err := GetOpenAbuseReportCount("reported-message"); err != nil || count != 1 {
+		t.Errorf("expected 1 open report, got %d %v", count, err)
+	}
+
+	dismissed, err := GetAbuseReports(models.AbuseReportDismissed, 10)
+	if err != nil || len(dismissed) != 1 || dismissed[0].ResolvedBy != "moderator:mod-1" || dismissed[0].ResolvedAt == nil {
+		t.Errorf("unexpected dismissed reports: %+v %v", dismissed, err)
+	}
+}
@@ -0,0 +1,226 @@
+package data
+
+import (
+	"strings"
+	"time"
+
+	"github.com/owncast/owncast/models"
+	log "github.com/sirupsen/logrus"
+)
+
+func createChatArchiveTables() {
+	log.Traceln("Creating chat archive tables...")
+
+	MustExec(`CREATE TABLE IF NOT EXISTS chat_archive (
+		"id" TEXT NOT NULL,
+		"user_id" TEXT,
+		"body" TEXT NOT NULL,
+		"eventType" TEXT NOT NULL,
+		"timestamp" DATETIME NOT NULL,
+		"channel" TEXT NOT NULL DEFAULT '',
+		"room" TEXT NOT NULL DEFAULT '',
+		"hidden_at" DATETIME,
+		"deleted_at" DATETIME,
+		"edited_at" DATETIME
+	);`, _db)
+	MustExec(`CREATE INDEX IF NOT EXISTS idx_chat_archive_timestamp ON chat_archive (timestamp);`, _db)
+	MustExec(`CREATE INDEX IF NOT EXISTS idx_chat_archive_user_id ON chat_archive (user_id);`, _db)
+
+	// The full-text index of the plain text of each archived event, keyed by
+	// the rowid of the event in chat_archive.
+	MustExec(`CREATE VIRTUAL TABLE IF NOT EXISTS chat_archive_fts USING fts4(text);`, _db)
+}
+
+// InsertChatArchiveEntry will add a chat event to the archive and index its
+// plain text for searching.
+func InsertChatArchiveEntry(entry models.ChatArchiveEntry, text string) error {
+	_datastore.DbLock.Lock()
+	defer _datastore.DbLock.Unlock()
+
+	tx, err := _db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() // nolint
+
+	var userID *string
+	if entry.UserID != "" {
+		userID = &entry.UserID
+	}
+
+	result, err := tx.Exec("INSERT INTO chat_archive(id, user_id, body, eventType, timestamp, channel, room, hidden_at) values(?, ?, ?, ?, ?, ?, ?, ?)", entry.ID, userID, entry.Body, entry.Type, entry.Timestamp, entry.ChannelID, entry.RoomID, entry.HiddenAt)
+	if err != nil {
+		return err
+	}
+
+	rowID, err := result.LastInsertId()
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec("INSERT INTO chat_archive_fts(docid, text) values(?, ?)", rowID, text); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// SearchChatArchive will return the archived chat events matching a query,
+// oldest first.
+func SearchChatArchive(query models.ChatArchiveQuery) ([]models.ChatArchiveEntry, error) {
+	entries := make([]models.ChatArchiveEntry, 0)
+
+	conditions := []string{}
+	args := []interface{}{}
+
+	if query.Text != "" {
+		conditions = append(conditions, "chat_archive.rowid IN (SELECT docid FROM chat_archive_fts WHERE chat_archive_fts MATCH ?)")
+		args = append(args, query.Text)
+	}
+	if query.UserID != "" {
+		conditions = append(conditions, "chat_archive.user_id = ?")
+		args = append(args, query.UserID)
+	}
+	if query.Type != "" {
+		conditions = append(conditions, "chat_archive.eventType = ?")
+		args = append(args, query.Type)
+	}
+	if query.ChannelID != nil {
+		conditions = append(conditions, "chat_archive.channel = ?")
+		args = append(args, *query.ChannelID)
+	}
+	if query.RoomID != nil {
+		conditions = append(conditions, "chat_archive.room = ?")
+		args = append(args, *query.RoomID)
+	}
+	if !query.IncludeRemoved {
+		conditions = append(conditions, "chat_archive.hidden_at IS NULL AND chat_archive.deleted_at IS NULL")
+	}
+	if query.From != nil {
+		conditions = append(conditions, "chat_archive.timestamp >= ?")
+		args = append(args, *query.From)
+	}
+	if query.To != nil {
+		conditions = append(conditions, "chat_archive.timestamp <= ?")
+		args = append(args, *query.To)
+	}
+
+	statement := "SELECT chat_archive.id, chat_archive.user_id, users.display_name, chat_archive.body, chat_archive.eventType, chat_archive.timestamp, chat_archive.channel, chat_archive.room, chat_archive.hidden_at, chat_archive.deleted_at, chat_archive.edited_at FROM chat_archive LEFT JOIN users ON chat_archive.user_id = users.id"
+	if len(conditions) > 0 {
+		statement += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	statement += " ORDER BY chat_archive.timestamp ASC"
+	if query.Limit > 0 {
+		statement += " LIMIT ?"
+		args = append(args, query.Limit)
+	}
+
+	rows, err := _db.Query(statement, args...)
+	if err != nil {
+		return entries, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var entry models.ChatArchiveEntry
+		var userID, displayName *string
+		if err := rows.Scan(&entry.ID, &userID, &displayName, &entry.Body, &entry.Type, &entry.Timestamp, &entry.ChannelID, &entry.RoomID, &entry.HiddenAt, &entry.DeletedAt, &entry.EditedAt); err != nil {
+			return entries, err
+		}
+		if userID != nil {
+			entry.UserID = *userID
+		}
+		if displayName != nil {
+			entry.DisplayName = *displayName
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
+
+// UpdateChatArchiveEntryBody will replace the body of an archived chat
+// message after it was edited, and index its new plain text.
+func UpdateChatArchiveEntryBody(id string, body string, text string, editedAt time.Time) error {
+	_datastore.DbLock.Lock()
+	defer _datastore.DbLock.Unlock()
+
+	tx, err := _db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() // nolint
+
+	if _, err := tx.Exec("UPDATE chat_archive SET body = ?, edited_at = ? WHERE id = ?", body, editedAt, id); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec("UPDATE chat_archive_fts SET text = ? WHERE docid IN (SELECT rowid FROM chat_archive WHERE id = ?)", text, id); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// SetChatArchiveEntryDeleted will mark an archived chat message as deleted
+// by its sender.
+func SetChatArchiveEntryDeleted(id string, deletedAt time.Time) error {
+	_datastore.DbLock.Lock()
+	defer _datastore.DbLock.Unlock()
+
+	_, err := _db.Exec("UPDATE chat_archive SET hidden_at = ?, deleted_at = ? WHERE id = ?", deletedAt, deletedAt, id)
+	return err
+}
+
+// SetChatArchiveEntriesHidden will hide or show archived chat messages. A
+// nil hiddenAt shows them.
+func SetChatArchiveEntriesHidden(ids []string, hiddenAt *time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	_datastore.DbLock.Lock()
+	defer _datastore.DbLock.Unlock()
+
+	args := []interface{}{hiddenAt}
+	for _, id := range ids {
+		args = append(args, id)
+	}
+
+	// nolint:gosec
+	_, err := _db.Exec("UPDATE chat_archive SET hidden_at = ? WHERE id IN (?"+strings.Repeat(", ?", len(ids)-1)+")", args...)
+	return err
+}
+
+// SetChatArchiveEntriesHiddenForUser will hide or show all the archived chat
+// messages of a user. A nil hiddenAt shows them.
+func SetChatArchiveEntriesHiddenForUser(userID string, hiddenAt *time.Time) error {
+	_datastore.DbLock.Lock()
+	defer _datastore.DbLock.Unlock()
+
+	_, err := _db.Exec("UPDATE chat_archive SET hidden_at = ? WHERE user_id = ?", hiddenAt, userID)
+	return err
+}
+
+// RemoveChatArchiveEntriesBefore will delete the archived chat events from
+// before a point in time.
+func RemoveChatArchiveEntriesBefore(before time.Time) error {
+	_datastore.DbLock.Lock()
+	defer _datastore.DbLock.Unlock()
+
+	tx, err := _db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() // nolint
+
+	if _, err := tx.Exec("DELETE FROM chat_archive_fts WHERE docid IN (SELECT rowid FROM chat_archive WHERE timestamp < ?)", before); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec("DELETE FROM chat_archive WHERE timestamp < ?", before); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,131 @@
+package data
+
+import (
+	"testing"
+	"time"
+
+	"github.com/owncast/owncast/models"
+)
+
+func TestSearchChatArchive(t *testing.T) {
+	createChatArchiveTables()
+
+	now := time.Now()
+	for _, entry := range []models.ChatArchiveEntry{
+		{ID: "archived-1", UserID: "archive-user-1", Body: "<p>who won the giveaway?</p>", Type: "CHAT", Timestamp: now.Add(-3 * time.Hour)},
+		{ID: "archived-2", UserID: "archive-user-2", Body: "<p>the giveaway is tomorrow</p>", Type: "CHAT", Timestamp: now.Add(-2 * time.Hour)},
+		{ID: "archived-3", Body: "<p>giveaway winner announced</p>", Type: "SYSTEM", Timestamp: now.Add(-time.Hour)},
+	} {
+		if err := InsertChatArchiveEntry(entry, entry.Body[3:len(entry.Body)-4]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	search := func(query models.ChatArchiveQuery) []models.ChatArchiveEntry {
+		t.Helper()
+		entries, err := SearchChatArchive(query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return entries
+	}
+
+	if entries := search(models.ChatArchiveQuery{Text: "giveaway"}); len(entries) != 3 || entries[0].ID != "archived-1" {
+		t.Errorf("expected all entries oldest first, got %+v", entries)
+	}
+	if entries := search(models.ChatArchiveQuery{Text: "giveaway", Type: "CHAT", UserID: "archive-user-2"}); len(entries) != 1 || entries[0].ID != "archived-2" {
+		t.Errorf("expected only archived-2, got %+v", entries)
+	}
+	from := now.Add(-150 * time.Minute)
+	if entries := search(models.ChatArchiveQuery{From: &from}); len(entries) != 2 {
+		t.Errorf("expected 2 entries after from, got %+v", entries)
+	}
+
+	if err := RemoveChatArchiveEntriesBefore(from); err != nil {
+		t.Fatal(err)
+	}
+	if entries := search(models.ChatArchiveQuery{Text: "won"}); len(entries) != 0 {
+		t.Errorf("expected removed entries not to be found, got %+v", entries)
+	}
+}
+
+func TestChatArchiveRemovedMessages(t *testing.T) {
+	createChatArchiveTables()
+
+	now := time.Now()
+	for _, entry := range []models.ChatArchiveEntry{
+		{ID: "removed-1", UserID: "removed-user-1", Body: "<p>first lottery message</p>", Type: "CHAT", Timestamp: now},
+		{ID: "removed-2", UserID: "removed-user-1", Body: "<p>second lottery message</p>", Type: "CHAT", Timestamp: now},
+		{ID: "removed-3", UserID: "removed-user-2", Body: "<p>third lottery message</p>", Type: "CHAT", Timestamp: now, HiddenAt: &now},
+	} {
+		if err := InsertChatArchiveEntry(entry, entry.Body[3:len(entry.Body)-4]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	search := func(query models.ChatArchiveQuery) []models.ChatArchiveEntry {
+		t.Helper()
+		entries, err := SearchChatArchive(query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return entries
+	}
+
+	if entries := search(models.ChatArchiveQuery{Text: "lottery"}); len(entries) != 2 {
+		t.Errorf("expected hidden messages to be left out, got %+v", entries)
+	}
+	if entries := search(models.ChatArchiveQuery{Text: "lottery", IncludeRemoved: true}); len(entries) != 3 || entries[2].HiddenAt == nil {
+		t.Errorf("expected hidden messages to be included, got %+v", entries)
+	}
+
+	if err := UpdateChatArchiveEntryBody("removed-1", "<p>first raffle message</p>", "first raffle message", now); err != nil {
+		t.Fatal(err)
+	}
+	if entries := search(models.ChatArchiveQuery{Text: "raffle"}); len(entries) != 1 || entries[0].Body != "<p>first raffle message</p>" || entries[0].EditedAt == nil {
+		t.Errorf("expected the edited message, got %+v", entries)
+	}
+
+	if err := SetChatArchiveEntryDeleted("removed-2", now); err != nil {
+		t.Fatal(err)
+	}
+	if entries := search(models.ChatArchiveQuery{UserID: "removed-user-1"}); len(entries) != 1 || entries[0].ID != "removed-1" {
+		t.Errorf("expected deleted messages to be left out, got %+v", entries)
+	}
+
+	if err := SetChatArchiveEntriesHiddenForUser("removed-user-1", &now); err != nil {
+		t.Fatal(err)
+	}
+	if entries := search(models.ChatArchiveQuery{UserID: "removed-user-1"}); len(entries) != 0 {
+		t.Errorf("expected the hidden user's messages to be left out, got %+v", entries)
+	}
+
+	if err := SetChatArchiveEntriesHidden([]string{"removed-1", "removed-2", "removed-3"}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if entries := search(models.ChatArchiveQuery{Text: "message"}); len(entries) != 2 {
+		t.Errorf("expected shown messages other than the deleted one, got %+v", entries)
+	}
+}
+
+func TestSearchChatArchiveRoom(t *testing.T) {
+	createChatArchiveTables()
+
+	now := time.Now()
+	for _, entry := range []models.ChatArchiveEntry{
+		{ID: "room-1", Body: "<p>public tombola</p>", Type: "CHAT", Timestamp: now},
+		{ID: "room-2", Body: "<p>backstage tombola</p>", Type: "CHAT", Timestamp: now, RoomID: "backstage"},
+	} {
+		if err := InsertChatArchiveEntry(entry, entry.Body[3:len(entry.Body)-4]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	mainRoom, backstage := "", "backstage"
+	if entries, err := SearchChatArchive(models.ChatArchiveQuery{Text: "tombola", RoomID: &mainRoom}); err != nil || len(entries) != 1 || entries[0].ID != "room-1" {
+		t.Errorf("expected only the main room message, got %+v %v", entries, err)
+	}
+	if entries, err := SearchChatArchive(models.ChatArchiveQuery{Text: "tombola", RoomID: &backstage}); err != nil || len(entries) != 1 || entries[0].RoomID != "backstage" {
+		t.Errorf("expected only the backstage message, got %+v %v", entries, err)
+	}
+}
@@ -29,6 +29,7 @@ const (
 	httpListenAddressKey            = "http_listen_address"
 	websocketHostOverrideKey        = "websocket_host_override"
 	rtmpPortNumberKey               = "rtmp_port_number"
+	srtPortNumberKey                = "srt_port_number"
 	serverMetadataTagsKey           = "server_metadata_tags"
 	directoryEnabledKey             = "directory_enabled"
 	directoryRegistrationKeyKey     = "directory_registration_key"
@@ -46,6 +47,7 @@ const (
 	customStylesKey                 = "custom_styles"
 	customJavascriptKey             = "custom_javascript"
 	videoCodecKey                   = "video_codec"
+	segmentFormatKey                = "segment_format"
 	blockedUsernamesKey             = "blocked_usernames"
 	publicKeyKey                    = "public_key"
 	privateKeyKey                   = "private_key"
@@ -72,6 +74,17 @@ const (
 	streamKeysKey                        = "stream_keys"
 	disableSearchIndexingKey             = "disable_search_indexing"
 	videoServingEndpointKey              = "video_serving_endpoint"
+	recordingConfigKey                   = "recording_config"
+	restreamDestinationsKey              = "restream_destinations"
+	channelsKey                          = "channels"
+	chatModesKey                         = "chat_modes"
+	automodRulesKey                      = "automod_rules"
+	chatMessageEditWindowKey             = "chat_message_edit_window"
+	directMessageRetentionKey            = "direct_message_retention_days"
+	abuseReportConfigKey                 = "abuse_report_config"
+	chatArchiveConfigKey                 = "chat_archive_config"
+	chatRoomsKey                         = "chat_rooms"
+	chatRateLimitPolicyKey               = "chat_rate_limit_policy"
 )
 
 // GetExtraPageBodyContent will return the user-supplied body content.
@@ -281,6 +294,22 @@ func SetRTMPPortNumber(port float64) error {
 	return _datastore.SetNumber(rtmpPortNumberKey, port)
 }
 
+// GetSRTPortNumber will return the server SRT port. Zero means SRT is disabled.
+func GetSRTPortNumber() int {
+	port, err := _datastore.GetNumber(srtPortNumberKey)
+	if err != nil {
+		log.Traceln(srtPortNumberKey, err)
+		return config.GetDefaults().SRTServerPort
+	}
+
+	return int(port)
+}
+
+// SetSRTPortNumber will set the server SRT port.
+func SetSRTPortNumber(port float64) error {
+	return _datastore.SetNumber(srtPortNumberKey, port)
+}
+
 // GetServerMetadataTags will return the metadata tags.
 func GetServerMetadataTags() []string {
 	tagsString, err := _datastore.GetString(serverMetadataTagsKey)
@@ -463,6 +492,14 @@ func GetStreamLatencyLevel() models.LatencyLevel {
 		level = 2 // default
 	} else if level > 4 {
 		level = 4 // highest
+	} else if level < models.LowLatencyHLSLevel {
+		level = models.LowLatencyHLSLevel // lowest
+	}
+
+	// Low latency playlists are served locally, so fall back to the nearest
+	// normal level when using external storage.
+	if level == models.LowLatencyHLSLevel && GetS3Config().Enabled {
+		level = 0
 	}
 
 	return models.GetLatencyLevel(int(level))
@@ -528,6 +565,132 @@ func GetChatEstbalishedUsersOnlyMode() bool {
 	return false
 }
 
+// SetChatModes sets the restrictions on who can send chat messages and what
+// they can send.
+func SetChatModes(modes models.ChatModes) error {
+	configEntry := ConfigEntry{Key: chatModesKey, Value: modes}
+	return _datastore.Save(configEntry)
+}
+
+// GetChatModes returns the restrictions on who can send chat messages and
+// what they can send.
+func GetChatModes() models.ChatModes {
+	configEntry, err := _datastore.Get(chatModesKey)
+	if err != nil {
+		return models.ChatModes{}
+	}
+
+	var modes models.ChatModes
+	if err := configEntry.getObject(&modes); err != nil {
+		return models.ChatModes{}
+	}
+
+	return modes
+}
+
+// GetChatMessageEditWindow will return the number of seconds users can edit
+// and delete their own chat messages for. Zero means they cannot.
+func GetChatMessageEditWindow() int {
+	seconds, err := _datastore.GetNumber(chatMessageEditWindowKey)
+	if err != nil {
+		return config.GetDefaults().ChatMessageEditWindowSeconds
+	}
+
+	return int(seconds)
+}
+
+// SetChatMessageEditWindow will set the number of seconds users can edit and
+// delete their own chat messages for.
+func SetChatMessageEditWindow(seconds float64) error {
+	return _datastore.SetNumber(chatMessageEditWindowKey, seconds)
+}
+
+// GetDirectMessageRetentionDays will return the number of days private
+// messages between users are kept for. Zero means they are kept forever.
+func GetDirectMessageRetentionDays() int {
+	days, err := _datastore.GetNumber(directMessageRetentionKey)
+	if err != nil {
+		return config.GetDefaults().DirectMessageRetentionDays
+	}
+
+	return int(days)
+}
+
+// SetDirectMessageRetentionDays will set the number of days private messages
+// between users are kept for.
+func SetDirectMessageRetentionDays(days float64) error {
+	return _datastore.SetNumber(directMessageRetentionKey, days)
+}
+
+// SetAbuseReportConfig will set when and where moderators are alerted about
+// reported chat messages.
+func SetAbuseReportConfig(reportConfig models.AbuseReportConfig) error {
+	configEntry := ConfigEntry{Key: abuseReportConfigKey, Value: reportConfig}
+	return _datastore.Save(configEntry)
+}
+
+// GetAbuseReportConfig will return when and where moderators are alerted
+// about reported chat messages.
+func GetAbuseReportConfig() models.AbuseReportConfig {
+	defaultConfig := models.AbuseReportConfig{Threshold: config.GetDefaults().AbuseReportThreshold}
+
+	configEntry, err := _datastore.Get(abuseReportConfigKey)
+	if err != nil {
+		return defaultConfig
+	}
+
+	var reportConfig models.AbuseReportConfig
+	if err := configEntry.getObject(&reportConfig); err != nil {
+		return defaultConfig
+	}
+
+	return reportConfig
+}
+
+// SetChatArchiveConfig will set the configuration of the long-term chat
+// archive.
+func SetChatArchiveConfig(archiveConfig models.ChatArchiveConfig) error {
+	configEntry := ConfigEntry{Key: chatArchiveConfigKey, Value: archiveConfig}
+	return _datastore.Save(configEntry)
+}
+
+// GetChatArchiveConfig will return the configuration of the long-term chat
+// archive. The archive is disabled by default.
+func GetChatArchiveConfig() models.ChatArchiveConfig {
+	configEntry, err := _datastore.Get(chatArchiveConfigKey)
+	if err != nil {
+		return models.ChatArchiveConfig{}
+	}
+
+	var archiveConfig models.ChatArchiveConfig
+	if err := configEntry.getObject(&archiveConfig); err != nil {
+		return models.ChatArchiveConfig{}
+	}
+
+	return archiveConfig
+}
+
+// SetAutomodRules will set the automatic chat moderation rules.
+func SetAutomodRules(rules []models.AutomodRule) error {
+	configEntry := ConfigEntry{Key: automodRulesKey, Value: rules}
+	return _datastore.Save(configEntry)
+}
+
+// GetAutomodRules will return the automatic chat moderation rules.
+func GetAutomodRules() []models.AutomodRule {
+	configEntry, err := _datastore.Get(automodRulesKey)
+	if err != nil {
+		return []models.AutomodRule{}
+	}
+
+	var rules []models.AutomodRule
+	if err := configEntry.getObject(&rules); err != nil {
+		return []models.AutomodRule{}
+	}
+
+	return rules
+}
+
 // GetExternalActions will return the registered external actions.
 func GetExternalActions() []models.ExternalAction {
 	configEntry, err := _datastore.Get(externalActionsKey)
@@ -594,6 +757,25 @@ func GetVideoCodec() string {
 	return codec
 }
 
+// SetSegmentFormat will set the container format of video segments.
+func SetSegmentFormat(format models.SegmentFormat) error {
+	if !models.IsValidSegmentFormat(format) {
+		return errors.New("invalid segment format: " + format)
+	}
+
+	return _datastore.SetString(segmentFormatKey, format)
+}
+
+// GetSegmentFormat returns the container format of video segments.
+func GetSegmentFormat() models.SegmentFormat {
+	format, err := _datastore.GetString(segmentFormatKey)
+	if err != nil || !models.IsValidSegmentFormat(format) {
+		return models.SegmentFormatMPEGTS // Default value
+	}
+
+	return format
+}
+
 // VerifySettings will perform a sanity check for specific settings values.
 func VerifySettings() error {
 	if len(GetStreamKeys()) == 0 && config.TemporaryStreamKey == "" {
@@ -988,3 +1170,143 @@ func GetVideoServingEndpoint() string {
 func SetVideoServingEndpoint(message string) error {
 	return _datastore.SetString(videoServingEndpointKey, message)
 }
+
+// GetRecordingConfig will return the configuration for recording streams.
+func GetRecordingConfig() models.RecordingConfig {
+	configEntry, err := _datastore.Get(recordingConfigKey)
+	if err != nil {
+		return models.RecordingConfig{Enabled: false}
+	}
+
+	var recordingConfig models.RecordingConfig
+	if err := configEntry.getObject(&recordingConfig); err != nil {
+		return models.RecordingConfig{Enabled: false}
+	}
+
+	return recordingConfig
+}
+
+// SetRecordingConfig will set the configuration for recording streams.
+func SetRecordingConfig(config models.RecordingConfig) error {
+	configEntry := ConfigEntry{Key: recordingConfigKey, Value: config}
+	return _datastore.Save(configEntry)
+}
+
+// GetRestreamDestinations will return the external RTMP destinations the stream is copied to.
+func GetRestreamDestinations() []models.RestreamDestination {
+	configEntry, err := _datastore.Get(restreamDestinationsKey)
+	if err != nil {
+		return []models.RestreamDestination{}
+	}
+
+	var destinations []models.RestreamDestination
+	if err := configEntry.getObject(&destinations); err != nil {
+		return []models.RestreamDestination{}
+	}
+
+	return destinations
+}
+
+// SetRestreamDestinations will set the external RTMP destinations the stream is copied to.
+func SetRestreamDestinations(destinations []models.RestreamDestination) error {
+	configEntry := ConfigEntry{Key: restreamDestinationsKey, Value: destinations}
+	return _datastore.Save(configEntry)
+}
+
+// GetChannels will return the channels hosted alongside the default stream.
+func GetChannels() []models.Channel {
+	configEntry, err := _datastore.Get(channelsKey)
+	if err != nil {
+		return []models.Channel{}
+	}
+
+	var channels []models.Channel
+	if err := configEntry.getObject(&channels); err != nil {
+		return []models.Channel{}
+	}
+
+	return channels
+}
+
+// SetChannels will set the channels hosted alongside the default stream.
+func SetChannels(channels []models.Channel) error {
+	configEntry := ConfigEntry{Key: channelsKey, Value: channels}
+	return _datastore.Save(configEntry)
+}
+
+// GetChannel will return a single channel, and if it exists.
+func GetChannel(id string) (models.Channel, bool) {
+	for _, channel := range GetChannels() {
+		if channel.ID == id {
+			return channel, true
+		}
+	}
+
+	return models.Channel{}, false
+}
+
+// GetChannelStreamTitle will return the stream title of a channel.
+func GetChannelStreamTitle(channelID string) string {
+	if channelID == models.DefaultChannelID {
+		return GetStreamTitle()
+	}
+
+	channel, _ := GetChannel(channelID)
+	return channel.StreamTitle
+}
+
+// GetChatRooms will return the chat rooms available alongside the main chat.
+func GetChatRooms() []models.ChatRoom {
+	configEntry, err := _datastore.Get(chatRoomsKey)
+	if err != nil {
+		return []models.ChatRoom{}
+	}
+
+	var rooms []models.ChatRoom
+	if err := configEntry.getObject(&rooms); err != nil {
+		return []models.ChatRoom{}
+	}
+
+	return rooms
+}
+
+// SetChatRooms will set the chat rooms available alongside the main chat.
+func SetChatRooms(rooms []models.ChatRoom) error {
+	configEntry := ConfigEntry{Key: chatRoomsKey, Value: rooms}
+	return _datastore.Save(configEntry)
+}
+
+// GetChatRoom will return a single chat room, and if it exists.
+func GetChatRoom(id string) (models.ChatRoom, bool) {
+	for _, room := range GetChatRooms() {
+		if room.ID == id {
+			return room, true
+		}
+	}
+
+	return models.ChatRoom{}, false
+}
+
+// GetChatRateLimitPolicy will return the chat rate limits of each role of
+// user.
+func GetChatRateLimitPolicy() models.ChatRateLimitPolicy {
+	defaultPolicy := config.GetDefaults().ChatRateLimitPolicy
+
+	configEntry, err := _datastore.Get(chatRateLimitPolicyKey)
+	if err != nil {
+		return defaultPolicy
+	}
+
+	var policy models.ChatRateLimitPolicy
+	if err := configEntry.getObject(&policy); err != nil {
+		return defaultPolicy
+	}
+
+	return policy
+}
+
+// SetChatRateLimitPolicy will set the chat rate limits of each role of user.
+func SetChatRateLimitPolicy(policy models.ChatRateLimitPolicy) error {
+	configEntry := ConfigEntry{Key: chatRateLimitPolicyKey, Value: policy}
+	return _datastore.Save(configEntry)
+}
@@ -17,7 +17,7 @@ import (
 )
 
 const (
-	schemaVersion = 7
+	schemaVersion = 19
 )
 
 var (
@@ -74,6 +74,12 @@ func SetupPersistence(file string) error {
 	_, _ = db.Exec("pragma wal_checkpoint(full)")
 
 	createWebhooksTable()
+	createModerationLogTable()
+	createHeldMessagesTable()
+	createDirectMessagesTable(db)
+	createAbuseReportsTable(db)
+	createChatArchiveTables()
+	createPinnedMessagesTable()
 	createUsersTable(db)
 	createAccessTokenTable(db)
 
@@ -0,0 +1,69 @@
+package data
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/owncast/owncast/models"
+)
+
+func createDirectMessagesTable(db *sql.DB) {
+	MustExec(`CREATE TABLE IF NOT EXISTS direct_messages (
+		"id" TEXT PRIMARY KEY,
+		"sender_id" TEXT NOT NULL,
+		"recipient_id" TEXT NOT NULL,
+		"body" TEXT NOT NULL,
+		"timestamp" DATETIME NOT NULL
+	);`, db)
+	MustExec(`CREATE INDEX IF NOT EXISTS idx_direct_messages_sender_id ON direct_messages (sender_id);`, db)
+	MustExec(`CREATE INDEX IF NOT EXISTS idx_direct_messages_recipient_id ON direct_messages (recipient_id);`, db)
+}
+
+// InsertDirectMessage will save a private message between two users.
+func InsertDirectMessage(message models.DirectMessage) error {
+	_datastore.DbLock.Lock()
+	defer _datastore.DbLock.Unlock()
+
+	stmt, err := _db.Prepare("INSERT INTO direct_messages(id, sender_id, recipient_id, body, timestamp) values(?, ?, ?, ?, ?)")
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	_, err = stmt.Exec(message.ID, message.SenderID, message.RecipientID, message.Body, message.Timestamp)
+	return err
+}
+
+// GetDirectMessages will return the most recent private messages sent or
+// received by a user, newest first. If otherUserID is set only the
+// messages between the two users are returned.
+func GetDirectMessages(userID string, otherUserID string, limit int) ([]models.DirectMessage, error) {
+	messages := make([]models.DirectMessage, 0)
+
+	query := "SELECT id, sender_id, recipient_id, body, timestamp FROM direct_messages WHERE (sender_id = ? OR recipient_id = ?) AND (? = '' OR sender_id = ? OR recipient_id = ?) ORDER BY timestamp DESC LIMIT ?"
+	rows, err := _db.Query(query, userID, userID, otherUserID, otherUserID, otherUserID, limit)
+	if err != nil {
+		return messages, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var message models.DirectMessage
+		if err := rows.Scan(&message.ID, &message.SenderID, &message.RecipientID, &message.Body, &message.Timestamp); err != nil {
+			return messages, err
+		}
+		messages = append(messages, message)
+	}
+
+	return messages, rows.Err()
+}
+
+// RemoveDirectMessagesBefore will delete the private messages sent before a
+// point in time.
+func RemoveDirectMessagesBefore(before time.Time) error {
+	_datastore.DbLock.Lock()
+	defer _datastore.DbLock.Unlock()
+
+	_, err := _db.Exec("DELETE FROM direct_messages WHERE timestamp < ?", before)
+	return err
+}
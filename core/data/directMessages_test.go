@@ -0,0 +1,51 @@
+package data
+
+import (
+	"testing"
+	"time"
+
+	"github.com/owncast/owncast/models"
+)
+
+func TestDirectMessages(t *testing.T) {
+	createDirectMessagesTable(_datastore.DB)
+
+	now := time.Now()
+	for i, message := range []models.DirectMessage{
+		{ID: "dm-1", SenderID: "dm-moderator", RecipientID: "dm-user", Body: "please stop", Timestamp: now.Add(-3 * time.Hour)},
+		{ID: "dm-2", SenderID: "dm-user", RecipientID: "dm-moderator", Body: "sorry", Timestamp: now.Add(-2 * time.Hour)},
+		{ID: "dm-3", SenderID: "dm-user", RecipientID: "dm-friend", Body: "hello", Timestamp: now.Add(-time.Hour)},
+	} {
+		if err := InsertDirectMessage(message); err != nil {
+			t.Fatalf("message %d: %v", i, err)
+		}
+	}
+
+	all, err := GetDirectMessages("dm-user", "", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 3 || all[0].ID != "dm-3" {
+		t.Errorf("expected all three messages newest first, got %+v", all)
+	}
+
+	conversation, err := GetDirectMessages("dm-user", "dm-moderator", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(conversation) != 2 || conversation[0].ID != "dm-2" || conversation[1].ID != "dm-1" {
+		t.Errorf("unexpected conversation: %+v", conversation)
+	}
+
+	if err := RemoveDirectMessagesBefore(now.Add(-90 * time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+
+	remaining, err := GetDirectMessages("dm-user", "", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(remaining) != 1 || remaining[0].ID != "dm-3" {
+		t.Errorf("expected only the newest message to remain, got %+v", remaining)
+	}
+}
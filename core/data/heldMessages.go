@@ -0,0 +1,106 @@
+package data
+
+import (
+	"database/sql"
+
+	"github.com/owncast/owncast/models"
+	log "github.com/sirupsen/logrus"
+)
+
+func createHeldMessagesTable() {
+	log.Traceln("Creating held messages table...")
+
+	createTableSQL := `CREATE TABLE IF NOT EXISTS held_messages (
+		"id" TEXT PRIMARY KEY,
+		"user_id" TEXT NOT NULL,
+		"body" TEXT NOT NULL,
+		"reason" TEXT NOT NULL,
+		"channel" TEXT NOT NULL DEFAULT '',
+		"room" TEXT NOT NULL DEFAULT '',
+		"timestamp" DATETIME NOT NULL,
+		"reply_to" TEXT NOT NULL DEFAULT ''
+	);`
+
+	stmt, err := _db.Prepare(createTableSQL)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer stmt.Close()
+	if _, err = stmt.Exec(); err != nil {
+		log.Warnln(err)
+	}
+}
+
+// InsertHeldMessage will add a chat message to the moderator review queue.
+func InsertHeldMessage(message models.HeldMessage) error {
+	_datastore.DbLock.Lock()
+	defer _datastore.DbLock.Unlock()
+
+	stmt, err := _db.Prepare("INSERT INTO held_messages(id, user_id, body, reason, channel, room, timestamp, reply_to) values(?, ?, ?, ?, ?, ?, ?, ?)")
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	_, err = stmt.Exec(message.ID, message.UserID, message.Body, message.Reason, message.ChannelID, message.RoomID, message.Timestamp, message.ReplyTo)
+	return err
+}
+
+// GetHeldMessages will return the messages waiting for moderator review,
+// oldest first.
+func GetHeldMessages() ([]models.HeldMessage, error) {
+	messages := make([]models.HeldMessage, 0)
+
+	rows, err := _db.Query("SELECT id, user_id, body, reason, channel, room, timestamp, reply_to FROM held_messages ORDER BY timestamp ASC")
+	if err != nil {
+		return messages, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var message models.HeldMessage
+		if err := rows.Scan(&message.ID, &message.UserID, &message.Body, &message.Reason, &message.ChannelID, &message.RoomID, &message.Timestamp, &message.ReplyTo); err != nil {
+			return messages, err
+		}
+		messages = append(messages, message)
+	}
+
+	return messages, rows.Err()
+}
+
+// GetHeldMessage will return the held message with the ID. Returns nil if
+// there is no held message with the ID.
+func GetHeldMessage(id string) (*models.HeldMessage, error) {
+	var message models.HeldMessage
+	row := _db.QueryRow("SELECT id, user_id, body, reason, channel, room, timestamp, reply_to FROM held_messages WHERE id = ?", id)
+	if err := row.Scan(&message.ID, &message.UserID, &message.Body, &message.Reason, &message.ChannelID, &message.RoomID, &message.Timestamp, &message.ReplyTo); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &message, nil
+}
+
+// RemoveHeldMessage will take a message out of the moderator review queue
+// and return it. Returns nil if there is no held message with the ID.
+func RemoveHeldMessage(id string) (*models.HeldMessage, error) {
+	_datastore.DbLock.Lock()
+	defer _datastore.DbLock.Unlock()
+
+	var message models.HeldMessage
+	row := _db.QueryRow("SELECT id, user_id, body, reason, channel, room, timestamp, reply_to FROM held_messages WHERE id = ?", id)
+	if err := row.Scan(&message.ID, &message.UserID, &message.Body, &message.Reason, &message.ChannelID, &message.RoomID, &message.Timestamp, &message.ReplyTo); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if _, err := _db.Exec("DELETE FROM held_messages WHERE id = ?", id); err != nil {
+		return nil, err
+	}
+
+	return &message, nil
+}
@@ -0,0 +1,53 @@
+package data
+
+import (
+	"testing"
+	"time"
+
+	"github.com/owncast/owncast/models"
+)
+
+func TestHeldMessages(t *testing.T) {
+	createHeldMessagesTable()
+
+	first := models.HeldMessage{ID: "held-1", UserID: "user-1", Body: "first", Reason: "Message contains a link", Timestamp: time.Now().Add(-time.Minute), ReplyTo: "replied-1"}
+	second := models.HeldMessage{ID: "held-2", UserID: "user-2", Body: "second", Reason: "Message contains a link", Timestamp: time.Now()}
+
+	for _, message := range []models.HeldMessage{second, first} {
+		if err := InsertHeldMessage(message); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	held, err := GetHeldMessages()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(held) != 2 || held[0].ID != first.ID || held[1].ID != second.ID {
+		t.Fatalf("expected both held messages oldest first, got %+v", held)
+	}
+
+	if message, err := GetHeldMessage(first.ID); err != nil || message == nil || message.ReplyTo != first.ReplyTo {
+		t.Fatalf("expected %s to keep its reply, got %+v %v", first.ID, message, err)
+	}
+
+	removed, err := RemoveHeldMessage(first.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if removed == nil || removed.Body != first.Body || removed.UserID != first.UserID || removed.ReplyTo != first.ReplyTo {
+		t.Fatalf("expected to remove %s, got %+v", first.ID, removed)
+	}
+
+	if removed, err := RemoveHeldMessage(first.ID); err != nil || removed != nil {
+		t.Fatalf("expected removed message to be gone, got %+v %v", removed, err)
+	}
+
+	held, err = GetHeldMessages()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(held) != 1 || held[0].ID != second.ID {
+		t.Fatalf("expected only %s to be held, got %+v", second.ID, held)
+	}
+}
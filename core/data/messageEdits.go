@@ -0,0 +1,51 @@
+package data
+
+import (
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/owncast/owncast/models"
+)
+
+func createMessageEditsTable(db *sql.DB) {
+	MustExec(`CREATE TABLE IF NOT EXISTS message_edits (
+		"message_id" TEXT NOT NULL,
+		"body" TEXT NOT NULL,
+		"edited_at" DATETIME NOT NULL
+	);`, db)
+	MustExec(`CREATE INDEX IF NOT EXISTS idx_message_edits_message_id ON message_edits (message_id);`, db)
+}
+
+// GetMessageEdits will return the previous versions of each of the provided
+// chat messages, keyed by message ID. Edits are in the order they were made.
+func GetMessageEdits(messageIDs []string) (map[string][]models.ChatMessageEdit, error) {
+	edits := map[string][]models.ChatMessageEdit{}
+	if len(messageIDs) == 0 {
+		return edits, nil
+	}
+
+	args := make([]interface{}, len(messageIDs))
+	for i, id := range messageIDs {
+		args[i] = id
+	}
+
+	query := "SELECT message_id, body, edited_at FROM message_edits WHERE message_id IN (?" + strings.Repeat(", ?", len(messageIDs)-1) + ") ORDER BY edited_at ASC" //nolint:gosec
+	rows, err := _db.Query(query, args...)
+	if err != nil {
+		return edits, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var messageID, body string
+		var editedAt time.Time
+		if err := rows.Scan(&messageID, &body, &editedAt); err != nil {
+			return edits, err
+		}
+
+		edits[messageID] = append(edits[messageID], models.ChatMessageEdit{Body: body, EditedAt: editedAt})
+	}
+
+	return edits, rows.Err()
+}
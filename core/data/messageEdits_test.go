@@ -0,0 +1,38 @@
+package data
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGetMessageEdits(t *testing.T) {
+	createMessageEditsTable(_datastore.DB)
+
+	first := time.Now().Add(-time.Minute)
+	second := time.Now()
+	for _, edit := range []struct {
+		messageID string
+		body      string
+		editedAt  time.Time
+	}{
+		{"edited-1", "second version", second},
+		{"edited-1", "first version", first},
+		{"edited-2", "only version", second},
+	} {
+		if _, err := _db.Exec("INSERT INTO message_edits(message_id, body, edited_at) values(?, ?, ?)", edit.messageID, edit.body, edit.editedAt); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	edits, err := GetMessageEdits([]string{"edited-1", "edited-3"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if history := edits["edited-1"]; len(history) != 2 || history[0].Body != "first version" || history[1].Body != "second version" {
+		t.Errorf("unexpected edits of edited-1: %+v", history)
+	}
+	if _, exists := edits["edited-2"]; exists {
+		t.Error("expected edits of edited-2 not to be returned")
+	}
+}
@@ -0,0 +1,96 @@
+package data
+
+import (
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/owncast/owncast/models"
+)
+
+func createMessageReactionsTable(db *sql.DB) {
+	MustExec(`CREATE TABLE IF NOT EXISTS message_reactions (
+		"message_id" TEXT NOT NULL,
+		"user_id" TEXT NOT NULL,
+		"emoji" TEXT NOT NULL,
+		"timestamp" DATETIME NOT NULL,
+		PRIMARY KEY (message_id, user_id, emoji)
+	);`, db)
+	MustExec(`CREATE INDEX IF NOT EXISTS idx_message_reactions_message_id ON message_reactions (message_id);`, db)
+}
+
+// AddMessageReaction will save a user's reaction to a chat message. Returns
+// false if the user had already reacted with the same emoji.
+func AddMessageReaction(messageID, userID, emoji string) (bool, error) {
+	_datastore.DbLock.Lock()
+	defer _datastore.DbLock.Unlock()
+
+	result, err := _db.Exec("INSERT OR IGNORE INTO message_reactions(message_id, user_id, emoji, timestamp) values(?, ?, ?, ?)", messageID, userID, emoji, time.Now())
+	if err != nil {
+		return false, err
+	}
+
+	added, err := result.RowsAffected()
+	return added > 0, err
+}
+
+// RemoveMessageReaction will remove a user's reaction to a chat message.
+// Returns false if the user had not reacted with the emoji.
+func RemoveMessageReaction(messageID, userID, emoji string) (bool, error) {
+	_datastore.DbLock.Lock()
+	defer _datastore.DbLock.Unlock()
+
+	result, err := _db.Exec("DELETE FROM message_reactions WHERE message_id = ? AND user_id = ? AND emoji = ?", messageID, userID, emoji)
+	if err != nil {
+		return false, err
+	}
+
+	removed, err := result.RowsAffected()
+	return removed > 0, err
+}
+
+// GetMessageReactions will return the reactions to each of the provided
+// chat messages, keyed by message ID. Reactions are in the order they were
+// first made.
+func GetMessageReactions(messageIDs []string) (map[string][]models.ChatReaction, error) {
+	reactions := map[string][]models.ChatReaction{}
+	if len(messageIDs) == 0 {
+		return reactions, nil
+	}
+
+	args := make([]interface{}, len(messageIDs))
+	for i, id := range messageIDs {
+		args[i] = id
+	}
+
+	query := "SELECT message_id, emoji, user_id FROM message_reactions WHERE message_id IN (?" + strings.Repeat(", ?", len(messageIDs)-1) + ") ORDER BY timestamp ASC" //nolint:gosec
+	rows, err := _db.Query(query, args...)
+	if err != nil {
+		return reactions, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var messageID, emoji, userID string
+		if err := rows.Scan(&messageID, &emoji, &userID); err != nil {
+			return reactions, err
+		}
+
+		messageReactions := reactions[messageID]
+		found := false
+		for i := range messageReactions {
+			if messageReactions[i].Emoji == emoji {
+				messageReactions[i].UserIDs = append(messageReactions[i].UserIDs, userID)
+				messageReactions[i].Count++
+				found = true
+				break
+			}
+		}
+		if !found {
+			messageReactions = append(messageReactions, models.ChatReaction{Emoji: emoji, UserIDs: []string{userID}, Count: 1})
+		}
+		reactions[messageID] = messageReactions
+	}
+
+	return reactions, rows.Err()
+}
@@ -0,0 +1,45 @@
+package data
+
+import "testing"
+
+func TestMessageReactions(t *testing.T) {
+	createMessageReactionsTable(_datastore.DB)
+
+	for _, reaction := range []struct{ messageID, userID, emoji string }{
+		{"reacted-1", "user-1", "👍"},
+		{"reacted-1", "user-2", "👍"},
+		{"reacted-1", "user-1", "bananadance"},
+		{"reacted-2", "user-3", "👍"},
+	} {
+		if added, err := AddMessageReaction(reaction.messageID, reaction.userID, reaction.emoji); err != nil || !added {
+			t.Fatalf("expected reaction to be added, got %t %v", added, err)
+		}
+	}
+
+	if added, err := AddMessageReaction("reacted-1", "user-1", "👍"); err != nil || added {
+		t.Errorf("expected duplicate reaction not to be added, got %t %v", added, err)
+	}
+
+	if removed, err := RemoveMessageReaction("reacted-1", "user-1", "bananadance"); err != nil || !removed {
+		t.Errorf("expected reaction to be removed, got %t %v", removed, err)
+	}
+	if removed, err := RemoveMessageReaction("reacted-1", "user-1", "bananadance"); err != nil || removed {
+		t.Errorf("expected missing reaction not to be removed, got %t %v", removed, err)
+	}
+
+	reactions, err := GetMessageReactions([]string{"reacted-1", "reacted-2", "reacted-3"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first := reactions["reacted-1"]
+	if len(first) != 1 || first[0].Emoji != "👍" || first[0].Count != 2 || len(first[0].UserIDs) != 2 {
+		t.Errorf("unexpected reactions to reacted-1: %+v", first)
+	}
+	if second := reactions["reacted-2"]; len(second) != 1 || second[0].Count != 1 {
+		t.Errorf("unexpected reactions to reacted-2: %+v", second)
+	}
+	if _, exists := reactions["reacted-3"]; exists {
+		t.Error("expected no reactions to reacted-3")
+	}
+}
@@ -3,6 +3,7 @@ package data
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/owncast/owncast/db"
 	"github.com/owncast/owncast/models"
@@ -22,6 +23,12 @@ func CreateMessagesTable(db *sql.DB) {
 		"subtitle" TEXT,
 		"image" TEXT,
 		"link" TEXT,
+		"channel" TEXT NOT NULL DEFAULT '',
+		"reply_to" TEXT,
+		"edited_at" DATETIME,
+		"deleted_at" DATETIME,
+		"stream_offset" INTEGER,
+		"room" TEXT NOT NULL DEFAULT '',
 		PRIMARY KEY (id)
 	);`
 	MustExec(createTableSQL, db)
@@ -33,6 +40,10 @@ func CreateMessagesTable(db *sql.DB) {
 	MustExec(`CREATE INDEX IF NOT EXISTS idx_hidden_at ON messages (hidden_at);`, db)
 	MustExec(`CREATE INDEX IF NOT EXISTS idx_timestamp ON messages (timestamp);`, db)
 	MustExec(`CREATE INDEX IF NOT EXISTS idx_messages_hidden_at_timestamp on messages(hidden_at, timestamp);`, db)
+	MustExec(`CREATE INDEX IF NOT EXISTS idx_messages_channel_stream_offset on messages(channel, stream_offset);`, db)
+
+	createMessageReactionsTable(db)
+	createMessageEditsTable(db)
 }
 
 // GetMessagesCount will return the number of messages in the database.
@@ -57,7 +68,9 @@ func CreateBanIPTable(db *sql.DB) {
 	createTableSQL := `  CREATE TABLE IF NOT EXISTS ip_bans (
     "ip_address" TEXT NOT NULL PRIMARY KEY,
     "notes" TEXT,
-    "created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+    "created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    "expires_at" TIMESTAMP,
+    "user_id" TEXT
   );`
 
 	stmt, err := db.Prepare(createTableSQL)
@@ -70,18 +83,35 @@ func CreateBanIPTable(db *sql.DB) {
 	}
 }
 
-// BanIPAddress will persist a new IP address ban to the datastore.
-func BanIPAddress(address, note string) error {
-	return _datastore.GetQueries().BanIPAddress(context.Background(), db.BanIPAddressParams{
+// BanIPAddress will persist an IP address ban to the datastore. A nil
+// expiry bans the address permanently. An address that is already banned
+// keeps the later of the two expiries, or is banned permanently if either
+// ban is. The user ID is the banned user the
+// address belonged to, and is empty for addresses banned by themselves.
+func BanIPAddress(address, note, userID string, expiresAt *time.Time) error {
+	params := db.BanIPAddressParams{
 		IpAddress: address,
 		Notes:     sql.NullString{String: note, Valid: true},
-	})
+		UserID:    sql.NullString{String: userID, Valid: userID != ""},
+	}
+	if expiresAt != nil {
+		params.ExpiresAt = sql.NullTime{Time: *expiresAt, Valid: true}
+	}
+
+	return _datastore.GetQueries().BanIPAddress(context.Background(), params)
 }
 
-// IsIPAddressBanned will return if an IP address has been previously blocked.
+// IsIPAddressBanned will return if an IP address has been previously blocked
+// and the ban has not expired.
 func IsIPAddressBanned(address string) (bool, error) {
-	blocked, error := _datastore.GetQueries().IsIPAddressBlocked(context.Background(), address)
-	return blocked > 0, error
+	expiresAt, err := _datastore.GetQueries().GetIPAddressBanExpiry(context.Background(), address)
+	if err == sql.ErrNoRows {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	return !expiresAt.Valid || time.Now().Before(expiresAt.Time), nil
 }
 
 // GetIPAddressBans will return all the banned IP addresses.
@@ -93,16 +123,48 @@ func GetIPAddressBans() ([]models.IPAddress, error) {
 
 	response := []models.IPAddress{}
 	for _, ip := range result {
-		response = append(response, models.IPAddress{
+		ban := models.IPAddress{
 			IPAddress: ip.IpAddress,
 			Notes:     ip.Notes.String,
 			CreatedAt: ip.CreatedAt.Time,
-		})
+			UserID:    ip.UserID.String,
+		}
+		if ip.ExpiresAt.Valid {
+			expiresAt := ip.ExpiresAt.Time
+			ban.ExpiresAt = &expiresAt
+		}
+		response = append(response, ban)
 	}
 	return response, err
 }
 
+// RemoveExpiredIPAddressBans will remove the IP address bans that have expired.
+func RemoveExpiredIPAddressBans() error {
+	bans, err := GetIPAddressBans()
+	if err != nil {
+		return err
+	}
+
+	for _, ban := range bans {
+		if ban.ExpiresAt == nil || time.Now().Before(*ban.ExpiresAt) {
+			continue
+		}
+
+		if err := RemoveIPAddressBan(ban.IPAddress); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // RemoveIPAddressBan will remove a previously banned IP address.
 func RemoveIPAddressBan(address string) error {
 	return _datastore.GetQueries().RemoveIPAddressBan(context.Background(), address)
 }
+
+// RemoveIPAddressBansForUser will remove the bans of the IP addresses of a
+// banned user.
+func RemoveIPAddressBansForUser(userID string) error {
+	return _datastore.GetQueries().RemoveIPAddressBansForUser(context.Background(), sql.NullString{String: userID, Valid: true})
+}
@@ -0,0 +1,115 @@
+package data
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIPAddressBanExpiry(t *testing.T) {
+	CreateBanIPTable(_datastore.DB)
+
+	expired := time.Now().Add(-time.Minute)
+	upcoming := time.Now().Add(time.Hour)
+
+	if err := BanIPAddress("192.0.2.1", "permanent", "", nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := BanIPAddress("192.0.2.2", "expired", "", &expired); err != nil {
+		t.Fatal(err)
+	}
+	if err := BanIPAddress("192.0.2.3", "temporary", "", &upcoming); err != nil {
+		t.Fatal(err)
+	}
+
+	for address, expected := range map[string]bool{
+		"192.0.2.1": true,
+		"192.0.2.2": false,
+		"192.0.2.3": true,
+		"192.0.2.4": false,
+	} {
+		if banned, err := IsIPAddressBanned(address); err != nil || banned != expected {
+			t.Errorf("%s: expected banned %t, got %t %v", address, expected, banned, err)
+		}
+	}
+
+	if err := RemoveExpiredIPAddressBans(); err != nil {
+		t.Fatal(err)
+	}
+
+	bans, err := GetIPAddressBans()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(bans) != 2 {
+		t.Errorf("expected only the expired ban to be removed, got %+v", bans)
+	}
+}
+
+func TestRemoveIPAddressBansForUser(t *testing.T) {
+	CreateBanIPTable(_datastore.DB)
+
+	if err := BanIPAddress("198.51.100.1", "Banning of sam", "ban-user-1", nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := BanIPAddress("198.51.100.2", "Banning of sam", "ban-user-2", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := RemoveIPAddressBansForUser("ban-user-1"); err != nil {
+		t.Fatal(err)
+	}
+
+	for address, expected := range map[string]bool{
+		"198.51.100.1": false,
+		"198.51.100.2": true,
+	} {
+		if banned, err := IsIPAddressBanned(address); err != nil || banned != expected {
+			t.Errorf("%s: expected banned %t, got %t %v", address, expected, banned, err)
+		}
+	}
+}
+
+func TestBanIPAddressAgain(t *testing.T) {
+	CreateBanIPTable(_datastore.DB)
+
+	expired := time.Now().Add(-time.Minute)
+	soon := time.Now().Add(time.Minute)
+	later := time.Now().Add(time.Hour)
+
+	getExpiry := func(address string) *time.Time {
+		t.Helper()
+		bans, err := GetIPAddressBans()
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, ban := range bans {
+			if ban.IPAddress == address {
+				return ban.ExpiresAt
+			}
+		}
+		t.Fatalf("expected %s to be banned", address)
+		return nil
+	}
+
+	// An expired ban that has not been removed yet is replaced.
+	for _, expiresAt := range []*time.Time{&expired, &later, &soon} {
+		if err := BanIPAddress("203.0.113.1", "timed", "", expiresAt); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if expiresAt := getExpiry("203.0.113.1"); expiresAt == nil || !expiresAt.Equal(later) {
+		t.Errorf("expected the later expiry to be kept, got %v", expiresAt)
+	}
+
+	// A permanent ban is never shortened.
+	if err := BanIPAddress("203.0.113.2", "permanent", "", nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := BanIPAddress("203.0.113.2", "timed", "", &later); err != nil {
+		t.Fatal(err)
+	}
+	if expiresAt := getExpiry("203.0.113.2"); expiresAt != nil {
+		t.Errorf("expected the ban to stay permanent, got %v", expiresAt)
+	}
+}
@@ -33,6 +33,30 @@ func migrateDatabaseSchema(db *sql.DB, from, to int) error {
 			migrateToSchema6(db)
 		case 6:
 			migrateToSchema7(db)
+		case 7:
+			migrateToSchema8(db)
+		case 8:
+			migrateToSchema9(db)
+		case 9:
+			migrateToSchema10(db)
+		case 10:
+			migrateToSchema11(db)
+		case 11:
+			migrateToSchema12(db)
+		case 12:
+			migrateToSchema13(db)
+		case 13:
+			migrateToSchema14(db)
+		case 14:
+			migrateToSchema15(db)
+		case 15:
+			migrateToSchema16(db)
+		case 16:
+			migrateToSchema17(db)
+		case 17:
+			migrateToSchema18(db)
+		case 18:
+			migrateToSchema19(db)
 		default:
 			log.Fatalln("missing database migration step")
 		}
@@ -46,6 +70,220 @@ func migrateDatabaseSchema(db *sql.DB, from, to int) error {
 	return nil
 }
 
+func migrateToSchema19(db *sql.DB) {
+	// Users can be timed out from chat until a point in time.
+	stmt, err := db.Prepare("ALTER TABLE users ADD COLUMN timed_out_until TIMESTAMP")
+	if err != nil {
+		log.Errorln("Error running migration. This may be because you have already been running a dev version.", err)
+		return
+	}
+	defer stmt.Close()
+
+	if _, err := stmt.Exec(); err != nil {
+		log.Warnln(err)
+	}
+}
+
+func migrateToSchema18(db *sql.DB) {
+	// Users keep when they first sent a chat message, as their messages are
+	// pruned from the chat history. Existing users are given their oldest
+	// message still saved.
+	for _, statement := range []string{
+		"ALTER TABLE users ADD COLUMN first_message_at TIMESTAMP",
+		"UPDATE users SET first_message_at = (SELECT MIN(timestamp) FROM messages WHERE messages.user_id = users.id AND messages.eventType = 'CHAT') WHERE first_message_at IS NULL",
+		"UPDATE users SET first_message_at = (SELECT MIN(timestamp) FROM chat_archive WHERE chat_archive.user_id = users.id AND chat_archive.eventType = 'CHAT') WHERE first_message_at IS NULL",
+	} {
+		stmt, err := db.Prepare(statement)
+		if err != nil {
+			log.Errorln("Error running migration. This may be because you have already been running a dev version.", err)
+			continue
+		}
+
+		if _, err := stmt.Exec(); err != nil {
+			log.Warnln(err)
+		}
+		stmt.Close()
+	}
+}
+
+func migrateToSchema17(db *sql.DB) {
+	// Archived chat keeps the chat room it was sent to. Existing archived
+	// messages still in the chat history are assigned to its room.
+	for _, statement := range []string{
+		"ALTER TABLE chat_archive ADD COLUMN room TEXT NOT NULL DEFAULT ''",
+		"UPDATE chat_archive SET room = (SELECT messages.room FROM messages WHERE messages.id = chat_archive.id) WHERE id IN (SELECT id FROM messages WHERE room != '')",
+	} {
+		stmt, err := db.Prepare(statement)
+		if err != nil {
+			log.Errorln("Error running migration. This may be because you have already been running a dev version.", err)
+			continue
+		}
+
+		if _, err := stmt.Exec(); err != nil {
+			log.Warnln(err)
+		}
+		stmt.Close()
+	}
+}
+
+func migrateToSchema16(db *sql.DB) {
+	// Archived chat keeps if it was hidden, deleted or edited.
+	for _, statement := range []string{
+		"ALTER TABLE chat_archive ADD COLUMN hidden_at DATETIME",
+		"ALTER TABLE chat_archive ADD COLUMN deleted_at DATETIME",
+		"ALTER TABLE chat_archive ADD COLUMN edited_at DATETIME",
+	} {
+		stmt, err := db.Prepare(statement)
+		if err != nil {
+			log.Errorln("Error running migration. This may be because you have already been running a dev version.", err)
+			continue
+		}
+
+		if _, err := stmt.Exec(); err != nil {
+			log.Warnln(err)
+		}
+		stmt.Close()
+	}
+}
+
+func migrateToSchema15(db *sql.DB) {
+	// Held messages keep the message they are a reply to.
+	stmt, err := db.Prepare("ALTER TABLE held_messages ADD COLUMN reply_to TEXT NOT NULL DEFAULT ''")
+	if err != nil {
+		log.Errorln("Error running migration. This may be because you have already been running a dev version.", err)
+		return
+	}
+	defer stmt.Close()
+
+	if _, err := stmt.Exec(); err != nil {
+		log.Warnln(err)
+	}
+}
+
+func migrateToSchema14(db *sql.DB) {
+	// IP address bans are tied to the banned user by their ID rather than
+	// their display name. Existing bans are assigned to the only banned user
+	// with the display name in their notes.
+	for _, statement := range []string{
+		"ALTER TABLE ip_bans ADD COLUMN user_id TEXT",
+		`UPDATE ip_bans SET user_id = (SELECT users.id FROM users WHERE ip_bans.notes = 'Banning of ' || users.display_name AND users.disabled_at IS NOT NULL)
+			WHERE (SELECT COUNT(*) FROM users WHERE ip_bans.notes = 'Banning of ' || users.display_name AND users.disabled_at IS NOT NULL) = 1`,
+	} {
+		stmt, err := db.Prepare(statement)
+		if err != nil {
+			log.Errorln("Error running migration. This may be because you have already been running a dev version.", err)
+			continue
+		}
+
+		if _, err := stmt.Exec(); err != nil {
+			log.Warnln(err)
+		}
+		stmt.Close()
+	}
+}
+
+func migrateToSchema13(db *sql.DB) {
+	// Chat messages can be sent to rooms other than the main chat.
+	for _, statement := range []string{
+		"ALTER TABLE messages ADD COLUMN room TEXT NOT NULL DEFAULT ''",
+		"ALTER TABLE held_messages ADD COLUMN room TEXT NOT NULL DEFAULT ''",
+	} {
+		stmt, err := db.Prepare(statement)
+		if err != nil {
+			log.Errorln("Error running migration. This may be because you have already been running a dev version.", err)
+			continue
+		}
+
+		if _, err := stmt.Exec(); err != nil {
+			log.Warnln(err)
+		}
+		stmt.Close()
+	}
+}
+
+func migrateToSchema12(db *sql.DB) {
+	// Chat messages are replayed alongside recorded streams.
+	stmt, err := db.Prepare("ALTER TABLE messages ADD COLUMN stream_offset INTEGER")
+	if err != nil {
+		log.Errorln("Error running migration. This may be because you have already been running a dev version.", err)
+		return
+	}
+	defer stmt.Close()
+
+	if _, err := stmt.Exec(); err != nil {
+		log.Warnln(err)
+	}
+}
+
+func migrateToSchema11(db *sql.DB) {
+	// Users can edit and delete their own chat messages.
+	for _, statement := range []string{
+		"ALTER TABLE messages ADD COLUMN edited_at DATETIME",
+		"ALTER TABLE messages ADD COLUMN deleted_at DATETIME",
+	} {
+		stmt, err := db.Prepare(statement)
+		if err != nil {
+			log.Errorln("Error running migration. This may be because you have already been running a dev version.", err)
+			continue
+		}
+
+		if _, err := stmt.Exec(); err != nil {
+			log.Warnln(err)
+		}
+		stmt.Close()
+	}
+}
+
+func migrateToSchema10(db *sql.DB) {
+	// Chat messages can be a reply to another message.
+	stmt, err := db.Prepare("ALTER TABLE messages ADD COLUMN reply_to TEXT")
+	if err != nil {
+		log.Errorln("Error running migration. This may be because you have already been running a dev version.", err)
+		return
+	}
+	defer stmt.Close()
+
+	if _, err := stmt.Exec(); err != nil {
+		log.Warnln(err)
+	}
+}
+
+func migrateToSchema9(db *sql.DB) {
+	// Users can be disabled and IP addresses banned until a point in time.
+	for _, statement := range []string{
+		"ALTER TABLE users ADD COLUMN disabled_until TIMESTAMP",
+		"ALTER TABLE ip_bans ADD COLUMN expires_at TIMESTAMP",
+	} {
+		stmt, err := db.Prepare(statement)
+		if err != nil {
+			log.Errorln("Error running migration. This may be because you have already been running a dev version.", err)
+			continue
+		}
+
+		if _, err := stmt.Exec(); err != nil {
+			log.Warnln(err)
+		}
+		stmt.Close()
+	}
+}
+
+func migrateToSchema8(db *sql.DB) {
+	// Chat messages and webhooks now belong to a channel. Existing rows
+	// belong to the default channel.
+	for _, table := range []string{"messages", "webhooks"} {
+		stmt, err := db.Prepare("ALTER TABLE " + table + " ADD COLUMN channel TEXT NOT NULL DEFAULT ''") //nolint:gosec
+		if err != nil {
+			log.Errorln("Error running migration. This may be because you have already been running a dev version.", err)
+			continue
+		}
+
+		if _, err := stmt.Exec(); err != nil {
+			log.Warnln(err)
+		}
+		stmt.Close()
+	}
+}
+
 func migrateToSchema7(db *sql.DB) {
 	log.Println("Migrating users. This may take time if you have lots of users...")
 
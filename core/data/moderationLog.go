@@ -0,0 +1,67 @@
+package data
+
+import (
+	"github.com/owncast/owncast/models"
+	log "github.com/sirupsen/logrus"
+)
+
+func createModerationLogTable() {
+	log.Traceln("Creating moderation log table...")
+
+	createTableSQL := `CREATE TABLE IF NOT EXISTS moderation_log (
+		"id" INTEGER PRIMARY KEY AUTOINCREMENT,
+		"timestamp" DATETIME NOT NULL,
+		"user_id" TEXT NOT NULL,
+		"message_id" TEXT NOT NULL,
+		"body" TEXT NOT NULL,
+		"source" TEXT NOT NULL,
+		"reason" TEXT NOT NULL,
+		"action" TEXT NOT NULL,
+		"channel" TEXT NOT NULL DEFAULT ''
+	);`
+
+	stmt, err := _db.Prepare(createTableSQL)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer stmt.Close()
+	if _, err = stmt.Exec(); err != nil {
+		log.Warnln(err)
+	}
+}
+
+// InsertModerationLogEntry will record a moderation action taken on a chat message.
+func InsertModerationLogEntry(entry models.ModerationLogEntry) error {
+	_datastore.DbLock.Lock()
+	defer _datastore.DbLock.Unlock()
+
+	stmt, err := _db.Prepare("INSERT INTO moderation_log(timestamp, user_id, message_id, body, source, reason, action, channel) values(?, ?, ?, ?, ?, ?, ?, ?)")
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	_, err = stmt.Exec(entry.Timestamp, entry.UserID, entry.MessageID, entry.Body, entry.Source, entry.Reason, entry.Action, entry.ChannelID)
+	return err
+}
+
+// GetModerationLog will return the most recent moderation actions, newest first.
+func GetModerationLog(limit int) ([]models.ModerationLogEntry, error) {
+	entries := make([]models.ModerationLogEntry, 0)
+
+	rows, err := _db.Query("SELECT id, timestamp, user_id, message_id, body, source, reason, action, channel FROM moderation_log ORDER BY timestamp DESC LIMIT ?", limit)
+	if err != nil {
+		return entries, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var entry models.ModerationLogEntry
+		if err := rows.Scan(&entry.ID, &entry.Timestamp, &entry.UserID, &entry.MessageID, &entry.Body, &entry.Source, &entry.Reason, &entry.Action, &entry.ChannelID); err != nil {
+			return entries, err
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
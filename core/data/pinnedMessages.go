@@ -0,0 +1,127 @@
+package data
+
+import (
+	"database/sql"
+	"strings"
+
+	"github.com/owncast/owncast/models"
+	log "github.com/sirupsen/logrus"
+)
+
+func createPinnedMessagesTable() {
+	log.Traceln("Creating pinned messages table...")
+
+	createTableSQL := `CREATE TABLE IF NOT EXISTS pinned_messages (
+		"channel" TEXT PRIMARY KEY,
+		"message_id" TEXT NOT NULL DEFAULT '',
+		"user_id" TEXT NOT NULL DEFAULT '',
+		"body" TEXT NOT NULL,
+		"style" TEXT NOT NULL DEFAULT '',
+		"pinned_by" TEXT NOT NULL,
+		"pinned_at" DATETIME NOT NULL
+	);`
+
+	stmt, err := _db.Prepare(createTableSQL)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer stmt.Close()
+	if _, err = stmt.Exec(); err != nil {
+		log.Warnln(err)
+	}
+}
+
+// SetPinnedMessage will pin a message to the chat of a channel, replacing
+// the message already pinned there.
+func SetPinnedMessage(pinned models.PinnedMessage) error {
+	_datastore.DbLock.Lock()
+	defer _datastore.DbLock.Unlock()
+
+	stmt, err := _db.Prepare("INSERT OR REPLACE INTO pinned_messages(channel, message_id, user_id, body, style, pinned_by, pinned_at) values(?, ?, ?, ?, ?, ?, ?)")
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	_, err = stmt.Exec(pinned.ChannelID, pinned.MessageID, pinned.UserID, pinned.Body, pinned.Style, pinned.PinnedBy, pinned.PinnedAt)
+	return err
+}
+
+// GetPinnedMessage will return the message pinned to the chat of a channel.
+// Returns nil if nothing is pinned there.
+func GetPinnedMessage(channelID string) (*models.PinnedMessage, error) {
+	var pinned models.PinnedMessage
+	row := _db.QueryRow("SELECT channel, message_id, user_id, body, style, pinned_by, pinned_at FROM pinned_messages WHERE channel = ?", channelID)
+	if err := row.Scan(&pinned.ChannelID, &pinned.MessageID, &pinned.UserID, &pinned.Body, &pinned.Style, &pinned.PinnedBy, &pinned.PinnedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &pinned, nil
+}
+
+// RemovePinnedMessage will unpin the message pinned to the chat of a
+// channel. Returns false if nothing was pinned there.
+func RemovePinnedMessage(channelID string) (bool, error) {
+	_datastore.DbLock.Lock()
+	defer _datastore.DbLock.Unlock()
+
+	result, err := _db.Exec("DELETE FROM pinned_messages WHERE channel = ?", channelID)
+	if err != nil {
+		return false, err
+	}
+
+	removed, err := result.RowsAffected()
+	return removed > 0, err
+}
+
+// RemovePinnedChatMessages will unpin any of the chat messages that are
+// pinned and return the channels they were pinned to.
+func RemovePinnedChatMessages(messageIDs []string) ([]string, error) {
+	channels := []string{}
+	if len(messageIDs) == 0 {
+		return channels, nil
+	}
+
+	_datastore.DbLock.Lock()
+	defer _datastore.DbLock.Unlock()
+
+	placeholders := "?" + strings.Repeat(", ?", len(messageIDs)-1)
+	args := make([]interface{}, len(messageIDs))
+	for i, id := range messageIDs {
+		args[i] = id
+	}
+
+	rows, err := _db.Query("SELECT channel FROM pinned_messages WHERE message_id IN ("+placeholders+")", args...) //nolint:gosec
+	if err != nil {
+		return channels, err
+	}
+	for rows.Next() {
+		var channelID string
+		if err := rows.Scan(&channelID); err != nil {
+			rows.Close()
+			return channels, err
+		}
+		channels = append(channels, channelID)
+	}
+	rows.Close()
+
+	if len(channels) == 0 {
+		return channels, nil
+	}
+
+	_, err = _db.Exec("DELETE FROM pinned_messages WHERE message_id IN ("+placeholders+")", args...) //nolint:gosec
+	return channels, err
+}
+
+// UpdatePinnedChatMessageBody will change the text of a pinned chat message
+// after it has been edited.
+func UpdatePinnedChatMessageBody(messageID string, body string) error {
+	_datastore.DbLock.Lock()
+	defer _datastore.DbLock.Unlock()
+
+	_, err := _db.Exec("UPDATE pinned_messages SET body = ? WHERE message_id = ? AND message_id != ''", body, messageID)
+	return err
+}
@@ -0,0 +1,72 @@
+package data
+
+import (
+	"testing"
+	"time"
+
+	"github.com/owncast/owncast/models"
+)
+
+func TestPinnedMessages(t *testing.T) {
+	createPinnedMessagesTable()
+
+	if pinned, err := GetPinnedMessage(models.DefaultChannelID); err != nil || pinned != nil {
+		t.Fatalf("expected nothing pinned, got %+v %v", pinned, err)
+	}
+
+	announcement := models.PinnedMessage{
+		PinnedAt:  time.Now().Truncate(time.Second),
+		Body:      "<p>we are live!</p>",
+		Style:     models.AnnouncementStyleCelebration,
+		PinnedBy:  "admin",
+		ChannelID: models.DefaultChannelID,
+	}
+	if err := SetPinnedMessage(announcement); err != nil {
+		t.Fatal(err)
+	}
+
+	message := models.PinnedMessage{
+		PinnedAt:  time.Now().Truncate(time.Second),
+		MessageID: "pinned-message-1",
+		UserID:    "pinned-user-1",
+		Body:      "<p>giveaway rules</p>",
+		PinnedBy:  "moderator:pinned-moderator",
+		ChannelID: models.DefaultChannelID,
+	}
+	if err := SetPinnedMessage(message); err != nil {
+		t.Fatal(err)
+	}
+
+	pinned, err := GetPinnedMessage(models.DefaultChannelID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pinned == nil || pinned.MessageID != message.MessageID || pinned.PinnedBy != message.PinnedBy || pinned.Style != "" {
+		t.Errorf("expected the announcement to be replaced by the message, got %+v", pinned)
+	}
+
+	if err := UpdatePinnedChatMessageBody(message.MessageID, "<p>new giveaway rules</p>"); err != nil {
+		t.Fatal(err)
+	}
+	if pinned, _ := GetPinnedMessage(models.DefaultChannelID); pinned == nil || pinned.Body != "<p>new giveaway rules</p>" {
+		t.Errorf("expected the pinned body to be updated, got %+v", pinned)
+	}
+
+	channels, err := RemovePinnedChatMessages([]string{"pinned-message-2", message.MessageID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(channels) != 1 || channels[0] != models.DefaultChannelID {
+		t.Errorf("expected the main channel to be unpinned, got %v", channels)
+	}
+
+	if err := SetPinnedMessage(announcement); err != nil {
+		t.Fatal(err)
+	}
+	if removed, err := RemovePinnedMessage(models.DefaultChannelID); err != nil || !removed {
+		t.Errorf("expected the announcement to be removed, got %v %v", removed, err)
+	}
+	if removed, err := RemovePinnedMessage(models.DefaultChannelID); err != nil || removed {
+		t.Errorf("expected nothing left to remove, got %v %v", removed, err)
+	}
+}
@@ -40,6 +40,9 @@ func createUsersTable(db *sql.DB) {
 		"scopes" TEXT,
 		"type" TEXT DEFAULT 'STANDARD',
 		"last_used" DATETIME DEFAULT CURRENT_TIMESTAMP,
+		"disabled_until" TIMESTAMP,
+		"first_message_at" TIMESTAMP,
+		"timed_out_until" TIMESTAMP,
 		PRIMARY KEY (id)
 	);`
 
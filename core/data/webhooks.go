@@ -18,7 +18,8 @@ func createWebhooksTable() {
 		"url" string NOT NULL,
 		"events" TEXT NOT NULL,
 		"timestamp" DATETIME DEFAULT CURRENT_TIMESTAMP,
-		"last_used" DATETIME
+		"last_used" DATETIME,
+		"channel" TEXT NOT NULL DEFAULT ''
 	);`
 
 	stmt, err := _db.Prepare(createTableSQL)
@@ -31,8 +32,13 @@ func createWebhooksTable() {
 	}
 }
 
-// InsertWebhook will add a new webhook to the database.
+// InsertWebhook will add a new webhook for the default channel to the database.
 func InsertWebhook(url string, events []models.EventType) (int, error) {
+	return InsertChannelWebhook(url, events, models.DefaultChannelID)
+}
+
+// InsertChannelWebhook will add a new webhook for a channel to the database.
+func InsertChannelWebhook(url string, events []models.EventType, channelID string) (int, error) {
 	log.Traceln("Adding new webhook")
 
 	eventsString := strings.Join(events, ",")
@@ -41,13 +47,13 @@ func InsertWebhook(url string, events []models.EventType) (int, error) {
 	if err != nil {
 		return 0, err
 	}
-	stmt, err := tx.Prepare("INSERT INTO webhooks(url, events) values(?, ?)")
+	stmt, err := tx.Prepare("INSERT INTO webhooks(url, events, channel) values(?, ?, ?)")
 	if err != nil {
 		return 0, err
 	}
 	defer stmt.Close()
 
-	insertResult, err := stmt.Exec(url, eventsString)
+	insertResult, err := stmt.Exec(url, eventsString, channelID)
 	if err != nil {
 		return 0, err
 	}
@@ -100,15 +106,15 @@ func GetWebhooksForEvent(event models.EventType) []models.Webhook {
 	webhooks := make([]models.Webhook, 0)
 
 	query := `SELECT * FROM (
-		WITH RECURSIVE split(id, url, event, rest) AS (
-		  SELECT id, url, '', events || ',' FROM webhooks
+		WITH RECURSIVE split(id, url, channel, event, rest) AS (
+		  SELECT id, url, channel, '', events || ',' FROM webhooks
 		   UNION ALL
-		  SELECT id, url,
+		  SELECT id, url, channel,
 				 substr(rest, 0, instr(rest, ',')),
 				 substr(rest, instr(rest, ',')+1)
 			FROM split
 		   WHERE rest <> '')
-		SELECT id, url, event
+		SELECT id, url, channel, event
 		  FROM split
 		 WHERE event <> ''
 	  ) AS webhook WHERE event IS "` + event + `"`
@@ -122,16 +128,18 @@ func GetWebhooksForEvent(event models.EventType) []models.Webhook {
 	for rows.Next() {
 		var id int
 		var url string
+		var channel string
 
-		if err := rows.Scan(&id, &url, &event); err != nil {
+		if err := rows.Scan(&id, &url, &channel, &event); err != nil {
 			log.Debugln(err)
 			log.Error("There is a problem with the database.")
 			break
 		}
 
 		singleWebhook := models.Webhook{
-			ID:  id,
-			URL: url,
+			ID:      id,
+			URL:     url,
+			Channel: channel,
 		}
 
 		webhooks = append(webhooks, singleWebhook)
@@ -143,7 +151,7 @@ func GetWebhooksForEvent(event models.EventType) []models.Webhook {
 func GetWebhooks() ([]models.Webhook, error) { //nolint
 	webhooks := make([]models.Webhook, 0)
 
-	query := "SELECT * FROM webhooks"
+	query := "SELECT id, url, events, timestamp, last_used, channel FROM webhooks"
 
 	rows, err := _db.Query(query)
 	if err != nil {
@@ -157,8 +165,9 @@ func GetWebhooks() ([]models.Webhook, error) { //nolint
 		var events string
 		var timestampString string
 		var lastUsedString *string
+		var channel string
 
-		if err := rows.Scan(&id, &url, &events, &timestampString, &lastUsedString); err != nil {
+		if err := rows.Scan(&id, &url, &events, &timestampString, &lastUsedString, &channel); err != nil {
 			log.Error("There is a problem reading the database.", err)
 			return webhooks, err
 		}
@@ -180,6 +189,7 @@ func GetWebhooks() ([]models.Webhook, error) { //nolint
 			Events:    strings.Split(events, ","),
 			Timestamp: timestamp,
 			LastUsed:  lastUsed,
+			Channel:   channel,
 		}
 
 		webhooks = append(webhooks, singleWebhook)
@@ -0,0 +1,500 @@
+package llhls
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/grafov/m3u8"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/owncast/owncast/models"
+	"github.com/owncast/owncast/utils"
+)
+
+// ffmpeg is unable to write LL-HLS playlists itself. Instead it writes every
+// partial segment as a short segment of its own, and those are grouped
+// back into full segments here. The resulting LL-HLS playlists are kept in
+// memory so requests are able to block until they contain a specific part.
+
+var (
+	// ErrNotAvailable is returned when LL-HLS is not being used for a variant.
+	ErrNotAvailable = errors.New("low latency playlist is not available")
+	// ErrInvalidRequest is returned when a request is for a part too far in the future.
+	ErrInvalidRequest = errors.New("requested media sequence number is too far in the future")
+	// ErrTimeout is returned when a blocking request is not satisfied in time.
+	ErrTimeout = errors.New("timed out waiting for the requested part")
+)
+
+type part struct {
+	filename string
+	sequence int
+	duration float64
+}
+
+// variantPlaylist is the LL-HLS state of a single output variant.
+type variantPlaylist struct {
+	completeSegments map[int]float64
+	directory        string
+	identifier       string
+	// The file extension of the parts, .ts or .m4s.
+	extension string
+	// The init segment of fMP4 parts, if used.
+	initSegment string
+	parts       []part
+	// The most recent part that is available on disk.
+	lastWrittenSequence int
+}
+
+var (
+	_level    models.LatencyLevel
+	_variants map[string]*variantPlaylist
+	_lock     sync.Mutex
+
+	// Closed and replaced every time a variant changes to wake up any
+	// requests that are waiting on it.
+	_updated = make(chan struct{})
+
+	// The largest part length seen, which is advertised as the part target.
+	_partTarget float64
+)
+
+// Start will start tracking partial segments when the latency level uses LL-HLS.
+func Start(level models.LatencyLevel) {
+	_lock.Lock()
+	defer _lock.Unlock()
+
+	if !level.IsLowLatencyHLS() {
+		_variants = nil
+		return
+	}
+
+	_level = level
+	_variants = make(map[string]*variantPlaylist)
+	_partTarget = level.GetSecondsPerPart()
+	notify()
+}
+
+// Stop will stop serving LL-HLS playlists.
+func Stop() {
+	_lock.Lock()
+	defer _lock.Unlock()
+
+	_variants = nil
+	notify()
+}
+
+func notify() {
+	close(_updated)
+	_updated = make(chan struct{})
+}
+
+// parsePartFilename will return the segment identifier and sequence number
+// of a part written by ffmpeg in the form of stream-{identifier}-{sequence}.ts
+// or stream-{identifier}-{sequence}.m4s.
+func parsePartFilename(filename string) (string, int, bool) {
+	if !utils.IsHLSSegment(filename) || !strings.HasPrefix(filename, "stream-") {
+		return "", 0, false
+	}
+
+	name := strings.TrimSuffix(strings.TrimPrefix(filename, "stream-"), filepath.Ext(filename))
+	separator := strings.LastIndex(name, "-")
+	if separator < 1 {
+		return "", 0, false
+	}
+
+	sequence, err := strconv.Atoi(name[separator+1:])
+	if err != nil {
+		return "", 0, false
+	}
+
+	return name[:separator], sequence, true
+}
+
+func segmentFilename(identifier string, mediaSequence int, extension string) string {
+	return fmt.Sprintf("segment-%s-%d%s", identifier, mediaSequence, extension)
+}
+
+func partFilename(identifier string, sequence int, extension string) string {
+	return fmt.Sprintf("stream-%s-%d%s", identifier, sequence, extension)
+}
+
+// getVariant will return the state of a variant for a part, resetting it if
+// the part was written by a new transcoder.
+func getVariant(localFilePath string, identifier string) *variantPlaylist {
+	index := utils.GetIndexFromFilePath(localFilePath)
+	variant := _variants[index]
+
+	if variant == nil || variant.identifier != identifier {
+		variant = &variantPlaylist{
+			completeSegments:    make(map[int]float64),
+			directory:           filepath.Dir(localFilePath),
+			identifier:          identifier,
+			extension:           filepath.Ext(localFilePath),
+			lastWrittenSequence: -1,
+		}
+		_variants[index] = variant
+	}
+
+	return variant
+}
+
+// SegmentWritten is fired when a partial segment is written to disk.
+func SegmentWritten(localFilePath string) {
+	_lock.Lock()
+	defer _lock.Unlock()
+
+	if _variants == nil {
+		return
+	}
+
+	identifier, sequence, ok := parsePartFilename(filepath.Base(localFilePath))
+	if !ok {
+		return
+	}
+
+	variant := getVariant(localFilePath, identifier)
+	if sequence > variant.lastWrittenSequence {
+		variant.lastWrittenSequence = sequence
+		notify()
+	}
+}
+
+// VariantPlaylistWritten is fired when ffmpeg writes a variant playlist
+// listing the partial segments and their lengths.
+func VariantPlaylistWritten(localFilePath string) {
+	_lock.Lock()
+	defer _lock.Unlock()
+
+	if _variants == nil {
+		return
+	}
+
+	parts, initSegment, err := readParts(localFilePath)
+	if err != nil || len(parts) == 0 {
+		return
+	}
+
+	lastPart := parts[len(parts)-1].filename
+	identifier, _, ok := parsePartFilename(lastPart)
+	if !ok {
+		return
+	}
+
+	variant := getVariant(filepath.Join(filepath.Dir(localFilePath), lastPart), identifier)
+	variant.initSegment = initSegment
+	for _, p := range parts {
+		if len(variant.parts) > 0 && p.sequence <= variant.parts[len(variant.parts)-1].sequence {
+			continue
+		}
+
+		variant.parts = append(variant.parts, p)
+		_partTarget = math.Max(_partTarget, math.Ceil(p.duration*1000)/1000)
+	}
+
+	variant.completeSegments = variant.buildCompleteSegments()
+	variant.trim()
+	notify()
+}
+
+// readParts will return the parts listed in a playlist written by ffmpeg,
+// along with the init segment of fMP4 parts.
+func readParts(playlistPath string) ([]part, string, error) {
+	f, err := os.Open(playlistPath) // nolint: gosec
+	if err != nil {
+		return nil, "", err
+	}
+	defer f.Close()
+
+	p, listType, err := m3u8.DecodeFrom(bufio.NewReader(f), false)
+	if err != nil {
+		return nil, "", err
+	}
+
+	mediaPlaylist, ok := p.(*m3u8.MediaPlaylist)
+	if listType != m3u8.MEDIA || !ok {
+		return nil, "", errors.New("not a media playlist: " + playlistPath)
+	}
+
+	initSegment := ""
+	if mediaPlaylist.Map != nil {
+		initSegment = filepath.Base(mediaPlaylist.Map.URI)
+	}
+
+	parts := []part{}
+	for _, segment := range mediaPlaylist.GetAllSegments() {
+		filename := filepath.Base(segment.URI)
+		_, sequence, ok := parsePartFilename(filename)
+		if !ok {
+			continue
+		}
+
+		parts = append(parts, part{filename: filename, sequence: sequence, duration: segment.Duration})
+	}
+
+	return parts, initSegment, nil
+}
+
+// buildCompleteSegments will write a full segment for every group of parts
+// that is complete, returning the lengths of all the complete segments.
+func (v *variantPlaylist) buildCompleteSegments() map[int]float64 {
+	partsPerSegment := _level.PartsPerSegment
+	groups := make(map[int][]part)
+	for _, p := range v.parts {
+		mediaSequence := p.sequence / partsPerSegment
+		groups[mediaSequence] = append(groups[mediaSequence], p)
+	}
+
+	complete := make(map[int]float64)
+	for mediaSequence, parts := range groups {
+		if len(parts) != partsPerSegment {
+			continue
+		}
+
+		duration := 0.0
+		for _, p := range parts {
+			duration += p.duration
+		}
+
+		if _, exists := v.completeSegments[mediaSequence]; !exists {
+			if err := v.writeSegment(mediaSequence, parts); err != nil {
+				log.Warnln("unable to write low latency segment", err)
+				continue
+			}
+		}
+
+		complete[mediaSequence] = duration
+	}
+
+	return complete
+}
+
+// writeSegment will write a full segment made up of its parts. MPEG-TS
+// parts, and fMP4 fragments sharing an init segment, are able to be simply
+// concatenated.
+func (v *variantPlaylist) writeSegment(mediaSequence int, parts []part) error {
+	segmentPath := filepath.Join(v.directory, segmentFilename(v.identifier, mediaSequence, v.extension))
+	tmpFile, err := os.CreateTemp(v.directory, "tmp-segment-*")
+	if err != nil {
+		return err
+	}
+
+	for _, p := range parts {
+		content, err := os.ReadFile(filepath.Join(v.directory, p.filename)) // nolint: gosec
+		if err != nil {
+			_ = tmpFile.Close()
+			_ = os.Remove(tmpFile.Name())
+			return err
+		}
+
+		if _, err := tmpFile.Write(content); err != nil {
+			_ = tmpFile.Close()
+			_ = os.Remove(tmpFile.Name())
+			return err
+		}
+	}
+
+	if err := tmpFile.Close(); err != nil {
+		return err
+	}
+
+	return utils.Move(tmpFile.Name(), segmentPath)
+}
+
+// trim will forget about parts of segments that are no longer in the playlist.
+func (v *variantPlaylist) trim() {
+	if len(v.parts) == 0 {
+		return
+	}
+
+	lastMediaSequence := v.parts[len(v.parts)-1].sequence / _level.PartsPerSegment
+	firstMediaSequence := lastMediaSequence - _level.SegmentCount
+
+	for len(v.parts) > 0 && v.parts[0].sequence/_level.PartsPerSegment < firstMediaSequence {
+		v.parts = v.parts[1:]
+	}
+
+	// Tracking may have started part way through a segment that will never
+	// be complete.
+	for len(v.parts) > 0 && v.parts[0].sequence%_level.PartsPerSegment != 0 && v.parts[0].sequence/_level.PartsPerSegment < lastMediaSequence {
+		v.parts = v.parts[1:]
+	}
+
+	for mediaSequence := range v.completeSegments {
+		if mediaSequence < firstMediaSequence {
+			delete(v.completeSegments, mediaSequence)
+		}
+	}
+}
+
+// lastPosition will return the media sequence number and part index of the
+// most recent part in the playlist.
+func (v *variantPlaylist) lastPosition() (int, int) {
+	if len(v.parts) == 0 {
+		return -1, -1
+	}
+
+	sequence := v.parts[len(v.parts)-1].sequence
+	return sequence / _level.PartsPerSegment, sequence % _level.PartsPerSegment
+}
+
+// contains will return if the playlist contains the part, or the full
+// segment when partIndex is negative.
+func (v *variantPlaylist) contains(mediaSequence, partIndex int) bool {
+	lastMediaSequence, lastPartIndex := v.lastPosition()
+	if mediaSequence < lastMediaSequence {
+		return true
+	}
+
+	if mediaSequence > lastMediaSequence {
+		return false
+	}
+
+	if partIndex < 0 {
+		_, complete := v.completeSegments[mediaSequence]
+		return complete
+	}
+
+	return partIndex <= lastPartIndex
+}
+
+// render will return the LL-HLS playlist.
+func (v *variantPlaylist) render() string {
+	lastMediaSequence, _ := v.lastPosition()
+	firstMediaSequence := v.parts[0].sequence / _level.PartsPerSegment
+
+	targetDuration := float64(_level.SecondsPerSegment)
+	for _, duration := range v.completeSegments {
+		targetDuration = math.Max(targetDuration, duration)
+	}
+
+	var b strings.Builder
+	b.WriteString("#EXTM3U\n")
+	b.WriteString("#EXT-X-VERSION:6\n")
+	fmt.Fprintf(&b, "#EXT-X-TARGETDURATION:%d\n", int(math.Ceil(targetDuration)))
+	fmt.Fprintf(&b, "#EXT-X-SERVER-CONTROL:CAN-BLOCK-RELOAD=YES,PART-HOLD-BACK=%.3f\n", _partTarget*3)
+	fmt.Fprintf(&b, "#EXT-X-PART-INF:PART-TARGET=%.3f\n", _partTarget)
+	fmt.Fprintf(&b, "#EXT-X-MEDIA-SEQUENCE:%d\n", firstMediaSequence)
+	b.WriteString("#EXT-X-INDEPENDENT-SEGMENTS\n")
+	if v.initSegment != "" {
+		fmt.Fprintf(&b, "#EXT-X-MAP:URI=\"%s\"\n", v.initSegment)
+	}
+
+	for _, p := range v.parts {
+		mediaSequence := p.sequence / _level.PartsPerSegment
+
+		// Parts are only listed for the most recent segments.
+		if mediaSequence >= lastMediaSequence-2 {
+			// Every part begins with a keyframe.
+			fmt.Fprintf(&b, "#EXT-X-PART:DURATION=%.3f,URI=\"%s\",INDEPENDENT=YES\n", p.duration, p.filename)
+		}
+
+		if duration, complete := v.completeSegments[mediaSequence]; complete && p.sequence%_level.PartsPerSegment == _level.PartsPerSegment-1 {
+			fmt.Fprintf(&b, "#EXTINF:%.3f,\n", duration)
+			b.WriteString(segmentFilename(v.identifier, mediaSequence, v.extension) + "\n")
+		}
+	}
+
+	nextSequence := v.parts[len(v.parts)-1].sequence + 1
+	fmt.Fprintf(&b, "#EXT-X-PRELOAD-HINT:TYPE=PART,URI=\"%s\"\n", partFilename(v.identifier, nextSequence, v.extension))
+
+	return b.String()
+}
+
+// wait will block until the condition, which is checked while holding the
+// lock, is met, the timeout passes or the request is cancelled.
+func wait(ctx context.Context, condition func() (bool, error)) error {
+	_lock.Lock()
+	// Blocking requests are held for up to three segments.
+	timeout := time.NewTimer(time.Duration(_level.SecondsPerSegment*3) * time.Second)
+	_lock.Unlock()
+	defer timeout.Stop()
+
+	for {
+		_lock.Lock()
+		done, err := condition()
+		updated := _updated
+		_lock.Unlock()
+
+		if done || err != nil {
+			return err
+		}
+
+		select {
+		case <-updated:
+		case <-timeout.C:
+			return ErrTimeout
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// GetVariantPlaylist will return the LL-HLS playlist of a variant. When a
+// media sequence number is provided the request blocks until the playlist
+// contains that segment, or the part of it when a part index is provided.
+// Negative values mean they were not provided.
+func GetVariantPlaylist(ctx context.Context, index string, mediaSequence, partIndex int) (string, error) {
+	var playlist string
+
+	err := wait(ctx, func() (bool, error) {
+		if _variants == nil {
+			return false, ErrNotAvailable
+		}
+
+		variant := _variants[index]
+		if variant == nil || len(variant.parts) == 0 {
+			// The stream has only just started, so wait for it to begin.
+			if mediaSequence >= 0 {
+				return false, nil
+			}
+			return false, ErrNotAvailable
+		}
+
+		if mediaSequence >= 0 {
+			lastMediaSequence, _ := variant.lastPosition()
+			if mediaSequence > lastMediaSequence+2 {
+				return false, ErrInvalidRequest
+			}
+
+			if !variant.contains(mediaSequence, partIndex) {
+				return false, nil
+			}
+		}
+
+		playlist = variant.render()
+		return true, nil
+	})
+
+	return playlist, err
+}
+
+// WaitForPart will block until a part that has been hinted as the next part
+// of a variant is available on disk.
+func WaitForPart(ctx context.Context, index string, filename string) error {
+	identifier, sequence, ok := parsePartFilename(filename)
+	if !ok {
+		return ErrNotAvailable
+	}
+
+	return wait(ctx, func() (bool, error) {
+		if _variants == nil {
+			return false, ErrNotAvailable
+		}
+
+		variant := _variants[index]
+		if variant == nil || variant.identifier != identifier || sequence > variant.lastWrittenSequence+1 {
+			return false, ErrNotAvailable
+		}
+
+		return sequence <= variant.lastWrittenSequence, nil
+	})
+}
@@ -0,0 +1,103 @@
+package llhls
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/owncast/owncast/models"
+)
+
+func TestLowLatencyPlaylist(t *testing.T) {
+	directory := filepath.Join(t.TempDir(), "0")
+	if err := os.MkdirAll(directory, 0o750); err != nil {
+		t.Fatal(err)
+	}
+
+	Start(models.GetLatencyLevel(models.LowLatencyHLSLevel))
+	defer Stop()
+
+	playlistPath := filepath.Join(directory, "stream.m3u8")
+	playlist := "#EXTM3U\n#EXT-X-VERSION:3\n#EXT-X-TARGETDURATION:1\n#EXT-X-MEDIA-SEQUENCE:0\n"
+
+	// Write a full segment made of four parts and the first part of the next.
+	writePart := func(sequence int) {
+		filename := partFilename("abc", sequence, ".ts")
+		if err := os.WriteFile(filepath.Join(directory, filename), []byte(fmt.Sprint(sequence)), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		SegmentWritten(filepath.Join(directory, filename))
+
+		playlist += "#EXTINF:0.500000,\n" + filename + "\n"
+		if err := os.WriteFile(playlistPath, []byte(playlist), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		VariantPlaylistWritten(playlistPath)
+	}
+
+	for sequence := 0; sequence < 5; sequence++ {
+		writePart(sequence)
+	}
+
+	content, err := GetVariantPlaylist(context.Background(), "0", -1, -1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, line := range []string{
+		"#EXT-X-SERVER-CONTROL:CAN-BLOCK-RELOAD=YES,PART-HOLD-BACK=1.500",
+		"#EXT-X-PART-INF:PART-TARGET=0.500",
+		"#EXT-X-PART:DURATION=0.500,URI=\"stream-abc-4.ts\",INDEPENDENT=YES",
+		"#EXTINF:2.000,\nsegment-abc-0.ts",
+		"#EXT-X-PRELOAD-HINT:TYPE=PART,URI=\"stream-abc-5.ts\"",
+	} {
+		if !strings.Contains(content, line) {
+			t.Errorf("playlist is missing %q:\n%s", line, content)
+		}
+	}
+
+	if segment, err := os.ReadFile(filepath.Join(directory, segmentFilename("abc", 0, ".ts"))); err != nil || string(segment) != "0123" {
+		t.Errorf("segment = %q, %v, want the concatenated parts", segment, err)
+	}
+
+	if _, err := GetVariantPlaylist(context.Background(), "0", 4, -1); err != ErrInvalidRequest {
+		t.Errorf("requesting a segment too far ahead = %v, want %v", err, ErrInvalidRequest)
+	}
+
+	// A blocking reload returns once the requested part is written.
+	result := make(chan string)
+	go func() {
+		content, _ := GetVariantPlaylist(context.Background(), "0", 1, 1)
+		result <- content
+	}()
+
+	select {
+	case <-result:
+		t.Fatal("playlist was returned before the requested part was written")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	writePart(5)
+
+	if content := <-result; !strings.Contains(content, "stream-abc-5.ts\",INDEPENDENT=YES") {
+		t.Errorf("playlist is missing the requested part:\n%s", content)
+	}
+
+	// A request for the hinted part is held until it is written.
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		writePart(6)
+	}()
+
+	if err := WaitForPart(context.Background(), "0", partFilename("abc", 6, ".ts")); err != nil {
+		t.Errorf("waiting for the hinted part = %v", err)
+	}
+
+	if err := WaitForPart(context.Background(), "0", partFilename("abc", 9, ".ts")); err != ErrNotAvailable {
+		t.Errorf("waiting for a part that has not been hinted = %v, want %v", err, ErrNotAvailable)
+	}
+}
@@ -7,12 +7,55 @@ import (
 
 	"github.com/grafov/m3u8"
 	"github.com/owncast/owncast/config"
+	"github.com/owncast/owncast/core/transcoder"
+	"github.com/owncast/owncast/models"
 	"github.com/owncast/owncast/static"
 	"github.com/owncast/owncast/utils"
 	log "github.com/sirupsen/logrus"
 )
 
-func appendOfflineToVariantPlaylist(index int, playlistFilePath string) {
+// offlineClip is the video appended to the playlists of a stream once it
+// ends, in the segment format of those playlists.
+type offlineClip struct {
+	segmentFilePath string
+	// initFilePath is the fMP4 initialization segment. Empty for MPEG-TS.
+	initFilePath string
+}
+
+func (c offlineClip) segmentFilename() string {
+	return filepath.Base(c.segmentFilePath)
+}
+
+// getOfflineClip will write the offline clip to disk in the segment format
+// of the stream.
+func getOfflineClip(segmentFormat models.SegmentFormat) (offlineClip, error) {
+	tsFilePath, err := saveOfflineClipToDisk("offline.ts")
+	if err != nil {
+		return offlineClip{}, err
+	}
+
+	if segmentFormat != models.SegmentFormatFMP4 {
+		return offlineClip{segmentFilePath: tsFilePath}, nil
+	}
+
+	// fMP4 playlists carry an EXT-X-MAP that players would apply to an
+	// MPEG-TS clip, so the clip is remuxed and given its own map.
+	outputDirectory, err := os.MkdirTemp(config.TempDir, "offline-fmp4")
+	if err != nil {
+		return offlineClip{}, fmt.Errorf("unable to create offline clip directory: %s", err)
+	}
+
+	if err := transcoder.MakeFMP4OfflineClip(tsFilePath, outputDirectory); err != nil {
+		return offlineClip{}, err
+	}
+
+	return offlineClip{
+		segmentFilePath: filepath.Join(outputDirectory, transcoder.OfflineFMP4SegmentFilename),
+		initFilePath:    filepath.Join(outputDirectory, transcoder.OfflineFMP4InitFilename),
+	}, nil
+}
+
+func appendOfflineToVariantPlaylist(index int, playlistFilePath string, clip offlineClip) {
 	existingPlaylistContents, err := os.ReadFile(playlistFilePath) // nolint: gosec
 	if err != nil {
 		log.Debugln("unable to read existing playlist file", err)
@@ -34,9 +77,12 @@ func appendOfflineToVariantPlaylist(index int, playlistFilePath string) {
 
 	// Manually append the offline clip to the end of the media playlist.
 	_, _ = atomicWriteTmpPlaylistFile.WriteString("#EXT-X-DISCONTINUITY\n")
+	if clip.initFilePath != "" {
+		_, _ = atomicWriteTmpPlaylistFile.WriteString(fmt.Sprintf("#EXT-X-MAP:URI=\"%s\"\n", filepath.Base(clip.initFilePath)))
+	}
 	// If "offline" content gets changed then change the duration below
 	_, _ = atomicWriteTmpPlaylistFile.WriteString("#EXTINF:8.000000,\n")
-	_, _ = atomicWriteTmpPlaylistFile.WriteString("offline.ts\n")
+	_, _ = atomicWriteTmpPlaylistFile.WriteString(clip.segmentFilename() + "\n")
 	_, _ = atomicWriteTmpPlaylistFile.WriteString("#EXT-X-ENDLIST\n")
 
 	if err := atomicWriteTmpPlaylistFile.Close(); err != nil {
@@ -48,36 +94,49 @@ func appendOfflineToVariantPlaylist(index int, playlistFilePath string) {
 	}
 }
 
-func makeVariantIndexOffline(index int, offlineFilePath string, offlineFilename string) {
-	playlistFilePath := fmt.Sprintf(filepath.Join(config.HLSStoragePath, "%d/stream.m3u8"), index)
-	segmentFilePath := fmt.Sprintf(filepath.Join(config.HLSStoragePath, "%d/%s"), index, offlineFilename)
+func makeVariantIndexOffline(channelID string, index int, clip offlineClip) {
+	channelPath := filepath.Join(config.HLSStoragePath, channelID)
+	playlistFilePath := fmt.Sprintf(filepath.Join(channelPath, "%d/stream.m3u8"), index)
 
-	if err := utils.Copy(offlineFilePath, segmentFilePath); err != nil {
-		log.Warnln(err)
+	clipFilePaths := []string{clip.segmentFilePath}
+	if clip.initFilePath != "" {
+		clipFilePaths = append(clipFilePaths, clip.initFilePath)
 	}
 
-	if _, err := _storage.Save(segmentFilePath, 0); err != nil {
-		log.Warnln(err)
+	for _, clipFilePath := range clipFilePaths {
+		segmentFilePath := fmt.Sprintf(filepath.Join(channelPath, "%d/%s"), index, filepath.Base(clipFilePath))
+
+		if err := utils.Copy(clipFilePath, segmentFilePath); err != nil {
+			log.Warnln(err)
+		}
+
+		if _, err := _storage.Save(segmentFilePath, 0); err != nil {
+			log.Warnln(err)
+		}
 	}
 
 	if utils.DoesFileExists(playlistFilePath) {
-		appendOfflineToVariantPlaylist(index, playlistFilePath)
+		appendOfflineToVariantPlaylist(index, playlistFilePath, clip)
 	} else {
-		createEmptyOfflinePlaylist(playlistFilePath, offlineFilename)
+		createEmptyOfflinePlaylist(playlistFilePath, clip)
 	}
 	if _, err := _storage.Save(playlistFilePath, 0); err != nil {
 		log.Warnln(err)
 	}
 }
 
-func createEmptyOfflinePlaylist(playlistFilePath string, offlineFilename string) {
+func createEmptyOfflinePlaylist(playlistFilePath string, clip offlineClip) {
 	p, err := m3u8.NewMediaPlaylist(1, 1)
 	if err != nil {
 		log.Errorln(err)
 	}
 
+	if clip.initFilePath != "" {
+		p.SetDefaultMap(filepath.Base(clip.initFilePath), 0, 0)
+	}
+
 	// If "offline" content gets changed then change the duration below
-	if err := p.Append(offlineFilename, 8.0, ""); err != nil {
+	if err := p.Append(clip.segmentFilename(), 8.0, ""); err != nil {
 		log.Errorln(err)
 	}
 
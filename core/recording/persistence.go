@@ -0,0 +1,227 @@
+package recording
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/owncast/owncast/config"
+	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/db"
+	"github.com/owncast/owncast/models"
+)
+
+// Setup will create the recordings table and finalize any recordings that
+// were left unfinished when the server last stopped.
+func Setup(datastore *data.Datastore) {
+	createRecordingsTable(datastore.DB)
+
+	if err := os.MkdirAll(config.RecordingsPath, 0o750); err != nil {
+		log.Errorln("unable to create recordings directory", err)
+	}
+
+	finalizeUnfinishedRecordings()
+}
+
+func createRecordingsTable(db *sql.DB) {
+	log.Traceln("Creating recordings table...")
+
+	createTableSQL := `CREATE TABLE IF NOT EXISTS recordings (
+		"id" TEXT NOT NULL PRIMARY KEY,
+		"title" TEXT,
+		"variant_index" INTEGER NOT NULL,
+		"visibility" TEXT NOT NULL DEFAULT 'private',
+		"started_at" TIMESTAMP NOT NULL,
+		"ended_at" TIMESTAMP);`
+
+	data.MustExec(createTableSQL, db)
+	data.MustExec(`CREATE INDEX IF NOT EXISTS idx_recordings_started_at ON recordings (started_at);`, db)
+}
+
+// DuringRecordingCondition will return an SQL condition that is true when a
+// timestamp column of another table falls within a recording, so data kept
+// for replaying alongside recordings does not depend on how they are stored.
+func DuringRecordingCondition(timestampColumn string) string {
+	return "EXISTS (SELECT 1 FROM recordings WHERE " + timestampColumn + " >= recordings.started_at AND (recordings.ended_at IS NULL OR " + timestampColumn + " <= recordings.ended_at))"
+}
+
+func finalizeUnfinishedRecordings() {
+	recordings, err := data.GetDatastore().GetQueries().GetUnfinishedRecordings(context.Background())
+	if err != nil {
+		log.Errorln("unable to query unfinished recordings", err)
+		return
+	}
+
+	for _, recording := range recordings {
+		directory := filepath.Join(config.RecordingsPath, recording.ID)
+		playlistPath := filepath.Join(directory, playlistFilename)
+
+		segments, initSegment, err := readPlaylistSegments(playlistPath)
+		if err != nil || len(segments) == 0 {
+			log.Warnln("Removing unfinished recording", recording.ID, "with no recorded video")
+			if err := DeleteRecording(recording.ID); err != nil {
+				log.Errorln(err)
+			}
+			continue
+		}
+
+		if err := writePlaylist(directory, segments, initSegment, true); err != nil {
+			log.Errorln("unable to finalize recording playlist", err)
+			continue
+		}
+
+		endedAt := recording.StartedAt
+		if info, err := os.Stat(playlistPath); err == nil {
+			endedAt = info.ModTime()
+		}
+
+		if err := setRecordingEnded(recording.ID, endedAt); err != nil {
+			log.Errorln(err)
+		}
+	}
+}
+
+func addRecording(id, title string, variantIndex int, visibility models.RecordingVisibility, startedAt time.Time) error {
+	return data.GetDatastore().GetQueries().AddRecording(context.Background(), db.AddRecordingParams{
+		ID:           id,
+		Title:        sql.NullString{String: title, Valid: title != ""},
+		VariantIndex: int32(variantIndex),
+		Visibility:   visibility,
+		StartedAt:    startedAt,
+	})
+}
+
+func setRecordingEnded(id string, endedAt time.Time) error {
+	return data.GetDatastore().GetQueries().SetRecordingEnded(context.Background(), db.SetRecordingEndedParams{
+		ID:      id,
+		EndedAt: sql.NullTime{Time: endedAt, Valid: true},
+	})
+}
+
+func deleteRecording(id string) error {
+	if err := data.GetDatastore().GetQueries().DeleteRecording(context.Background(), id); err != nil {
+		return errors.Wrap(err, "unable to delete recording "+id)
+	}
+
+	return os.RemoveAll(filepath.Join(config.RecordingsPath, id))
+}
+
+func makeRecordingFromRow(row db.Recording) models.Recording {
+	recording := models.Recording{
+		ID:           row.ID,
+		Title:        row.Title.String,
+		Visibility:   row.Visibility,
+		StartedAt:    row.StartedAt,
+		VariantIndex: int(row.VariantIndex),
+	}
+
+	if row.EndedAt.Valid {
+		recording.EndedAt = &row.EndedAt.Time
+	}
+
+	segments, _, _ := readPlaylistSegments(filepath.Join(config.RecordingsPath, row.ID, playlistFilename))
+	for _, segment := range segments {
+		recording.Duration += segment.duration
+	}
+
+	return recording
+}
+
+// GetRecordings will return all recordings, most recent first.
+func GetRecordings() ([]models.Recording, error) {
+	rows, err := data.GetDatastore().GetQueries().GetRecordings(context.Background())
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to query recordings")
+	}
+
+	recordings := []models.Recording{}
+	for _, row := range rows {
+		recordings = append(recordings, makeRecordingFromRow(row))
+	}
+
+	return recordings, nil
+}
+
+// GetPublicRecordings will return the finished recordings that are listed
+// publicly, most recent first.
+func GetPublicRecordings() ([]models.Recording, error) {
+	rows, err := data.GetDatastore().GetQueries().GetFinishedRecordingsWithVisibility(context.Background(), models.RecordingVisibilityPublic)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to query public recordings")
+	}
+
+	recordings := []models.Recording{}
+	for _, row := range rows {
+		recordings = append(recordings, makeRecordingFromRow(row))
+	}
+
+	return recordings, nil
+}
+
+// SetRecordingVisibility will change who is able to watch a recording.
+func SetRecordingVisibility(id string, visibility models.RecordingVisibility) error {
+	if !models.IsValidRecordingVisibility(visibility) {
+		return errors.New("invalid recording visibility: " + visibility)
+	}
+
+	return data.GetDatastore().GetQueries().SetRecordingVisibility(context.Background(), db.SetRecordingVisibilityParams{
+		ID:         id,
+		Visibility: visibility,
+	})
+}
+
+// GetRecordingFilePath will return the local path of a playlist or segment
+// belonging to a recording.
+func GetRecordingFilePath(id, filename string) string {
+	return filepath.Join(config.RecordingsPath, filepath.Base(id), filepath.Base(filename))
+}
+
+// GetRecording will return a single recording.
+func GetRecording(id string) (*models.Recording, error) {
+	row, err := data.GetDatastore().GetQueries().GetRecordingByID(context.Background(), id)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to find recording "+id)
+	}
+
+	recording := makeRecordingFromRow(row)
+	return &recording, nil
+}
+
+// GetRecordingSegmentPaths will return the local paths of all the segments
+// of a recording, in order. The init segment of fMP4 segments comes first.
+func GetRecordingSegmentPaths(id string) ([]string, error) {
+	directory := filepath.Join(config.RecordingsPath, filepath.Base(id))
+	segments, initSegment, err := readPlaylistSegments(filepath.Join(directory, playlistFilename))
+	if err != nil {
+		return nil, err
+	}
+
+	paths := []string{}
+	if initSegment != "" {
+		paths = append(paths, filepath.Join(directory, initSegment))
+	}
+
+	for _, segment := range segments {
+		paths = append(paths, filepath.Join(directory, segment.filename))
+	}
+
+	return paths, nil
+}
+
+// DeleteRecording will remove a recording and all of its video.
+func DeleteRecording(id string) error {
+	_lock.Lock()
+	isRecording := _current != nil && _current.id == id
+	_lock.Unlock()
+
+	if isRecording {
+		return errors.New("unable to delete a recording that is in progress")
+	}
+
+	return deleteRecording(id)
+}
@@ -0,0 +1,248 @@
+package recording
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/grafov/m3u8"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"github.com/teris-io/shortid"
+
+	"github.com/owncast/owncast/config"
+	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/core/playlist"
+	"github.com/owncast/owncast/models"
+	"github.com/owncast/owncast/utils"
+)
+
+// Segments of a single output variant are copied out of the HLS directory
+// as they are written, before the storage provider has a chance to upload
+// or clean them up. The recording playlist is rewritten as the stream goes
+// on and finalized as a VOD playlist once the stream ends.
+
+const playlistFilename = "stream.m3u8"
+
+type recordedSegment struct {
+	filename string
+	duration float64
+}
+
+// recorder is the recording of the current stream.
+type recorder struct {
+	// Segments that have been copied but have not yet shown up in the
+	// variant playlist, so their duration is not known.
+	pending map[string]bool
+
+	id           string
+	directory    string
+	variantIndex string
+	// The init segment of fMP4 segments, if used.
+	initSegment string
+	segments    []recordedSegment
+}
+
+var (
+	_current *recorder
+	_lock    sync.Mutex
+)
+
+// Start will start recording the stream if recording is enabled.
+func Start(title string, outputVariants []models.StreamOutputVariant) {
+	recordingConfig := data.GetRecordingConfig()
+	if !recordingConfig.Enabled {
+		return
+	}
+
+	variantIndex := recordingConfig.VariantIndex
+	if variantIndex < 0 || variantIndex >= len(outputVariants) {
+		variantIndex = data.FindHighestVideoQualityIndex(outputVariants)
+	}
+
+	visibility := recordingConfig.DefaultVisibility
+	if !models.IsValidRecordingVisibility(visibility) {
+		visibility = models.RecordingVisibilityPrivate
+	}
+
+	id := shortid.MustGenerate()
+	directory := filepath.Join(config.RecordingsPath, id)
+	if err := os.MkdirAll(directory, 0o750); err != nil {
+		log.Errorln("unable to create recording directory", err)
+		return
+	}
+
+	if err := addRecording(id, title, variantIndex, visibility, time.Now()); err != nil {
+		log.Errorln("unable to save recording", err)
+		return
+	}
+
+	_lock.Lock()
+	defer _lock.Unlock()
+
+	_current = &recorder{
+		id:           id,
+		directory:    directory,
+		variantIndex: strconv.Itoa(variantIndex),
+		pending:      make(map[string]bool),
+	}
+
+	log.Infoln("Recording stream variant", variantIndex, "to", directory)
+}
+
+// Stop will stop the current recording and finalize its playlist.
+func Stop() {
+	_lock.Lock()
+	defer _lock.Unlock()
+
+	if _current == nil {
+		return
+	}
+
+	r := _current
+	_current = nil
+
+	// Nothing was recorded, so there is nothing worth keeping.
+	if len(r.segments) == 0 {
+		if err := deleteRecording(r.id); err != nil {
+			log.Errorln(err)
+		}
+		return
+	}
+
+	if err := writePlaylist(r.directory, r.segments, r.initSegment, true); err != nil {
+		log.Errorln("unable to finalize recording playlist", err)
+	}
+
+	if err := setRecordingEnded(r.id, time.Now()); err != nil {
+		log.Errorln(err)
+	}
+
+	log.Infoln("Recording", r.id, "finished")
+}
+
+// SegmentWritten will copy a segment into the current recording if it
+// belongs to the recorded variant.
+func SegmentWritten(localFilePath string) {
+	_lock.Lock()
+	defer _lock.Unlock()
+
+	if _current == nil || utils.GetIndexFromFilePath(localFilePath) != _current.variantIndex {
+		return
+	}
+
+	filename := filepath.Base(localFilePath)
+	if err := utils.Copy(localFilePath, filepath.Join(_current.directory, filename)); err != nil {
+		log.Errorln("unable to copy segment to recording", err)
+		return
+	}
+
+	_current.pending[filename] = true
+}
+
+// VariantPlaylistWritten will add any newly recorded segments listed in the
+// variant playlist to the recording playlist.
+func VariantPlaylistWritten(localFilePath string) {
+	_lock.Lock()
+	defer _lock.Unlock()
+
+	if _current == nil || utils.GetIndexFromFilePath(localFilePath) != _current.variantIndex {
+		return
+	}
+
+	segments, initSegment, err := readPlaylistSegments(localFilePath)
+	if err != nil {
+		log.Warnln("unable to read variant playlist for recording", err)
+		return
+	}
+
+	if initSegment != "" && _current.pending[initSegment] {
+		delete(_current.pending, initSegment)
+		_current.initSegment = initSegment
+	}
+
+	added := false
+	for _, segment := range segments {
+		if !_current.pending[segment.filename] {
+			continue
+		}
+
+		delete(_current.pending, segment.filename)
+		_current.segments = append(_current.segments, segment)
+		added = true
+	}
+
+	if !added {
+		return
+	}
+
+	if err := writePlaylist(_current.directory, _current.segments, _current.initSegment, false); err != nil {
+		log.Errorln("unable to write recording playlist", err)
+	}
+}
+
+// writePlaylist will write the playlist for a recording. A playlist that is
+// not final is an EVENT playlist that is still being appended to.
+func writePlaylist(directory string, segments []recordedSegment, initSegment string, final bool) error {
+	p, err := m3u8.NewMediaPlaylist(0, uint(len(segments)))
+	if err != nil {
+		return err
+	}
+
+	if initSegment != "" {
+		p.SetDefaultMap(initSegment, 0, 0)
+	}
+
+	for _, segment := range segments {
+		if err := p.Append(segment.filename, segment.duration, ""); err != nil {
+			return err
+		}
+	}
+
+	if final {
+		p.MediaType = m3u8.VOD
+		p.Close()
+	} else {
+		p.MediaType = m3u8.EVENT
+	}
+
+	return playlist.WritePlaylist(p.String(), filepath.Join(directory, playlistFilename))
+}
+
+// readPlaylistSegments will return the segments listed in a media playlist
+// along with the init segment of fMP4 segments, if any.
+func readPlaylistSegments(playlistPath string) ([]recordedSegment, string, error) {
+	f, err := os.Open(playlistPath) // nolint: gosec
+	if err != nil {
+		return nil, "", err
+	}
+	defer f.Close()
+
+	p, listType, err := m3u8.DecodeFrom(bufio.NewReader(f), false)
+	if err != nil {
+		return nil, "", err
+	}
+
+	mediaPlaylist, ok := p.(*m3u8.MediaPlaylist)
+	if listType != m3u8.MEDIA || !ok {
+		return nil, "", errors.New("not a media playlist: " + playlistPath)
+	}
+
+	initSegment := ""
+	if mediaPlaylist.Map != nil {
+		initSegment = filepath.Base(mediaPlaylist.Map.URI)
+	}
+
+	segments := []recordedSegment{}
+	for _, segment := range mediaPlaylist.GetAllSegments() {
+		segments = append(segments, recordedSegment{
+			filename: filepath.Base(segment.URI),
+			duration: segment.Duration,
+		})
+	}
+
+	return segments, initSegment, nil
+}
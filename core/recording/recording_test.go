@@ -0,0 +1,74 @@
+package recording
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecordSegments(t *testing.T) {
+	hlsDirectory := filepath.Join(t.TempDir(), "hls")
+	recordingDirectory := t.TempDir()
+
+	for _, variant := range []string{"0", "1"} {
+		if err := os.MkdirAll(filepath.Join(hlsDirectory, variant), 0o750); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	_current = &recorder{
+		id:           "test",
+		directory:    recordingDirectory,
+		variantIndex: "1",
+		pending:      make(map[string]bool),
+	}
+	defer func() { _current = nil }()
+
+	writeFile := func(path, content string) {
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Only segments of the recorded variant are kept.
+	for _, variant := range []string{"0", "1"} {
+		segmentPath := filepath.Join(hlsDirectory, variant, "stream-abc-1.ts")
+		writeFile(segmentPath, "video"+variant)
+		SegmentWritten(segmentPath)
+	}
+
+	playlistPath := filepath.Join(hlsDirectory, "1", "stream.m3u8")
+	writeFile(playlistPath, "#EXTM3U\n#EXT-X-VERSION:3\n#EXT-X-TARGETDURATION:4\n#EXT-X-MEDIA-SEQUENCE:0\n#EXTINF:4.000000,\nstream-abc-0.ts\n#EXTINF:3.500000,\nstream-abc-1.ts\n")
+	VariantPlaylistWritten(playlistPath)
+
+	if content, err := os.ReadFile(filepath.Join(recordingDirectory, "stream-abc-1.ts")); err != nil || string(content) != "video1" {
+		t.Fatalf("recorded segment = %q, %v, want %q", content, err, "video1")
+	}
+
+	// Segments that were not recorded, such as ones written before the
+	// recording started, are not part of the recording playlist.
+	segments, _, err := readPlaylistSegments(filepath.Join(recordingDirectory, playlistFilename))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(segments) != 1 || segments[0].filename != "stream-abc-1.ts" || segments[0].duration != 3.5 {
+		t.Fatalf("recorded segments = %+v, want stream-abc-1.ts at 3.5s", segments)
+	}
+
+	if err := writePlaylist(recordingDirectory, _current.segments, _current.initSegment, true); err != nil {
+		t.Fatal(err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(recordingDirectory, playlistFilename))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tag := range []string{"#EXT-X-PLAYLIST-TYPE:VOD", "#EXT-X-ENDLIST"} {
+		if !strings.Contains(string(content), tag) {
+			t.Errorf("finalized playlist is missing %s:\n%s", tag, content)
+		}
+	}
+}
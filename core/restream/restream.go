@@ -0,0 +1,332 @@
+package restream
+
+import (
+	"crypto/tls"
+	"net"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/nareix/joy5/av"
+	"github.com/nareix/joy5/format/rtmp"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/owncast/owncast/models"
+)
+
+// The inbound stream is copied, without being re-encoded, to each enabled
+// destination. Every destination is published to by its own goroutine so a
+// slow or failing destination does not hold up the others or the transcoder.
+// Packets are dropped for a destination that is unable to keep up, and it
+// resumes from the next keyframe.
+
+const (
+	// The number of packets buffered for each destination.
+	packetBufferSize = 512
+
+	dialTimeout  = 15 * time.Second
+	writeTimeout = 10 * time.Second
+
+	minBackoff = time.Second
+	maxBackoff = time.Minute
+)
+
+type destination struct {
+	config  models.RestreamDestination
+	status  models.RestreamStatus
+	packets chan av.Packet
+	stop    chan struct{}
+
+	// Set when packets were dropped or a new connection was made, as the
+	// destination must then be sent the decoder configuration and a keyframe
+	// before anything else.
+	awaitingKeyframe bool
+	timestampOffset  time.Duration
+}
+
+var (
+	_destinations = map[string]*destination{}
+	_configs      []models.RestreamDestination
+	_running      bool
+	_lock         sync.Mutex
+
+	// The most recent decoder configuration of the inbound stream.
+	_videoConfig *av.Packet
+	_audioConfig *av.Packet
+)
+
+// Start will begin copying the inbound stream to the enabled destinations.
+func Start(destinations []models.RestreamDestination) {
+	_lock.Lock()
+	_running = true
+	_videoConfig = nil
+	_audioConfig = nil
+	_lock.Unlock()
+
+	Update(destinations)
+}
+
+// Stop will disconnect from all destinations.
+func Stop() {
+	_lock.Lock()
+	defer _lock.Unlock()
+
+	_running = false
+	for id, d := range _destinations {
+		close(d.stop)
+		delete(_destinations, id)
+	}
+}
+
+// Update will set the destinations, connecting to newly enabled destinations
+// and disconnecting from removed or disabled ones if the stream is live.
+func Update(destinations []models.RestreamDestination) {
+	_lock.Lock()
+	defer _lock.Unlock()
+
+	_configs = destinations
+
+	wanted := map[string]models.RestreamDestination{}
+	if _running {
+		for _, config := range destinations {
+			if config.Enabled {
+				wanted[config.ID] = config
+			}
+		}
+	}
+
+	for id, d := range _destinations {
+		if config, ok := wanted[id]; !ok || config.URL != d.config.URL {
+			close(d.stop)
+			delete(_destinations, id)
+		} else {
+			d.config = config
+			d.status.Name = config.Name
+		}
+	}
+
+	for id, config := range wanted {
+		if _, ok := _destinations[id]; ok {
+			continue
+		}
+
+		d := &destination{
+			config:           config,
+			status:           models.RestreamStatus{ID: config.ID, Name: config.Name, State: models.RestreamStateConnecting},
+			packets:          make(chan av.Packet, packetBufferSize),
+			stop:             make(chan struct{}),
+			awaitingKeyframe: true,
+		}
+		_destinations[id] = d
+		go d.run()
+	}
+}
+
+// WritePacket will copy a packet of the inbound stream to each destination.
+func WritePacket(pkt av.Packet) {
+	_lock.Lock()
+	defer _lock.Unlock()
+
+	switch pkt.Type {
+	case av.H264DecoderConfig:
+		_videoConfig = &pkt
+	case av.AACDecoderConfig:
+		_audioConfig = &pkt
+	}
+
+	for _, d := range _destinations {
+		d.write(pkt)
+	}
+}
+
+// write will queue a packet to be sent to the destination. Must be called
+// with the lock held.
+func (d *destination) write(pkt av.Packet) {
+	if d.awaitingKeyframe {
+		if pkt.Type != av.H264 || !pkt.IsKeyFrame {
+			return
+		}
+
+		// Timestamps sent to each destination start from zero.
+		d.timestampOffset = pkt.Time
+		for _, config := range []*av.Packet{_videoConfig, _audioConfig} {
+			if config != nil && !d.enqueue(*config) {
+				return
+			}
+		}
+
+		d.awaitingKeyframe = false
+	}
+
+	if !d.enqueue(pkt) {
+		d.awaitingKeyframe = true
+	}
+}
+
+func (d *destination) enqueue(pkt av.Packet) bool {
+	pkt.Time -= d.timestampOffset
+	if pkt.Time < 0 {
+		pkt.Time = 0
+	}
+
+	select {
+	case d.packets <- pkt:
+		return true
+	default:
+		return false
+	}
+}
+
+// run will publish to the destination until it is stopped, reconnecting
+// with an increasing delay if the connection fails.
+func (d *destination) run() {
+	backoff := minBackoff
+
+	for {
+		connectedAt := time.Now()
+		err := d.publish()
+
+		select {
+		case <-d.stop:
+			return
+		default:
+		}
+
+		// A connection that stayed up for a while was not a failure to connect.
+		if time.Since(connectedAt) > maxBackoff {
+			backoff = minBackoff
+		}
+
+		log.Warnln("Restream to", d.getConfig().Name, "failed, reconnecting in", backoff, err)
+		d.setStatus(models.RestreamStateReconnecting, err)
+
+		select {
+		case <-d.stop:
+			return
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+		if backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
+
+// publish will connect to the destination and send it packets until the
+// connection fails or the destination is stopped.
+func (d *destination) publish() error {
+	d.setStatus(models.RestreamStateConnecting, nil)
+
+	config := d.getConfig()
+	conn, nc, err := dial(config.URL)
+	if err != nil {
+		return err
+	}
+	defer nc.Close()
+
+	// Start from a keyframe, dropping anything queued before connecting.
+	_lock.Lock()
+	d.awaitingKeyframe = true
+	for len(d.packets) > 0 {
+		<-d.packets
+	}
+	_lock.Unlock()
+
+	log.Infoln("Restreaming to", config.Name)
+	d.setStatus(models.RestreamStateLive, nil)
+
+	for {
+		select {
+		case <-d.stop:
+			return nil
+		case pkt := <-d.packets:
+			if err := nc.SetWriteDeadline(time.Now().Add(writeTimeout)); err != nil {
+				return err
+			}
+			if err := conn.WritePacket(pkt); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// getConfig will return the configuration of the destination, which is
+// replaced when the destinations are updated.
+func (d *destination) getConfig() models.RestreamDestination {
+	_lock.Lock()
+	defer _lock.Unlock()
+
+	return d.config
+}
+
+func (d *destination) setStatus(state models.RestreamState, err error) {
+	_lock.Lock()
+	defer _lock.Unlock()
+
+	d.status.State = state
+	switch state {
+	case models.RestreamStateLive:
+		now := time.Now()
+		d.status.ConnectedAt = &now
+		d.status.LastError = ""
+	case models.RestreamStateReconnecting:
+		d.status.ConnectedAt = nil
+		d.status.Reconnects++
+	}
+
+	if err != nil {
+		d.status.LastError = err.Error()
+	}
+}
+
+// dial will connect to an RTMP or RTMPS destination as a publisher.
+func dial(destinationURL string) (*rtmp.Conn, net.Conn, error) {
+	u, err := url.Parse(destinationURL)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	host := rtmp.UrlGetHost(u)
+	dialer := &net.Dialer{Timeout: dialTimeout}
+
+	var nc net.Conn
+	if u.Scheme == "rtmps" {
+		nc, err = tls.DialWithDialer(dialer, "tcp", host, &tls.Config{MinVersion: tls.VersionTLS12})
+	} else {
+		nc, err = dialer.Dial("tcp", host)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	conn, err := rtmp.NewClient().FromNetConn(nc, u, rtmp.PrepareWriting)
+	if err != nil {
+		_ = nc.Close()
+		return nil, nil, err
+	}
+
+	return conn, nc, nil
+}
+
+// GetStatus will return the status of each configured destination.
+func GetStatus() []models.RestreamStatus {
+	_lock.Lock()
+	defer _lock.Unlock()
+
+	statuses := make([]models.RestreamStatus, 0, len(_configs))
+	for _, config := range _configs {
+		if d, ok := _destinations[config.ID]; ok {
+			statuses = append(statuses, d.status)
+			continue
+		}
+
+		state := models.RestreamStateIdle
+		if !config.Enabled {
+			state = models.RestreamStateDisabled
+		}
+		statuses = append(statuses, models.RestreamStatus{ID: config.ID, Name: config.Name, State: state})
+	}
+
+	return statuses
+}
@@ -0,0 +1,49 @@
+package restream
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nareix/joy5/av"
+
+	"github.com/owncast/owncast/models"
+)
+
+func TestDestinationStartsFromKeyframe(t *testing.T) {
+	d := &destination{
+		config:           models.RestreamDestination{ID: "test", Enabled: true},
+		packets:          make(chan av.Packet, 4),
+		awaitingKeyframe: true,
+	}
+	_destinations = map[string]*destination{d.config.ID: d}
+	defer func() { _destinations = map[string]*destination{} }()
+
+	WritePacket(av.Packet{Type: av.H264DecoderConfig, Time: time.Second})
+	WritePacket(av.Packet{Type: av.AACDecoderConfig, Time: time.Second})
+	WritePacket(av.Packet{Type: av.H264, Time: 2 * time.Second})
+	WritePacket(av.Packet{Type: av.H264, Time: 3 * time.Second, IsKeyFrame: true})
+
+	want := []av.Packet{
+		{Type: av.H264DecoderConfig},
+		{Type: av.AACDecoderConfig},
+		{Type: av.H264, IsKeyFrame: true},
+	}
+	if len(d.packets) != len(want) {
+		t.Fatalf("got %d packets queued, want %d", len(d.packets), len(want))
+	}
+	for _, w := range want {
+		if got := <-d.packets; got.Type != w.Type || got.Time != w.Time || got.IsKeyFrame != w.IsKeyFrame {
+			t.Errorf("got packet %+v, want %+v", got, w)
+		}
+	}
+
+	// Fill the queue so the next packet is dropped.
+	WritePacket(av.Packet{Type: av.H264, Time: 4 * time.Second})
+	WritePacket(av.Packet{Type: av.H264, Time: 5 * time.Second})
+	WritePacket(av.Packet{Type: av.H264, Time: 6 * time.Second})
+	WritePacket(av.Packet{Type: av.H264, Time: 7 * time.Second})
+	WritePacket(av.Packet{Type: av.H264, Time: 8 * time.Second})
+	if !d.awaitingKeyframe {
+		t.Error("destination should wait for a keyframe after dropping packets")
+	}
+}
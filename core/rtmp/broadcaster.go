@@ -8,14 +8,20 @@ import (
 	log "github.com/sirupsen/logrus"
 )
 
-func setCurrentBroadcasterInfo(t flvio.Tag, remoteAddr string) {
+const (
+	rtmpProtocol = "RTMP"
+	srtProtocol  = "SRT"
+)
+
+func setCurrentBroadcasterInfo(t flvio.Tag, source *inboundSource) {
 	data, err := getInboundDetailsFromMetadata(t.DebugFields())
 	if err != nil {
 		log.Traceln("Unable to parse inbound broadcaster details:", err)
 	}
 
 	broadcaster := models.Broadcaster{
-		RemoteAddr: remoteAddr,
+		RemoteAddr: source.remoteAddr,
+		Protocol:   source.protocol,
 		Time:       time.Now(),
 		StreamDetails: models.InboundStreamDetails{
 			Width:          data.Width,
@@ -30,5 +36,5 @@ func setCurrentBroadcasterInfo(t flvio.Tag, remoteAddr string) {
 		},
 	}
 
-	_setBroadcaster(broadcaster)
+	setInboundSourceBroadcaster(source, broadcaster)
 }
@@ -0,0 +1,244 @@
+package rtmp
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nareix/joy5/av"
+	"github.com/nareix/joy5/format/flv"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/owncast/owncast/core/restream"
+	"github.com/owncast/owncast/models"
+)
+
+// A stream can be fed by a single inbound source at a time. A second
+// broadcaster, connecting using the backup path, is held as a hot standby
+// and takes over feeding the transcoder if the active source goes away.
+// The stream itself stays online while this takes place. Each channel has
+// its own active and standby sources.
+
+const (
+	livePathPrefix   = "/live/"
+	backupPathPrefix = "/backup/"
+
+	// Gap inserted between the last packet of the previous source and the
+	// first packet of the new source when failing over.
+	failoverTimestampGap = 40 * time.Millisecond
+)
+
+var errInboundSourceRemoved = errors.New("inbound source is no longer in use")
+
+// inboundSource is a single connected broadcaster, either RTMP or SRT.
+type inboundSource struct {
+	conn        io.Closer
+	broadcaster *models.Broadcaster
+
+	// The most recent decoder configuration sent by this source. It is
+	// replayed when this source takes over from another one.
+	videoConfig *av.Packet
+	audioConfig *av.Packet
+
+	remoteAddr string
+	protocol   string
+	channelID  string
+	isBackup   bool
+}
+
+// channelIngest is the inbound stream of a single channel.
+type channelIngest struct {
+	activeSource  *inboundSource
+	standbySource *inboundSource
+
+	pipe  *io.PipeWriter
+	muxer *flv.Muxer
+
+	// Timestamps of a new source are rebased to continue on from the
+	// previous source so the transcoder sees a single continuous stream.
+	timestampOffset  time.Duration
+	lastPacketTime   time.Duration
+	awaitingKeyframe bool
+}
+
+var (
+	// The ingest of each channel with a connected stream, keyed by channel id.
+	_ingests    = map[string]*channelIngest{}
+	_sourceLock sync.Mutex
+)
+
+// parseStreamKeyPath will return the path in the form of /live/<key> for a
+// path sent by a broadcaster, and if it was sent using the backup path of
+// /backup/<key>.
+func parseStreamKeyPath(path string) (string, bool) {
+	if strings.HasPrefix(path, backupPathPrefix) {
+		return livePathPrefix + strings.TrimPrefix(path, backupPathPrefix), true
+	}
+
+	return path, false
+}
+
+// addInboundSource will make an authorized source either the one feeding
+// the transcoder or the hot standby. Returns false if the source can not be
+// used and should be disconnected.
+func addInboundSource(source *inboundSource) bool {
+	_sourceLock.Lock()
+
+	if ingest, ok := _ingests[source.channelID]; ok {
+		defer _sourceLock.Unlock()
+
+		// A backup may stand by for a running stream. Once a backup has taken
+		// over, the original broadcaster is able to reconnect as its standby.
+		canStandby := source.isBackup || (ingest.activeSource != nil && ingest.activeSource.isBackup)
+		if ingest.standbySource != nil || !canStandby {
+			log.Errorln("stream already running; can not overtake an existing stream from", source.remoteAddr)
+			return false
+		}
+
+		log.Infoln("Inbound", source.protocol, "stream from", source.remoteAddr, "is standing by as a backup")
+		ingest.standbySource = source
+		return true
+	}
+
+	out, in := io.Pipe()
+	_ingests[source.channelID] = &channelIngest{
+		activeSource: source,
+		pipe:         in,
+		muxer:        flv.NewMuxer(in),
+	}
+	_sourceLock.Unlock()
+
+	log.Infoln("Inbound", source.protocol, "stream connected from", source.remoteAddr)
+	_setStreamAsConnected(source.channelID, out)
+
+	return true
+}
+
+// writeInboundPacket will write a packet from a source to the transcoder if
+// it is the active source. Packets from the standby are only inspected.
+func writeInboundPacket(source *inboundSource, pkt av.Packet) error {
+	_sourceLock.Lock()
+	defer _sourceLock.Unlock()
+
+	switch pkt.Type {
+	case av.H264DecoderConfig:
+		source.videoConfig = &pkt
+	case av.AACDecoderConfig:
+		source.audioConfig = &pkt
+	}
+
+	ingest, ok := _ingests[source.channelID]
+	if ok && source == ingest.standbySource {
+		return nil
+	}
+
+	if !ok || source != ingest.activeSource {
+		return errInboundSourceRemoved
+	}
+
+	// Restreaming is only available to the default channel.
+	isDefaultChannel := source.channelID == models.DefaultChannelID
+
+	if ingest.awaitingKeyframe {
+		// Nothing is written after a failover until the new source sends a
+		// keyframe the transcoder is able to start decoding from.
+		if pkt.Type != av.H264 || !pkt.IsKeyFrame {
+			return nil
+		}
+
+		ingest.awaitingKeyframe = false
+		ingest.timestampOffset = ingest.lastPacketTime + failoverTimestampGap - pkt.Time
+
+		for _, config := range []*av.Packet{source.videoConfig, source.audioConfig} {
+			if config == nil {
+				continue
+			}
+
+			configPacket := *config
+			configPacket.Time = pkt.Time + ingest.timestampOffset
+			if err := ingest.muxer.WritePacket(configPacket); err != nil {
+				return err
+			}
+			if isDefaultChannel {
+				restream.WritePacket(configPacket)
+			}
+		}
+	}
+
+	pkt.Time += ingest.timestampOffset
+	if pkt.Time > ingest.lastPacketTime {
+		ingest.lastPacketTime = pkt.Time
+	}
+
+	if err := ingest.muxer.WritePacket(pkt); err != nil {
+		return err
+	}
+
+	// Copy exactly what the transcoder receives to any restream destinations.
+	if isDefaultChannel {
+		restream.WritePacket(pkt)
+	}
+
+	return nil
+}
+
+// setInboundSourceBroadcaster will store the details of the broadcaster for a
+// source, making them the current broadcaster if it is the active source.
+func setInboundSourceBroadcaster(source *inboundSource, broadcaster models.Broadcaster) {
+	_sourceLock.Lock()
+	source.broadcaster = &broadcaster
+	ingest, ok := _ingests[source.channelID]
+	isActive := ok && source == ingest.activeSource
+	_sourceLock.Unlock()
+
+	if isActive {
+		_setBroadcaster(source.channelID, broadcaster)
+	}
+}
+
+// removeInboundSource is fired when a source disconnects or stops sending
+// packets. If the active source is removed the standby takes over, otherwise
+// the stream is disconnected.
+func removeInboundSource(source *inboundSource) {
+	_sourceLock.Lock()
+
+	_ = source.conn.Close()
+
+	ingest, ok := _ingests[source.channelID]
+	if !ok {
+		_sourceLock.Unlock()
+		return
+	}
+
+	if source == ingest.standbySource {
+		log.Infoln("Standby", source.protocol, "stream from", source.remoteAddr, "disconnected.")
+		ingest.standbySource = nil
+		_sourceLock.Unlock()
+		return
+	}
+
+	if source != ingest.activeSource {
+		_sourceLock.Unlock()
+		return
+	}
+
+	if ingest.standbySource != nil {
+		failover := ingest.standbySource
+		log.Warnln("Inbound stream from", source.remoteAddr, "stopped. Failing over to the", failover.protocol, "stream from", failover.remoteAddr)
+		ingest.activeSource = failover
+		ingest.standbySource = nil
+		ingest.awaitingKeyframe = true
+		broadcaster := failover.broadcaster
+		_sourceLock.Unlock()
+
+		if broadcaster != nil {
+			_setBroadcaster(source.channelID, *broadcaster)
+		}
+		return
+	}
+
+	_sourceLock.Unlock()
+	handleDisconnect(source.channelID)
+}
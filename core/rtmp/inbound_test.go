@@ -0,0 +1,146 @@
+package rtmp
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/nareix/joy5/av"
+	"github.com/nareix/joy5/format/flv"
+	"github.com/owncast/owncast/models"
+)
+
+type nopCloser struct{}
+
+func (nopCloser) Close() error { return nil }
+
+func TestInboundSourceFailover(t *testing.T) {
+	received := make(chan av.Packet, 16)
+	connected := 0
+
+	_setBroadcaster = func(string, models.Broadcaster) {}
+	_setStreamAsConnected = func(channelID string, out *io.PipeReader) {
+		connected++
+		go func() {
+			demuxer := flv.NewDemuxer(out)
+			for {
+				pkt, err := demuxer.ReadPacket()
+				if err != nil {
+					close(received)
+					return
+				}
+				received <- pkt
+			}
+		}()
+	}
+
+	primary := &inboundSource{conn: nopCloser{}, protocol: rtmpProtocol}
+	backup := &inboundSource{conn: nopCloser{}, protocol: rtmpProtocol, isBackup: true}
+	other := &inboundSource{conn: nopCloser{}, protocol: rtmpProtocol}
+
+	if !addInboundSource(primary) {
+		t.Fatal("primary source should be accepted")
+	}
+	if !addInboundSource(backup) {
+		t.Fatal("backup source should be accepted as standby")
+	}
+	if addInboundSource(other) {
+		t.Fatal("a second non-backup source should be rejected")
+	}
+
+	writePacket := func(source *inboundSource, pkt av.Packet) {
+		if err := writeInboundPacket(source, pkt); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	writePacket(primary, av.Packet{Type: av.H264, IsKeyFrame: true, Time: 5 * time.Second, Data: []byte{1}})
+
+	// Packets from the standby are not written.
+	writePacket(backup, av.Packet{Type: av.H264DecoderConfig, Data: []byte{2}})
+	writePacket(backup, av.Packet{Type: av.H264, IsKeyFrame: true, Time: time.Second, Data: []byte{3}})
+
+	removeInboundSource(primary)
+
+	if ingest, ok := _ingests[models.DefaultChannelID]; !ok || ingest.activeSource != backup {
+		t.Fatal("backup should have taken over without disconnecting the stream")
+	}
+
+	if err := writeInboundPacket(primary, av.Packet{Type: av.H264, Time: 6 * time.Second}); err != errInboundSourceRemoved {
+		t.Errorf("writing from a removed source = %v, want %v", err, errInboundSourceRemoved)
+	}
+
+	// The new source is only used once it sends a keyframe.
+	writePacket(backup, av.Packet{Type: av.H264, Time: 2 * time.Second, Data: []byte{4}})
+	writePacket(backup, av.Packet{Type: av.H264, IsKeyFrame: true, Time: 3 * time.Second, Data: []byte{5}})
+
+	handleDisconnect(models.DefaultChannelID)
+
+	packets := []av.Packet{}
+	for pkt := range received {
+		packets = append(packets, pkt)
+	}
+
+	if connected != 1 {
+		t.Errorf("stream connected %d times, want 1", connected)
+	}
+
+	if len(packets) != 3 {
+		t.Fatalf("received %d packets, want 3", len(packets))
+	}
+
+	if packets[1].Type != av.H264DecoderConfig {
+		t.Errorf("expected the backup decoder config to be replayed, got %s", packets[1])
+	}
+
+	want := 5*time.Second + failoverTimestampGap
+	if packets[2].Time != want || packets[2].Data[0] != 5 {
+		t.Errorf("first backup packet = %s, want keyframe at %s", packets[2], want)
+	}
+}
+
+func TestInboundSourcesPerChannel(t *testing.T) {
+	connected := map[string]int{}
+
+	_setBroadcaster = func(string, models.Broadcaster) {}
+	_setStreamAsConnected = func(channelID string, out *io.PipeReader) {
+		connected[channelID]++
+		go func() { _, _ = io.Copy(io.Discard, out) }()
+	}
+
+	first := &inboundSource{conn: nopCloser{}, protocol: rtmpProtocol}
+	second := &inboundSource{conn: nopCloser{}, protocol: rtmpProtocol, channelID: "second"}
+	overtake := &inboundSource{conn: nopCloser{}, protocol: srtProtocol, channelID: "second"}
+
+	if !addInboundSource(first) || !addInboundSource(second) {
+		t.Fatal("each channel should accept its own source")
+	}
+	if addInboundSource(overtake) {
+		t.Fatal("a second non-backup source for a channel should be rejected")
+	}
+
+	removeInboundSource(second)
+
+	if _, ok := _ingests["second"]; ok {
+		t.Error("the channel should have been disconnected")
+	}
+	if ingest, ok := _ingests[models.DefaultChannelID]; !ok || ingest.activeSource != first {
+		t.Error("disconnecting a channel should not affect another")
+	}
+
+	handleDisconnect(models.DefaultChannelID)
+
+	if connected[models.DefaultChannelID] != 1 || connected["second"] != 1 {
+		t.Errorf("channels connected %v, want each once", connected)
+	}
+}
+
+func Test_parseStreamKeyPath(t *testing.T) {
+	if path, isBackup := parseStreamKeyPath("/live/abc123"); path != "/live/abc123" || isBackup {
+		t.Errorf("parseStreamKeyPath() = %v, %v, want /live/abc123, false", path, isBackup)
+	}
+
+	if path, isBackup := parseStreamKeyPath("/backup/abc123"); path != "/live/abc123" || !isBackup {
+		t.Errorf("parseStreamKeyPath() = %v, %v, want /live/abc123, true", path, isBackup)
+	}
+}
@@ -6,7 +6,6 @@ import (
 	"net"
 	"time"
 
-	"github.com/nareix/joy5/format/flv"
 	"github.com/nareix/joy5/format/flv/flvio"
 	log "github.com/sirupsen/logrus"
 
@@ -16,20 +15,13 @@ import (
 	"github.com/owncast/owncast/models"
 )
 
-var _hasInboundRTMPConnection = false
-
-var (
-	_pipe           *io.PipeWriter
-	_rtmpConnection net.Conn
-)
-
 var (
-	_setStreamAsConnected func(*io.PipeReader)
-	_setBroadcaster       func(models.Broadcaster)
+	_setStreamAsConnected func(string, *io.PipeReader)
+	_setBroadcaster       func(string, models.Broadcaster)
 )
 
 // Start starts the rtmp service, listening on specified RTMP port.
-func Start(setStreamAsConnected func(*io.PipeReader), setBroadcaster func(models.Broadcaster)) {
+func Start(setStreamAsConnected func(string, *io.PipeReader), setBroadcaster func(string, models.Broadcaster)) {
 	_setStreamAsConnected = setStreamAsConnected
 	_setBroadcaster = setBroadcaster
 
@@ -65,57 +57,37 @@ func Start(setStreamAsConnected func(*io.PipeReader), setBroadcaster func(models
 
 // HandleConn is fired when an inbound RTMP connection takes place.
 func HandleConn(c *rtmp.Conn, nc net.Conn) {
+	keyPath, isBackup := parseStreamKeyPath(c.URL.Path)
+	streamKey, isValid := getStreamKeyForPath(keyPath)
+	source := &inboundSource{
+		conn:       nc,
+		remoteAddr: nc.RemoteAddr().String(),
+		protocol:   rtmpProtocol,
+		channelID:  streamKey.Channel,
+		isBackup:   isBackup,
+	}
+
 	c.LogTagEvent = func(isRead bool, t flvio.Tag) {
 		if t.Type == flvio.TAG_AMF0 {
 			log.Tracef("%+v\n", t.DebugFields())
-			setCurrentBroadcasterInfo(t, nc.RemoteAddr().String())
+			setCurrentBroadcasterInfo(t, source)
 		}
 	}
 
-	if _hasInboundRTMPConnection {
-		log.Errorln("stream already running; can not overtake an existing stream from", nc.RemoteAddr().String())
+	if !isValid {
+		log.Errorln("invalid streaming key; rejecting incoming stream from", nc.RemoteAddr().String())
 		_ = nc.Close()
 		return
 	}
 
-	accessGranted := false
-	validStreamingKeys := data.GetStreamKeys()
-
-	// If a stream key override was specified then use that instead.
-	if config.TemporaryStreamKey != "" {
-		validStreamingKeys = []models.StreamKey{{Key: config.TemporaryStreamKey}}
-	}
-
-	for _, key := range validStreamingKeys {
-		if secretMatch(key.Key, c.URL.Path) {
-			accessGranted = true
-			break
-		}
-	}
-
-	if !accessGranted {
-		log.Errorln("invalid streaming key; rejecting incoming stream from", nc.RemoteAddr().String())
+	if !addInboundSource(source) {
 		_ = nc.Close()
 		return
 	}
 
-	rtmpOut, rtmpIn := io.Pipe()
-	_pipe = rtmpIn
-	log.Infoln("Inbound stream connected from", nc.RemoteAddr().String())
-	_setStreamAsConnected(rtmpOut)
-
-	_hasInboundRTMPConnection = true
-	_rtmpConnection = nc
-
-	w := flv.NewMuxer(rtmpIn)
-
 	for {
-		if !_hasInboundRTMPConnection {
-			break
-		}
-
 		// If we don't get a readable packet in 10 seconds give up and disconnect
-		if err := _rtmpConnection.SetReadDeadline(time.Now().Add(10 * time.Second)); err != nil {
+		if err := nc.SetReadDeadline(time.Now().Add(10 * time.Second)); err != nil {
 			log.Debugln(err)
 		}
 
@@ -123,42 +95,87 @@ func HandleConn(c *rtmp.Conn, nc net.Conn) {
 
 		// Broadcaster disconnected
 		if err == io.EOF {
-			handleDisconnect(nc)
+			removeInboundSource(source)
 			return
 		}
 
-		// Read timeout.  Disconnect.
+		// Read timeout.  Disconnect, or fail over to a backup if one is standing by.
 		if neterr, ok := err.(net.Error); ok && neterr.Timeout() {
-			log.Debugln("Timeout reading the inbound stream from the broadcaster.  Assuming that they disconnected and ending the stream.")
-			handleDisconnect(nc)
+			log.Debugln("Timeout reading the inbound stream from the broadcaster.  Assuming that they disconnected.")
+			removeInboundSource(source)
 			return
 		}
 
-		if err := w.WritePacket(pkt); err != nil {
-			log.Errorln("unable to write rtmp packet", err)
-			handleDisconnect(nc)
+		if err := writeInboundPacket(source, pkt); err != nil {
+			if err != errInboundSourceRemoved {
+				log.Errorln("unable to write rtmp packet", err)
+			}
+			removeInboundSource(source)
 			return
 		}
 	}
 }
 
-func handleDisconnect(conn net.Conn) {
-	if !_hasInboundRTMPConnection {
-		return
+// getStreamKeyForPath will return the configured stream key contained in
+// the supplied path, in the form of /live/<key>, and if one was found. Keys
+// assigned to a channel that no longer exists are not valid.
+func getStreamKeyForPath(path string) (models.StreamKey, bool) {
+	validStreamingKeys := data.GetStreamKeys()
+
+	// If a stream key override was specified then use that instead.
+	if config.TemporaryStreamKey != "" {
+		validStreamingKeys = []models.StreamKey{{Key: config.TemporaryStreamKey}}
 	}
 
-	log.Infoln("Inbound stream disconnected.")
-	_ = conn.Close()
-	_ = _pipe.Close()
-	_hasInboundRTMPConnection = false
+	for _, key := range validStreamingKeys {
+		if !secretMatch(key.Key, path) {
+			continue
+		}
+
+		if key.Channel != models.DefaultChannelID {
+			if _, ok := data.GetChannel(key.Channel); !ok {
+				log.Warnln("stream key is assigned to channel", key.Channel, "which does not exist")
+				return models.StreamKey{}, false
+			}
+		}
+
+		return key, true
+	}
+
+	return models.StreamKey{}, false
 }
 
-// Disconnect will force disconnect the current inbound RTMP connection.
-func Disconnect() {
-	if _rtmpConnection == nil {
+func handleDisconnect(channelID string) {
+	_sourceLock.Lock()
+	defer _sourceLock.Unlock()
+
+	ingest, ok := _ingests[channelID]
+	if !ok {
 		return
 	}
 
+	log.Infoln("Inbound stream disconnected.")
+	for _, source := range []*inboundSource{ingest.activeSource, ingest.standbySource} {
+		if source != nil {
+			_ = source.conn.Close()
+		}
+	}
+	_ = ingest.pipe.Close()
+	delete(_ingests, channelID)
+}
+
+// Disconnect will force disconnect the current inbound RTMP or SRT
+// connection of a channel, along with any backup standing by.
+func Disconnect(channelID string) {
 	log.Traceln("Inbound stream disconnect requested.")
-	handleDisconnect(_rtmpConnection)
+	handleDisconnect(channelID)
+}
+
+// IsChannelConnected will return if a channel has an inbound stream.
+func IsChannelConnected(channelID string) bool {
+	_sourceLock.Lock()
+	defer _sourceLock.Unlock()
+
+	_, ok := _ingests[channelID]
+	return ok
 }
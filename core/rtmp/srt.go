@@ -0,0 +1,337 @@
+package rtmp
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os/exec"
+	"sync"
+	"time"
+
+	"github.com/nareix/joy5/format/flv"
+	"github.com/nareix/joy5/format/flv/flvio"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/models"
+	"github.com/owncast/owncast/utils"
+)
+
+// SRT ingest is handled by placing a small UDP relay in front of an ffmpeg
+// SRT listener. The relay inspects the handshake so the stream id can be
+// validated against the configured stream keys before any video is accepted.
+// ffmpeg then remuxes the inbound stream into FLV so it can be fed into the
+// same pipe as an inbound RTMP stream.
+
+const (
+	srtMaxPacketSize = 1500
+
+	// A primary broadcaster and a backup standing by, for each channel.
+	srtSessionsPerChannel = 2
+
+	// If we don't get a packet in this amount of time give up and disconnect.
+	srtIdleTimeout = 10 * time.Second
+)
+
+var (
+	_srtListener *net.UDPConn
+	_srtSessions = map[string]*srtSession{}
+	_srtLock     sync.Mutex
+)
+
+// srtSession is a single inbound SRT connection being relayed to ffmpeg.
+type srtSession struct {
+	lastPacketTime time.Time
+	remoteAddr     *net.UDPAddr
+	upstream       *net.UDPConn
+	command        *exec.Cmd
+	source         *inboundSource
+	channelID      string
+	closeOnce      sync.Once
+	authorized     bool
+	isBackup       bool
+}
+
+// StartSRT starts the SRT service, listening on the specified UDP port.
+func StartSRT(setStreamAsConnected func(string, *io.PipeReader), setBroadcaster func(string, models.Broadcaster)) {
+	_setStreamAsConnected = setStreamAsConnected
+	_setBroadcaster = setBroadcaster
+
+	port := data.GetSRTPortNumber()
+
+	var err error
+	if _srtListener, err = net.ListenUDP("udp", &net.UDPAddr{Port: port}); err != nil {
+		log.Fatal(err)
+	}
+
+	log.Tracef("SRT server is listening for incoming stream on port: %d", port)
+
+	go watchSRTSessions()
+
+	buffer := make([]byte, srtMaxPacketSize)
+	for {
+		n, remoteAddr, err := _srtListener.ReadFromUDP(buffer)
+		if err != nil {
+			time.Sleep(time.Second)
+			continue
+		}
+
+		handleSRTPacket(buffer[:n], remoteAddr)
+	}
+}
+
+// handleSRTPacket is fired for every inbound packet sent to the SRT port.
+func handleSRTPacket(packet []byte, remoteAddr *net.UDPAddr) {
+	_srtLock.Lock()
+	defer _srtLock.Unlock()
+
+	session := _srtSessions[remoteAddr.String()]
+
+	if session == nil {
+		// Only a new handshake is able to start a new session.
+		if !isSRTHandshake(packet) || getSRTHandshakeType(packet) != srtHandshakeInduction {
+			return
+		}
+
+		if len(_srtSessions) >= srtSessionsPerChannel*(len(data.GetChannels())+1) {
+			log.Errorln("stream already running; can not overtake an existing stream from", remoteAddr.String())
+			return
+		}
+
+		var err error
+		if session, err = newSRTSession(remoteAddr); err != nil {
+			log.Errorln("unable to start SRT session", err)
+			return
+		}
+		_srtSessions[remoteAddr.String()] = session
+	}
+
+	session.lastPacketTime = time.Now()
+
+	if !session.authorized {
+		// Nothing but the handshake makes it to ffmpeg until the stream key
+		// has been validated.
+		if !isSRTHandshake(packet) {
+			return
+		}
+
+		if getSRTHandshakeType(packet) == srtHandshakeConclusion {
+			streamID, _ := getSRTStreamID(packet)
+			keyPath, isBackup := parseStreamKeyPath(srtStreamIDToPath(streamID))
+			streamKey, isValid := getStreamKeyForPath(keyPath)
+			if !isValid {
+				log.Errorln("invalid streaming key; rejecting incoming stream from", remoteAddr.String())
+				_, _ = _srtListener.WriteToUDP(newSRTHandshakeRejection(packet, srtRejectionForbidden), remoteAddr)
+				go session.Close()
+				return
+			}
+
+			session.authorized = true
+			session.channelID = streamKey.Channel
+			session.isBackup = isBackup
+		}
+	}
+
+	if _, err := session.upstream.Write(packet); err != nil {
+		log.Traceln("unable to relay SRT packet", err)
+	}
+}
+
+// newSRTSession will start a new ffmpeg SRT listener and relay for an
+// inbound connection.
+func newSRTSession(remoteAddr *net.UDPAddr) (*srtSession, error) {
+	port, err := getFreeUDPPort()
+	if err != nil {
+		return nil, err
+	}
+
+	upstream, err := net.DialUDP("udp", nil, &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1), Port: port})
+	if err != nil {
+		return nil, err
+	}
+
+	ffmpegPath := utils.ValidatedFfmpegPath(data.GetFfMpegPath())
+	command := exec.Command(ffmpegPath, //nolint:gosec
+		"-hide_banner",
+		"-loglevel", "error",
+		"-i", fmt.Sprintf("srt://127.0.0.1:%d?mode=listener&transtype=live", port),
+		"-map", "0:v:0",
+		"-map", "0:a:0?",
+		"-c", "copy",
+		"-f", "flv",
+		"pipe:1",
+	)
+
+	stdout, err := command.StdoutPipe()
+	if err != nil {
+		_ = upstream.Close()
+		return nil, err
+	}
+
+	stderr, err := command.StderrPipe()
+	if err != nil {
+		_ = upstream.Close()
+		return nil, err
+	}
+
+	if err := command.Start(); err != nil {
+		_ = upstream.Close()
+		return nil, err
+	}
+
+	session := &srtSession{
+		remoteAddr:     remoteAddr,
+		upstream:       upstream,
+		command:        command,
+		lastPacketTime: time.Now(),
+	}
+
+	go func() {
+		scanner := bufio.NewScanner(stderr)
+		for scanner.Scan() {
+			log.Debugln("SRT:", scanner.Text())
+		}
+	}()
+
+	go session.relayResponses()
+	go session.handleStream(stdout)
+
+	return session, nil
+}
+
+// relayResponses sends the packets ffmpeg writes back to the broadcaster.
+func (s *srtSession) relayResponses() {
+	buffer := make([]byte, srtMaxPacketSize)
+	for {
+		n, err := s.upstream.Read(buffer)
+		if errors.Is(err, net.ErrClosed) {
+			return
+		} else if err != nil {
+			// ffmpeg may not be listening quite yet, the broadcaster will
+			// retry its handshake.
+			continue
+		}
+
+		if _, err := _srtListener.WriteToUDP(buffer[:n], s.remoteAddr); err != nil {
+			log.Traceln("unable to relay SRT response", err)
+		}
+	}
+}
+
+// handleStream reads the remuxed stream from ffmpeg and feeds it into
+// the pipe used by the transcoder.
+func (s *srtSession) handleStream(stdout io.Reader) {
+	defer s.Close()
+
+	demuxer := flv.NewDemuxer(stdout)
+
+	source := &inboundSource{
+		conn:       s,
+		remoteAddr: s.remoteAddr.String(),
+		protocol:   srtProtocol,
+	}
+
+	readTag := func() (flvio.Tag, error) {
+		tag, err := demuxer.ReadTag()
+		if err == nil && tag.Type == flvio.TAG_AMF0 {
+			log.Tracef("%+v\n", tag.DebugFields())
+			setCurrentBroadcasterInfo(tag, source)
+		}
+		return tag, err
+	}
+
+	pkt, err := flv.ReadPacket(readTag)
+	if err != nil {
+		return
+	}
+
+	// The stream only starts once the handshake has been authorized.
+	_srtLock.Lock()
+	source.isBackup = s.isBackup
+	source.channelID = s.channelID
+	_srtLock.Unlock()
+
+	if !addInboundSource(source) {
+		return
+	}
+
+	_srtLock.Lock()
+	s.source = source
+	_srtLock.Unlock()
+
+	for {
+		if err := writeInboundPacket(source, pkt); err != nil {
+			if err != errInboundSourceRemoved {
+				log.Errorln("unable to write srt packet", err)
+			}
+			removeInboundSource(source)
+			return
+		}
+
+		// Broadcaster disconnected or timed out.
+		if pkt, err = flv.ReadPacket(readTag); err != nil {
+			removeInboundSource(source)
+			return
+		}
+	}
+}
+
+// Close will stop ffmpeg and the relay for this session.
+func (s *srtSession) Close() error {
+	s.closeOnce.Do(func() {
+		if s.command.Process != nil {
+			_ = s.command.Process.Kill()
+			_ = s.command.Wait()
+		}
+		_ = s.upstream.Close()
+
+		_srtLock.Lock()
+		if _srtSessions[s.remoteAddr.String()] == s {
+			delete(_srtSessions, s.remoteAddr.String())
+		}
+		_srtLock.Unlock()
+	})
+
+	return nil
+}
+
+// watchSRTSessions will close sessions that stop sending packets,
+// including ones that never complete their handshake.
+func watchSRTSessions() {
+	for range time.Tick(time.Second) {
+		_srtLock.Lock()
+		idle := []*srtSession{}
+		for _, session := range _srtSessions {
+			if time.Since(session.lastPacketTime) > srtIdleTimeout {
+				idle = append(idle, session)
+			}
+		}
+		_srtLock.Unlock()
+
+		for _, session := range idle {
+			_srtLock.Lock()
+			source := session.source
+			_srtLock.Unlock()
+
+			if source == nil {
+				_ = session.Close()
+				continue
+			}
+
+			log.Debugln("Timeout reading the inbound SRT stream from the broadcaster.  Assuming that they disconnected.")
+			removeInboundSource(source)
+		}
+	}
+}
+
+func getFreeUDPPort() (int, error) {
+	conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 0})
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+
+	return conn.LocalAddr().(*net.UDPAddr).Port, nil
+}
@@ -0,0 +1,134 @@
+package rtmp
+
+import (
+	"encoding/binary"
+	"strings"
+)
+
+// SRT packet layout. See the SRT protocol specification:
+// https://datatracker.ietf.org/doc/html/draft-sharabayko-srt
+const (
+	srtHeaderLength       = 16
+	srtHandshakeCIFLength = 48
+
+	srtHandshakeTypeOffset   = srtHeaderLength + 20
+	srtHandshakeSocketOffset = srtHeaderLength + 24
+	srtHandshakeExtOffset    = srtHeaderLength + srtHandshakeCIFLength
+
+	srtControlTypeHandshake = 0x0000
+
+	srtHandshakeInduction  = 0x00000001
+	srtHandshakeConclusion = 0xFFFFFFFF
+
+	// Rejected handshakes carry the rejection reason offset by this value.
+	srtHandshakeRejectionBase = 1000
+	// SRT_REJX_FORBIDDEN, the user space equivalent of HTTP 403.
+	srtRejectionForbidden = 1403
+
+	srtExtensionStreamID = 5
+)
+
+// isSRTHandshake will return if the packet is an SRT handshake control packet.
+func isSRTHandshake(packet []byte) bool {
+	if len(packet) < srtHandshakeExtOffset {
+		return false
+	}
+
+	isControl := packet[0]&0x80 != 0
+	controlType := binary.BigEndian.Uint16(packet[0:2]) & 0x7FFF
+
+	return isControl && controlType == srtControlTypeHandshake
+}
+
+// getSRTHandshakeType will return the type of a handshake packet, such as
+// induction or conclusion.
+func getSRTHandshakeType(packet []byte) uint32 {
+	return binary.BigEndian.Uint32(packet[srtHandshakeTypeOffset:])
+}
+
+// getSRTStreamID will return the stream id sent by the caller as part of the
+// handshake conclusion, if one exists.
+func getSRTStreamID(packet []byte) (string, bool) {
+	offset := srtHandshakeExtOffset
+
+	for offset+4 <= len(packet) {
+		extensionType := binary.BigEndian.Uint16(packet[offset:])
+		extensionLength := int(binary.BigEndian.Uint16(packet[offset+2:])) * 4
+		offset += 4
+
+		if offset+extensionLength > len(packet) {
+			return "", false
+		}
+
+		if extensionType == srtExtensionStreamID {
+			return decodeSRTString(packet[offset : offset+extensionLength]), true
+		}
+
+		offset += extensionLength
+	}
+
+	return "", false
+}
+
+// decodeSRTString will decode a string sent in a handshake extension.
+// Strings are sent as a series of 32bit words, each of them in little
+// endian byte order, padded with null bytes.
+func decodeSRTString(content []byte) string {
+	decoded := make([]byte, len(content))
+	for i := 0; i+4 <= len(content); i += 4 {
+		decoded[i] = content[i+3]
+		decoded[i+1] = content[i+2]
+		decoded[i+2] = content[i+1]
+		decoded[i+3] = content[i]
+	}
+
+	return strings.TrimRight(string(decoded), "\x00")
+}
+
+// newSRTHandshakeRejection will return a handshake response to the caller
+// that rejects the handshake request with the provided reason.
+func newSRTHandshakeRejection(request []byte, reason uint32) []byte {
+	rejection := make([]byte, srtHandshakeExtOffset)
+	copy(rejection, request[:srtHandshakeExtOffset])
+
+	// Address the response to the socket of the caller.
+	copy(rejection[12:16], request[srtHandshakeSocketOffset:srtHandshakeSocketOffset+4])
+
+	// Clear the extension flags as no extensions are included.
+	binary.BigEndian.PutUint16(rejection[srtHeaderLength+6:], 0)
+
+	binary.BigEndian.PutUint32(rejection[srtHandshakeTypeOffset:], srtHandshakeRejectionBase+reason)
+
+	return rejection
+}
+
+// srtStreamIDToPath will convert a SRT stream id into a path in the form of
+// /live/<key> so it can be validated the same way as an RTMP stream key.
+// Plain keys, live/<key> and the SRT access control syntax of
+// #!::r=live/<key>,m=publish are supported. A backup broadcaster uses
+// backup/<key> and is returned as /backup/<key>.
+func srtStreamIDToPath(streamID string) string {
+	const accessControlPrefix = "#!::"
+
+	if strings.HasPrefix(streamID, accessControlPrefix) {
+		resource := ""
+		for _, pair := range strings.Split(strings.TrimPrefix(streamID, accessControlPrefix), ",") {
+			if key, value, found := strings.Cut(pair, "="); found && key == "r" {
+				resource = value
+			}
+		}
+		streamID = resource
+	}
+
+	for _, prefix := range []string{livePathPrefix, backupPathPrefix} {
+		if strings.HasPrefix(streamID, prefix) {
+			return streamID
+		}
+
+		if strings.HasPrefix(streamID, strings.TrimPrefix(prefix, "/")) {
+			return "/" + streamID
+		}
+	}
+
+	return "/live/" + streamID
+}
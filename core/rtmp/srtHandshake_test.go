@@ -0,0 +1,91 @@
+package rtmp
+
+import (
+	"encoding/binary"
+	"testing"
+)
+
+// makeSRTConclusion builds a handshake conclusion packet with a stream id extension.
+func makeSRTConclusion(streamID string) []byte {
+	packet := make([]byte, srtHandshakeExtOffset)
+	binary.BigEndian.PutUint16(packet[0:2], 0x8000|srtControlTypeHandshake)
+	binary.BigEndian.PutUint32(packet[srtHandshakeTypeOffset:], srtHandshakeConclusion)
+	binary.BigEndian.PutUint32(packet[srtHandshakeSocketOffset:], 0x1234)
+
+	content := []byte(streamID)
+	for len(content)%4 != 0 {
+		content = append(content, 0)
+	}
+
+	// Each 32bit word of a string is sent in little endian byte order.
+	for i := 0; i < len(content); i += 4 {
+		content[i], content[i+1], content[i+2], content[i+3] = content[i+3], content[i+2], content[i+1], content[i]
+	}
+
+	extension := make([]byte, 4)
+	binary.BigEndian.PutUint16(extension[0:2], srtExtensionStreamID)
+	binary.BigEndian.PutUint16(extension[2:4], uint16(len(content)/4))
+
+	packet = append(packet, extension...)
+	return append(packet, content...)
+}
+
+func TestSRTHandshakeStreamID(t *testing.T) {
+	packet := makeSRTConclusion("live/abc123")
+
+	if !isSRTHandshake(packet) {
+		t.Fatal("expected packet to be a handshake")
+	}
+
+	if handshakeType := getSRTHandshakeType(packet); handshakeType != srtHandshakeConclusion {
+		t.Errorf("getSRTHandshakeType() = %x, want %x", handshakeType, srtHandshakeConclusion)
+	}
+
+	streamID, found := getSRTStreamID(packet)
+	if !found || streamID != "live/abc123" {
+		t.Errorf("getSRTStreamID() = %q, %v, want %q", streamID, found, "live/abc123")
+	}
+
+	rejection := newSRTHandshakeRejection(packet, srtRejectionForbidden)
+	if handshakeType := getSRTHandshakeType(rejection); handshakeType != srtHandshakeRejectionBase+srtRejectionForbidden {
+		t.Errorf("rejection handshake type = %d, want %d", handshakeType, srtHandshakeRejectionBase+srtRejectionForbidden)
+	}
+
+	if socketID := binary.BigEndian.Uint32(rejection[12:16]); socketID != 0x1234 {
+		t.Errorf("rejection destination socket = %x, want %x", socketID, 0x1234)
+	}
+}
+
+func TestSRTDataPacketIsNotHandshake(t *testing.T) {
+	packet := make([]byte, srtHandshakeExtOffset)
+	binary.BigEndian.PutUint32(packet[0:4], 42) // Data packets have the control bit unset.
+
+	if isSRTHandshake(packet) {
+		t.Error("data packet should not be detected as a handshake")
+	}
+}
+
+func Test_srtStreamIDToPath(t *testing.T) {
+	tests := []struct {
+		name     string
+		streamID string
+		want     string
+	}{
+		{"plain key", "abc123", "/live/abc123"},
+		{"live prefix", "live/abc123", "/live/abc123"},
+		{"absolute live prefix", "/live/abc123", "/live/abc123"},
+		{"access control syntax", "#!::r=live/abc123,m=publish", "/live/abc123"},
+		{"access control syntax without live", "#!::m=publish,r=abc123", "/live/abc123"},
+		{"backup prefix", "backup/abc123", "/backup/abc123"},
+		{"access control syntax with backup", "#!::r=backup/abc123,m=publish", "/backup/abc123"},
+		{"empty", "", "/live/"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := srtStreamIDToPath(tt.streamID); got != tt.want {
+				t.Errorf("srtStreamIDToPath() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -41,19 +41,23 @@ func setupStats() error {
 
 // IsStreamConnected checks if the stream is connected or not.
 func IsStreamConnected() bool {
-	if !_stats.StreamConnected {
+	return isStatsConnected(_stats)
+}
+
+func isStatsConnected(stats *models.Stats) bool {
+	if !stats.StreamConnected {
 		return false
 	}
 
 	// Kind of a hack.  It takes a handful of seconds between a RTMP connection and when HLS data is available.
 	// So account for that with an artificial buffer of four segments.
-	timeSinceLastConnected := time.Since(_stats.LastConnectTime.Time).Seconds()
+	timeSinceLastConnected := time.Since(stats.LastConnectTime.Time).Seconds()
 	waitTime := math.Max(float64(data.GetStreamLatencyLevel().SecondsPerSegment)*3.0, 7)
 	if timeSinceLastConnected < waitTime {
 		return false
 	}
 
-	return _stats.StreamConnected
+	return stats.StreamConnected
 }
 
 // RemoveChatClient removes a client from the active clients record.
@@ -67,26 +71,33 @@ func RemoveChatClient(clientID string) {
 
 // SetViewerActive sets a client as active and connected.
 func SetViewerActive(viewer *models.Viewer) {
-	// Don't update viewer counts if a live stream session is not active.
-	if !_stats.StreamConnected {
-		return
-	}
+	SetChannelViewerActive(models.DefaultChannelID, viewer)
+}
 
+// SetChannelViewerActive sets a client of a channel as active and connected.
+func SetChannelViewerActive(channelID string, viewer *models.Viewer) {
 	l.Lock()
 	defer l.Unlock()
 
+	stats := getChannelStats(channelID)
+
+	// Don't update viewer counts if a live stream session is not active.
+	if stats == nil || !stats.StreamConnected {
+		return
+	}
+
 	// Asynchronously, optionally, fetch GeoIP data.
 	go func(viewer *models.Viewer) {
 		viewer.Geo = _geoIPClient.GetGeoFromIP(viewer.IPAddress)
 	}(viewer)
 
-	if _, exists := _stats.Viewers[viewer.ClientID]; exists {
-		_stats.Viewers[viewer.ClientID].LastSeen = time.Now()
+	if _, exists := stats.Viewers[viewer.ClientID]; exists {
+		stats.Viewers[viewer.ClientID].LastSeen = time.Now()
 	} else {
-		_stats.Viewers[viewer.ClientID] = viewer
+		stats.Viewers[viewer.ClientID] = viewer
 	}
-	_stats.SessionMaxViewerCount = int(math.Max(float64(len(_stats.Viewers)), float64(_stats.SessionMaxViewerCount)))
-	_stats.OverallMaxViewerCount = int(math.Max(float64(_stats.SessionMaxViewerCount), float64(_stats.OverallMaxViewerCount)))
+	stats.SessionMaxViewerCount = int(math.Max(float64(len(stats.Viewers)), float64(stats.SessionMaxViewerCount)))
+	stats.OverallMaxViewerCount = int(math.Max(float64(stats.SessionMaxViewerCount), float64(stats.OverallMaxViewerCount)))
 }
 
 // GetActiveViewers will return the active viewers.
@@ -95,19 +106,25 @@ func GetActiveViewers() map[string]*models.Viewer {
 }
 
 func pruneViewerCount() {
-	viewers := make(map[string]*models.Viewer)
-
 	l.Lock()
 	defer l.Unlock()
 
-	for viewerID, viewer := range _stats.Viewers {
-		viewerLastSeenTime := _stats.Viewers[viewerID].LastSeen
-		if time.Since(viewerLastSeenTime) < _activeViewerPurgeTimeout {
-			viewers[viewerID] = viewer
-		}
+	allStats := []*models.Stats{_stats}
+	for _, channel := range _channels {
+		allStats = append(allStats, channel.stats)
 	}
 
-	_stats.Viewers = viewers
+	for _, stats := range allStats {
+		viewers := make(map[string]*models.Viewer)
+		for viewerID, viewer := range stats.Viewers {
+			viewerLastSeenTime := stats.Viewers[viewerID].LastSeen
+			if time.Since(viewerLastSeenTime) < _activeViewerPurgeTimeout {
+				viewers[viewerID] = viewer
+			}
+		}
+
+		stats.Viewers = viewers
+	}
 }
 
 func saveStats() {
@@ -12,20 +12,24 @@ func GetStatus() models.Status {
 		return models.Status{}
 	}
 
+	return getStatusFromStats(_stats, data.GetStreamTitle())
+}
+
+func getStatusFromStats(stats *models.Stats, streamTitle string) models.Status {
 	viewerCount := 0
-	if IsStreamConnected() {
-		viewerCount = len(_stats.Viewers)
+	if isStatsConnected(stats) {
+		viewerCount = len(stats.Viewers)
 	}
 
 	return models.Status{
-		Online:                IsStreamConnected(),
+		Online:                isStatsConnected(stats),
 		ViewerCount:           viewerCount,
-		OverallMaxViewerCount: _stats.OverallMaxViewerCount,
-		SessionMaxViewerCount: _stats.SessionMaxViewerCount,
-		LastDisconnectTime:    _stats.LastDisconnectTime,
-		LastConnectTime:       _stats.LastConnectTime,
+		OverallMaxViewerCount: stats.OverallMaxViewerCount,
+		SessionMaxViewerCount: stats.SessionMaxViewerCount,
+		LastDisconnectTime:    stats.LastDisconnectTime,
+		LastConnectTime:       stats.LastConnectTime,
 		VersionNumber:         config.VersionNumber,
-		StreamTitle:           data.GetStreamTitle(),
+		StreamTitle:           streamTitle,
 	}
 }
 
@@ -34,9 +38,19 @@ func GetCurrentBroadcast() *models.CurrentBroadcast {
 	return _currentBroadcast
 }
 
-// setBroadcaster will store the current inbound broadcasting details.
-func setBroadcaster(broadcaster models.Broadcaster) {
-	_broadcaster = &broadcaster
+// setBroadcaster will store the current inbound broadcasting details of a channel.
+func setBroadcaster(channelID string, broadcaster models.Broadcaster) {
+	if channelID == models.DefaultChannelID {
+		_broadcaster = &broadcaster
+		return
+	}
+
+	l.Lock()
+	defer l.Unlock()
+
+	if channel, ok := _channels[channelID]; ok {
+		channel.broadcaster = &broadcaster
+	}
 }
 
 // GetBroadcaster will return the details of the currently active broadcaster.
@@ -10,6 +10,7 @@ import (
 
 	"github.com/owncast/owncast/config"
 	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/utils"
 )
 
 // LocalStorage represents an instance of the local storage provider for HLS video.
@@ -57,6 +58,16 @@ func (s *LocalStorage) MasterPlaylistWritten(localFilePath string) {
 	}
 }
 
+// DASHManifestWritten is called when the DASH manifest is written.
+func (s *LocalStorage) DASHManifestWritten(localFilePath string) {
+	// If we're using a remote serving endpoint, we need to rewrite the manifest
+	if s.host != "" {
+		if err := rewriteManifestLocations(localFilePath, s.host, ""); err != nil {
+			log.Warnln(err)
+		}
+	}
+}
+
 // Save will save a local filepath using the storage provider.
 func (s *LocalStorage) Save(filePath string, retryCount int) (string, error) {
 	return filePath, nil
@@ -64,7 +75,7 @@ func (s *LocalStorage) Save(filePath string, retryCount int) (string, error) {
 
 func (s *LocalStorage) Cleanup() error {
 	// Determine how many files we should keep on disk
-	maxNumber := data.GetStreamLatencyLevel().SegmentCount
+	maxNumber := data.GetStreamLatencyLevel().GetMaxFilesPerVariant()
 	buffer := 10
 	baseDirectory := config.HLSStoragePath
 
@@ -97,17 +108,19 @@ func (s *LocalStorage) Cleanup() error {
 func getAllFilesRecursive(baseDirectory string) (map[string][]os.FileInfo, error) {
 	files := make(map[string][]os.FileInfo)
 
-	var directory string
 	err := filepath.Walk(baseDirectory, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
 
-		if info.IsDir() {
-			directory = info.Name()
-		}
-
-		if filepath.Ext(info.Name()) == ".ts" {
+		// Init segments are not removed as they are used by every segment.
+		if !info.IsDir() && utils.IsHLSSegment(info.Name()) {
+			// Files are grouped by their directory relative to the base
+			// directory, as channels have their own variant directories.
+			directory, err := filepath.Rel(baseDirectory, filepath.Dir(path))
+			if err != nil {
+				return err
+			}
 			files[directory] = append(files[directory], info)
 		}
 
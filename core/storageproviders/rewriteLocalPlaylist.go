@@ -7,6 +7,7 @@ import (
 
 	"github.com/grafov/m3u8"
 	"github.com/owncast/owncast/config"
+	"github.com/owncast/owncast/core/dash"
 	"github.com/owncast/owncast/core/playlist"
 
 	log "github.com/sirupsen/logrus"
@@ -24,6 +25,12 @@ func rewritePlaylistLocations(localFilePath, remoteServingEndpoint, pathPrefix s
 		log.Warnln(err)
 	}
 
+	// The master playlist of a channel is within the directory of the channel.
+	channelDirectory, err := filepath.Rel(config.HLSStoragePath, filepath.Dir(localFilePath))
+	if err != nil {
+		return err
+	}
+
 	for _, item := range p.Variants {
 		// Determine the final path to this playlist.
 		var finalPath string
@@ -32,12 +39,24 @@ func rewritePlaylistLocations(localFilePath, remoteServingEndpoint, pathPrefix s
 		} else {
 			finalPath = "/hls"
 		}
-		item.URI = remoteServingEndpoint + filepath.Join(finalPath, item.URI)
+		item.URI = remoteServingEndpoint + filepath.Join(finalPath, channelDirectory, item.URI)
 	}
 
-	publicPath := filepath.Join(config.HLSStoragePath, filepath.Base(localFilePath))
+	publicPath := filepath.Join(config.HLSStoragePath, channelDirectory, filepath.Base(localFilePath))
 
 	newPlaylist := p.String()
 
 	return playlist.WritePlaylist(newPlaylist, publicPath)
 }
+
+// rewriteManifestLocations will take the local DASH manifest and rewrite it to load segments from a specified location.
+func rewriteManifestLocations(localFilePath, remoteServingEndpoint, pathPrefix string) error {
+	var finalPath string
+	if pathPrefix != "" {
+		finalPath = filepath.Join(pathPrefix, "/hls")
+	} else {
+		finalPath = "/hls"
+	}
+
+	return dash.RewriteBaseURL(localFilePath, remoteServingEndpoint+finalPath+"/")
+}
@@ -143,6 +143,20 @@ func (s *S3Storage) MasterPlaylistWritten(localFilePath string) {
 	}
 }
 
+// DASHManifestWritten is called when the DASH manifest is written.
+func (s *S3Storage) DASHManifestWritten(localFilePath string) {
+	// Rewrite the manifest to use absolute remote S3 URLs
+	if err := rewriteManifestLocations(localFilePath, s.host, s.s3PathPrefix); err != nil {
+		log.Warnln(err)
+		return
+	}
+
+	// Also make it available alongside the segments.
+	if _, err := s.Save(localFilePath, 0); err != nil {
+		log.Warnln(err)
+	}
+}
+
 // Save saves the file to the s3 bucket.
 func (s *S3Storage) Save(filePath string, retryCount int) (string, error) {
 	file, err := os.Open(filePath) // nolint
@@ -176,6 +190,12 @@ func (s *S3Storage) Save(filePath string, retryCount int) (string, error) {
 		noCacheHeader := "no-cache, no-store, must-revalidate"
 		contentType := "application/x-mpegURL"
 
+		uploadInput.CacheControl = &noCacheHeader
+		uploadInput.ContentType = &contentType
+	} else if path.Ext(filePath) == ".mpd" {
+		noCacheHeader := "no-cache, no-store, must-revalidate"
+		contentType := "application/dash+xml"
+
 		uploadInput.CacheControl = &noCacheHeader
 		uploadInput.ContentType = &contentType
 	}
@@ -201,15 +221,19 @@ func (s *S3Storage) Save(filePath string, retryCount int) (string, error) {
 		return "", fmt.Errorf("Giving up uploading %s to object storage %s", filePath, s.s3Endpoint)
 	}
 
-	// Upload success. Remove the local file.
-	s.removeLocalFile(filePath)
+	// Upload success. Remove the local file, keeping init segments as they
+	// are needed locally to generate thumbnails from fMP4 segments and the
+	// DASH manifest as it is also served locally.
+	if !utils.IsHLSInitSegment(filePath) && path.Ext(filePath) != ".mpd" {
+		s.removeLocalFile(filePath)
+	}
 
 	return response.Location, nil
 }
 
 func (s *S3Storage) Cleanup() error {
 	// Determine how many files we should keep on S3 storage
-	maxNumber := data.GetStreamLatencyLevel().SegmentCount
+	maxNumber := data.GetStreamLatencyLevel().GetMaxFilesPerVariant()
 	buffer := 20
 
 	keys, err := s.getDeletableVideoSegmentsWithOffset(maxNumber + buffer)
@@ -255,16 +279,24 @@ func (s *S3Storage) connectAWS() *session.Session {
 }
 
 func (s *S3Storage) getDeletableVideoSegmentsWithOffset(offset int) ([]s3object, error) {
-	objectsToDelete, err := s.retrieveAllVideoSegments()
+	allObjects, err := s.retrieveAllVideoSegments()
 	if err != nil {
 		return nil, err
 	}
 
-	if offset > len(objectsToDelete)-1 {
-		offset = len(objectsToDelete) - 1
+	// Each variant of each channel keeps its own most recent segments.
+	objectsByDirectory := map[string][]s3object{}
+	for _, object := range allObjects {
+		directory := path.Dir(object.key)
+		objectsByDirectory[directory] = append(objectsByDirectory[directory], object)
 	}
 
-	objectsToDelete = objectsToDelete[offset : len(objectsToDelete)-1]
+	objectsToDelete := []s3object{}
+	for _, objects := range objectsByDirectory {
+		if len(objects) > offset {
+			objectsToDelete = append(objectsToDelete, objects[offset:]...)
+		}
+	}
 
 	return objectsToDelete, nil
 }
@@ -313,7 +345,7 @@ func (s *S3Storage) retrieveAllVideoSegments() ([]s3object, error) {
 	// Filter out non-video segments
 	allObjects := []s3object{}
 	for _, item := range allObjectsListResponse.Contents {
-		if !strings.HasSuffix(*item.Key, ".ts") {
+		if !utils.IsHLSSegment(*item.Key) {
 			continue
 		}
 
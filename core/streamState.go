@@ -10,7 +10,11 @@ import (
 	"github.com/owncast/owncast/activitypub"
 	"github.com/owncast/owncast/config"
 	"github.com/owncast/owncast/core/chat"
+	"github.com/owncast/owncast/core/dash"
 	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/core/llhls"
+	"github.com/owncast/owncast/core/recording"
+	"github.com/owncast/owncast/core/restream"
 	"github.com/owncast/owncast/core/rtmp"
 	"github.com/owncast/owncast/core/transcoder"
 	"github.com/owncast/owncast/core/webhooks"
@@ -31,8 +35,13 @@ var _onlineTimerCancelFunc context.CancelFunc
 
 var _lastNotified *time.Time
 
-// setStreamAsConnected sets the stream as connected.
-func setStreamAsConnected(rtmpOut *io.PipeReader) {
+// setStreamAsConnected sets the stream of a channel as connected.
+func setStreamAsConnected(channelID string, rtmpOut *io.PipeReader) {
+	if channelID != models.DefaultChannelID {
+		setChannelAsConnected(channelID, rtmpOut)
+		return
+	}
+
 	now := utils.NullTime{Time: time.Now(), Valid: true}
 	_stats.StreamConnected = true
 	_stats.LastDisconnectTime = nil
@@ -42,6 +51,7 @@ func setStreamAsConnected(rtmpOut *io.PipeReader) {
 	_currentBroadcast = &models.CurrentBroadcast{
 		LatencyLevel:   data.GetStreamLatencyLevel(),
 		OutputSettings: data.GetStreamOutputVariants(),
+		SegmentFormat:  transcoder.GetSegmentFormat(),
 	}
 
 	StopOfflineCleanupTimer()
@@ -57,6 +67,17 @@ func setStreamAsConnected(rtmpOut *io.PipeReader) {
 		log.Fatalln("failed to setup the storage", err)
 	}
 
+	// Low latency playlists are served locally, so are not available when
+	// using external storage.
+	if !data.GetS3Config().Enabled {
+		llhls.Start(_currentBroadcast.LatencyLevel)
+	}
+
+	// DASH players share the fMP4 segments written for HLS.
+	if _currentBroadcast.SegmentFormat == models.SegmentFormatFMP4 {
+		dash.Start(_currentBroadcast.OutputSettings, _currentBroadcast.LatencyLevel)
+	}
+
 	go func() {
 		_transcoder = transcoder.NewTranscoder()
 		_transcoder.TranscoderCompleted = func(error) {
@@ -64,15 +85,19 @@ func setStreamAsConnected(rtmpOut *io.PipeReader) {
 			_transcoder = nil
 			_currentBroadcast = nil
 		}
+		handler.SetChannelTranscoder(models.DefaultChannelID, _transcoder)
 		_transcoder.SetStdin(rtmpOut)
 		_transcoder.Start(true)
 	}()
 
+	recording.Start(data.GetStreamTitle(), _currentBroadcast.OutputSettings)
+	restream.Start(data.GetRestreamDestinations())
+
 	go webhooks.SendStreamStatusEvent(models.StreamStarted)
 	transcoder.StartThumbnailGenerator(segmentPath, data.FindHighestVideoQualityIndex(_currentBroadcast.OutputSettings))
 
-	_ = chat.SendSystemAction("Stay tuned, the stream is **starting**!", true)
-	chat.SendAllWelcomeMessage()
+	_ = chat.SendSystemActionToChannel(models.DefaultChannelID, "Stay tuned, the stream is **starting**!", true)
+	chat.SendAllWelcomeMessage(models.DefaultChannelID)
 
 	// Send delayed notification messages.
 	_onlineTimerCancelFunc = startLiveStreamNotificationsTimer()
@@ -80,7 +105,7 @@ func setStreamAsConnected(rtmpOut *io.PipeReader) {
 
 // SetStreamAsDisconnected sets the stream as disconnected.
 func SetStreamAsDisconnected() {
-	_ = chat.SendSystemAction("The stream is ending.", true)
+	_ = chat.SendSystemActionToChannel(models.DefaultChannelID, "The stream is ending.", true)
 
 	now := utils.NullTime{Time: time.Now(), Valid: true}
 	if _onlineTimerCancelFunc != nil {
@@ -92,16 +117,13 @@ func SetStreamAsDisconnected() {
 	_stats.LastConnectTime = nil
 	_broadcaster = nil
 
-	offlineFilename := "offline.ts"
-
-	offlineFilePath, err := saveOfflineClipToDisk(offlineFilename)
-	if err != nil {
-		log.Errorln(err)
-		return
-	}
+	recording.Stop()
+	llhls.Stop()
+	dash.Stop()
+	restream.Stop()
 
 	transcoder.StopThumbnailGenerator()
-	rtmp.Disconnect()
+	rtmp.Disconnect(models.DefaultChannelID)
 
 	if _yp != nil {
 		_yp.Stop()
@@ -117,8 +139,14 @@ func SetStreamAsDisconnected() {
 		return
 	}
 
+	clip, err := getOfflineClip(_currentBroadcast.SegmentFormat)
+	if err != nil {
+		log.Errorln(err)
+		return
+	}
+
 	for index := range _currentBroadcast.OutputSettings {
-		makeVariantIndexOffline(index, offlineFilePath, offlineFilename)
+		makeVariantIndexOffline(models.DefaultChannelID, index, clip)
 	}
 
 	StartOfflineCleanupTimer()
@@ -147,7 +175,12 @@ func StopOfflineCleanupTimer() {
 	}
 }
 
+// The cleanup timer runs while any channel is live.
 func startOnlineCleanupTimer() {
+	if _onlineCleanupTicker != nil {
+		return
+	}
+
 	_onlineCleanupTicker = time.NewTicker(1 * time.Minute)
 	go func() {
 		for range _onlineCleanupTicker.C {
@@ -159,8 +192,9 @@ func startOnlineCleanupTimer() {
 }
 
 func stopOnlineCleanupTimer() {
-	if _onlineCleanupTicker != nil {
+	if _onlineCleanupTicker != nil && !hasConnectedChannels() {
 		_onlineCleanupTicker.Stop()
+		_onlineCleanupTicker = nil
 	}
 }
 
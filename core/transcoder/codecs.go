@@ -8,6 +8,9 @@ import (
 	"strings"
 
 	log "github.com/sirupsen/logrus"
+
+	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/models"
 )
 
 // Codec represents a supported codec on the system.
@@ -21,6 +24,7 @@ type Codec interface {
 	ExtraFilters() string
 	VariantFlags(v *HLSVariant) string
 	GetPresetForLevel(l int) string
+	CodecsAttribute(v *HLSVariant) string
 }
 
 var supportedCodecs = map[string]string{
@@ -29,6 +33,9 @@ var supportedCodecs = map[string]string{
 	(&VaapiCodec{}).Name():        "vaapi",
 	(&NvencCodec{}).Name():        "NVIDIA nvenc",
 	(&VideoToolboxCodec{}).Name(): "videotoolbox",
+	(&Libx265Codec{}).Name():      "libx265",
+	(&SvtAv1Codec{}).Name():       "SVT-AV1",
+	(&LibaomAv1Codec{}).Name():    "libaom AV1",
 }
 
 // Libx264Codec represents an instance of the Libx264 Codec.
@@ -100,6 +107,12 @@ func (c *Libx264Codec) GetPresetForLevel(l int) string {
 	return preset
 }
 
+// CodecsAttribute is the identifier of the video of a variant encoded by
+// this codec used in the master playlist.
+func (c *Libx264Codec) CodecsAttribute(v *HLSVariant) string {
+	return v.getH264CodecsAttribute()
+}
+
 // OmxCodec represents an instance of the Omx codec.
 type OmxCodec struct{}
 
@@ -165,6 +178,12 @@ func (c *OmxCodec) GetPresetForLevel(l int) string {
 	return preset
 }
 
+// CodecsAttribute is the identifier of the video of a variant encoded by
+// this codec used in the master playlist.
+func (c *OmxCodec) CodecsAttribute(v *HLSVariant) string {
+	return v.getH264CodecsAttribute()
+}
+
 // VaapiCodec represents an instance of the Vaapi codec.
 type VaapiCodec struct{}
 
@@ -234,6 +253,12 @@ func (c *VaapiCodec) GetPresetForLevel(l int) string {
 	return preset
 }
 
+// CodecsAttribute is the identifier of the video of a variant encoded by
+// this codec used in the master playlist.
+func (c *VaapiCodec) CodecsAttribute(v *HLSVariant) string {
+	return v.getH264CodecsAttribute()
+}
+
 // NvencCodec represents an instance of the Nvenc Codec.
 type NvencCodec struct{}
 
@@ -302,6 +327,12 @@ func (c *NvencCodec) GetPresetForLevel(l int) string {
 	return preset
 }
 
+// CodecsAttribute is the identifier of the video of a variant encoded by
+// this codec used in the master playlist.
+func (c *NvencCodec) CodecsAttribute(v *HLSVariant) string {
+	return v.getH264CodecsAttribute()
+}
+
 // QuicksyncCodec represents an instance of the Intel Quicksync Codec.
 type QuicksyncCodec struct{}
 
@@ -365,6 +396,12 @@ func (c *QuicksyncCodec) GetPresetForLevel(l int) string {
 	return preset
 }
 
+// CodecsAttribute is the identifier of the video of a variant encoded by
+// this codec used in the master playlist.
+func (c *QuicksyncCodec) CodecsAttribute(v *HLSVariant) string {
+	return v.getH264CodecsAttribute()
+}
+
 // Video4Linux represents an instance of the V4L Codec.
 type Video4Linux struct{}
 
@@ -427,6 +464,12 @@ func (c *Video4Linux) GetPresetForLevel(l int) string {
 	return preset
 }
 
+// CodecsAttribute is the identifier of the video of a variant encoded by
+// this codec used in the master playlist.
+func (c *Video4Linux) CodecsAttribute(v *HLSVariant) string {
+	return v.getH264CodecsAttribute()
+}
+
 // VideoToolboxCodec represents an instance of the VideoToolbox codec.
 type VideoToolboxCodec struct{}
 
@@ -502,6 +545,316 @@ func (c *VideoToolboxCodec) GetPresetForLevel(l int) string {
 	return preset
 }
 
+// CodecsAttribute is the identifier of the video of a variant encoded by
+// this codec used in the master playlist.
+func (c *VideoToolboxCodec) CodecsAttribute(v *HLSVariant) string {
+	return v.getH264CodecsAttribute()
+}
+
+// Libx265Codec represents an instance of the Libx265 HEVC codec.
+type Libx265Codec struct{}
+
+// Name returns the codec name.
+func (c *Libx265Codec) Name() string {
+	return "libx265"
+}
+
+// DisplayName returns the human readable name of the codec.
+func (c *Libx265Codec) DisplayName() string {
+	return "x265 (HEVC)"
+}
+
+// GlobalFlags are the global flags used with this codec in the transcoder.
+func (c *Libx265Codec) GlobalFlags() string {
+	return ""
+}
+
+// PixelFormat is the pixel format required for this codec.
+func (c *Libx265Codec) PixelFormat() string {
+	return "yuv420p"
+}
+
+// Scaler is the scaler used for resizing the video in the transcoder.
+func (c *Libx265Codec) Scaler() string {
+	return ""
+}
+
+// ExtraArguments are the extra arguments used with this codec in the transcoder.
+func (c *Libx265Codec) ExtraArguments() string {
+	return strings.Join([]string{
+		"-tune", "zerolatency", // Option used for good for fast encoding and low-latency streaming (always includes iframes in each segment)
+	}, " ")
+}
+
+// ExtraFilters are the extra filters required for this codec in the transcoder.
+func (c *Libx265Codec) ExtraFilters() string {
+	return ""
+}
+
+// VariantFlags returns a string representing a single variant processed by this codec.
+func (c *Libx265Codec) VariantFlags(v *HLSVariant) string {
+	return strings.Join([]string{
+		fmt.Sprintf("-x265-params:v:%d \"scenecut=0:open-gop=0\"", v.index), // Only place keyframes at segment boundaries
+		fmt.Sprintf("-bufsize:v:%d %dk", v.index, v.getBufferSize()),
+		fmt.Sprintf("-profile:v:%d %s", v.index, "main"), // Encoding profile
+		fmt.Sprintf("-tag:v:%d %s", v.index, "hvc1"),     // Apple devices require the hvc1 sample entry
+	}, " ")
+}
+
+// GetPresetForLevel returns the string preset for this codec given an integer level.
+func (c *Libx265Codec) GetPresetForLevel(l int) string {
+	presetMapping := map[int]string{
+		0: "ultrafast",
+		1: "superfast",
+		2: "veryfast",
+		3: "faster",
+		4: "fast",
+	}
+
+	preset, ok := presetMapping[l]
+	if !ok {
+		defaultPreset := presetMapping[1]
+		log.Errorf("Invalid level for x265 preset %d, defaulting to %s", l, defaultPreset)
+		return defaultPreset
+	}
+
+	return preset
+}
+
+// CodecsAttribute is the identifier of the video of a variant encoded by
+// this codec used in the master playlist.
+func (c *Libx265Codec) CodecsAttribute(v *HLSVariant) string {
+	return v.getHEVCCodecsAttribute()
+}
+
+// SvtAv1Codec represents an instance of the SVT-AV1 codec.
+type SvtAv1Codec struct{}
+
+// Name returns the codec name.
+func (c *SvtAv1Codec) Name() string {
+	return "libsvtav1"
+}
+
+// DisplayName returns the human readable name of the codec.
+func (c *SvtAv1Codec) DisplayName() string {
+	return "SVT-AV1"
+}
+
+// GlobalFlags are the global flags used with this codec in the transcoder.
+func (c *SvtAv1Codec) GlobalFlags() string {
+	return ""
+}
+
+// PixelFormat is the pixel format required for this codec.
+func (c *SvtAv1Codec) PixelFormat() string {
+	return "yuv420p"
+}
+
+// Scaler is the scaler used for resizing the video in the transcoder.
+func (c *SvtAv1Codec) Scaler() string {
+	return ""
+}
+
+// ExtraArguments are the extra arguments used with this codec in the transcoder.
+func (c *SvtAv1Codec) ExtraArguments() string {
+	return ""
+}
+
+// ExtraFilters are the extra filters required for this codec in the transcoder.
+func (c *SvtAv1Codec) ExtraFilters() string {
+	return ""
+}
+
+// VariantFlags returns a string representing a single variant processed by this codec.
+func (c *SvtAv1Codec) VariantFlags(v *HLSVariant) string {
+	return strings.Join([]string{
+		fmt.Sprintf("-svtav1-params:v:%d \"scd=0:fast-decode=1\"", v.index), // Only place keyframes at segment boundaries
+		fmt.Sprintf("-bufsize:v:%d %dk", v.index, v.getBufferSize()),
+	}, " ")
+}
+
+// GetPresetForLevel returns the string preset for this codec given an integer level.
+// SVT-AV1 presets range from 0 (slowest) to 13 (fastest).
+func (c *SvtAv1Codec) GetPresetForLevel(l int) string {
+	presetMapping := map[int]string{
+		0: "12",
+		1: "11",
+		2: "10",
+		3: "9",
+		4: "8",
+	}
+
+	preset, ok := presetMapping[l]
+	if !ok {
+		defaultPreset := presetMapping[1]
+		log.Errorf("Invalid level for svt-av1 preset %d, defaulting to %s", l, defaultPreset)
+		return defaultPreset
+	}
+
+	return preset
+}
+
+// CodecsAttribute is the identifier of the video of a variant encoded by
+// this codec used in the master playlist.
+func (c *SvtAv1Codec) CodecsAttribute(v *HLSVariant) string {
+	return v.getAV1CodecsAttribute()
+}
+
+// LibaomAv1Codec represents an instance of the libaom AV1 codec.
+type LibaomAv1Codec struct{}
+
+// Name returns the codec name.
+func (c *LibaomAv1Codec) Name() string {
+	return "libaom-av1"
+}
+
+// DisplayName returns the human readable name of the codec.
+func (c *LibaomAv1Codec) DisplayName() string {
+	return "libaom AV1"
+}
+
+// GlobalFlags are the global flags used with this codec in the transcoder.
+func (c *LibaomAv1Codec) GlobalFlags() string {
+	return ""
+}
+
+// PixelFormat is the pixel format required for this codec.
+func (c *LibaomAv1Codec) PixelFormat() string {
+	return "yuv420p"
+}
+
+// Scaler is the scaler used for resizing the video in the transcoder.
+func (c *LibaomAv1Codec) Scaler() string {
+	return ""
+}
+
+// ExtraArguments are the extra arguments used with this codec in the transcoder.
+func (c *LibaomAv1Codec) ExtraArguments() string {
+	return ""
+}
+
+// ExtraFilters are the extra filters required for this codec in the transcoder.
+func (c *LibaomAv1Codec) ExtraFilters() string {
+	return ""
+}
+
+// VariantFlags returns a string representing a single variant processed by this codec.
+func (c *LibaomAv1Codec) VariantFlags(v *HLSVariant) string {
+	return strings.Join([]string{
+		fmt.Sprintf("-usage:v:%d realtime", v.index),
+		fmt.Sprintf("-cpu-used:v:%d %d", v.index, c.getCPUUsedForLevel(v.cpuUsageLevel)),
+		fmt.Sprintf("-lag-in-frames:v:%d 0", v.index), // Don't buffer frames to look ahead
+		fmt.Sprintf("-row-mt:v:%d 1", v.index),
+		fmt.Sprintf("-bufsize:v:%d %dk", v.index, v.getBufferSize()),
+	}, " ")
+}
+
+// GetPresetForLevel returns the string preset for this codec given an integer level.
+// libaom has no presets, so the speed is set using cpu-used in VariantFlags instead.
+func (c *LibaomAv1Codec) GetPresetForLevel(l int) string {
+	return ""
+}
+
+// getCPUUsedForLevel returns the realtime cpu-used speed, from 5 (slowest)
+// to 10 (fastest), given an integer level.
+func (c *LibaomAv1Codec) getCPUUsedForLevel(l int) int {
+	cpuUsedMapping := map[int]int{
+		0: 10,
+		1: 9,
+		2: 8,
+		3: 7,
+		4: 6,
+	}
+
+	cpuUsed, ok := cpuUsedMapping[l]
+	if !ok {
+		defaultCPUUsed := cpuUsedMapping[1]
+		log.Errorf("Invalid level for libaom-av1 cpu-used %d, defaulting to %d", l, defaultCPUUsed)
+		return defaultCPUUsed
+	}
+
+	return cpuUsed
+}
+
+// CodecsAttribute is the identifier of the video of a variant encoded by
+// this codec used in the master playlist.
+func (c *LibaomAv1Codec) CodecsAttribute(v *HLSVariant) string {
+	return v.getAV1CodecsAttribute()
+}
+
+// codecRequiresFMP4 will return if a codec is only able to be used with
+// fMP4 segments.
+func codecRequiresFMP4(codec Codec) bool {
+	switch codec.(type) {
+	case *Libx265Codec, *SvtAv1Codec, *LibaomAv1Codec:
+		return true
+	default:
+		return false
+	}
+}
+
+// GetSegmentFormat will return the container format of segments, taking in
+// to account codecs that require fMP4 segments.
+func GetSegmentFormat() models.SegmentFormat {
+	if codecRequiresFMP4(getCodec(data.GetVideoCodec())) {
+		return models.SegmentFormatFMP4
+	}
+
+	for _, variant := range data.GetStreamOutputVariants() {
+		if variant.Codec != "" && !variant.IsVideoPassthrough && codecRequiresFMP4(getCodec(variant.Codec)) {
+			return models.SegmentFormatFMP4
+		}
+	}
+
+	return data.GetSegmentFormat()
+}
+
+// ValidateVariantCodecs will return an error if the codecs chosen for the
+// stream output variants are unavailable or unable to be used together.
+func ValidateVariantCodecs(variants []models.StreamOutputVariant, defaultCodec string, availableCodecs []string) error {
+	available := make(map[string]bool, len(availableCodecs))
+	for _, name := range availableCodecs {
+		available[name] = true
+	}
+
+	codecs := map[string]Codec{}
+	for _, variant := range variants {
+		if variant.IsVideoPassthrough {
+			continue
+		}
+
+		name := variant.Codec
+		if name == "" {
+			name = defaultCodec
+		} else if _, supported := supportedCodecs[name]; !supported || !available[name] {
+			return fmt.Errorf("codec %s is not available on this system", name)
+		}
+
+		codecs[name] = getCodec(name)
+	}
+
+	if len(codecs) < 2 {
+		return nil
+	}
+
+	globalFlags := ""
+	for name, codec := range codecs {
+		// Hardware frames are not able to be shared with other encoders.
+		if codec.Scaler() != "" {
+			return fmt.Errorf("codec %s is not able to be used alongside other codecs", name)
+		}
+
+		if flags := codec.GlobalFlags(); flags != "" {
+			if globalFlags != "" && flags != globalFlags {
+				return fmt.Errorf("codec %s requires different hardware from the other codecs", name)
+			}
+			globalFlags = flags
+		}
+	}
+
+	return nil
+}
+
 // GetCodecs will return the supported codecs available on the system.
 func GetCodecs(ffmpegPath string) []string {
 	codecs := make([]string, 0)
@@ -516,8 +869,12 @@ func GetCodecs(ffmpegPath string) []string {
 	response := string(out)
 	lines := strings.Split(response, "\n")
 	for _, line := range lines {
-		if strings.Contains(line, "H.264") {
+		if strings.Contains(line, "H.264") || strings.Contains(line, "HEVC") || strings.Contains(line, "AV1") {
 			fields := strings.Fields(line)
+			if len(fields) < 2 {
+				continue
+			}
+
 			codec := fields[1]
 			if _, supported := supportedCodecs[codec]; supported {
 				codecs = append(codecs, codec)
@@ -542,6 +899,12 @@ func getCodec(name string) Codec {
 		return &Video4Linux{}
 	case (&VideoToolboxCodec{}).Name():
 		return &VideoToolboxCodec{}
+	case (&Libx265Codec{}).Name():
+		return &Libx265Codec{}
+	case (&SvtAv1Codec{}).Name():
+		return &SvtAv1Codec{}
+	case (&LibaomAv1Codec{}).Name():
+		return &LibaomAv1Codec{}
 	default:
 		return &Libx264Codec{}
 	}
@@ -0,0 +1,117 @@
+package transcoder
+
+import "fmt"
+
+// The RFC 6381 identifier of AAC-LC used in the CODECS attribute of the
+// master playlist. It is what audio is encoded to and what is expected from
+// passed through audio.
+const aacCodecsAttribute = "mp4a.40.2"
+
+// When a variant is not scaled, or only scaled on one side, the size of the
+// source video is unknown and is assumed to be 1080p with a 16:9 aspect.
+const (
+	assumedSourceWidth  = 1920
+	assumedSourceHeight = 1080
+)
+
+// codecLevel is a level of a video codec and the largest video it allows.
+type codecLevel struct {
+	// id is how the level is written in the CODECS attribute.
+	id int
+	// maxPictureSize is the most luma samples in a single frame.
+	maxPictureSize int
+	// maxSampleRate is the most luma samples per second.
+	maxSampleRate int
+	// maxBitrate is the highest bitrate in kbps.
+	maxBitrate int
+}
+
+// H.264 High profile levels. The picture sizes and sample rates are the
+// macroblock limits of each level in samples, and the bitrates allow for the
+// 1.25 factor of the High profile.
+var h264Levels = []codecLevel{
+	{id: 30, maxPictureSize: 1620 * 256, maxSampleRate: 40500 * 256, maxBitrate: 12500},
+	{id: 31, maxPictureSize: 3600 * 256, maxSampleRate: 108000 * 256, maxBitrate: 17500},
+	{id: 32, maxPictureSize: 5120 * 256, maxSampleRate: 216000 * 256, maxBitrate: 25000},
+	{id: 40, maxPictureSize: 8192 * 256, maxSampleRate: 245760 * 256, maxBitrate: 25000},
+	{id: 41, maxPictureSize: 8192 * 256, maxSampleRate: 245760 * 256, maxBitrate: 62500},
+	{id: 42, maxPictureSize: 8704 * 256, maxSampleRate: 522240 * 256, maxBitrate: 62500},
+	{id: 50, maxPictureSize: 22080 * 256, maxSampleRate: 589824 * 256, maxBitrate: 168750},
+	{id: 51, maxPictureSize: 36864 * 256, maxSampleRate: 983040 * 256, maxBitrate: 300000},
+	{id: 52, maxPictureSize: 36864 * 256, maxSampleRate: 2073600 * 256, maxBitrate: 300000},
+}
+
+// HEVC Main tier levels, identified by 30 times the level number.
+var hevcLevels = []codecLevel{
+	{id: 90, maxPictureSize: 552960, maxSampleRate: 16588800, maxBitrate: 6000},
+	{id: 93, maxPictureSize: 983040, maxSampleRate: 33177600, maxBitrate: 10000},
+	{id: 120, maxPictureSize: 2228224, maxSampleRate: 66846720, maxBitrate: 12000},
+	{id: 123, maxPictureSize: 2228224, maxSampleRate: 133693440, maxBitrate: 20000},
+	{id: 150, maxPictureSize: 8912896, maxSampleRate: 267386880, maxBitrate: 25000},
+	{id: 153, maxPictureSize: 8912896, maxSampleRate: 534773760, maxBitrate: 40000},
+	{id: 156, maxPictureSize: 8912896, maxSampleRate: 1069547520, maxBitrate: 60000},
+}
+
+// AV1 Main tier levels, identified by their seq_level_idx.
+var av1Levels = []codecLevel{
+	{id: 0, maxPictureSize: 147456, maxSampleRate: 4423680, maxBitrate: 1500},
+	{id: 1, maxPictureSize: 278784, maxSampleRate: 8363520, maxBitrate: 3000},
+	{id: 4, maxPictureSize: 665856, maxSampleRate: 19975680, maxBitrate: 6000},
+	{id: 5, maxPictureSize: 1065024, maxSampleRate: 31950720, maxBitrate: 10000},
+	{id: 8, maxPictureSize: 2359296, maxSampleRate: 70778880, maxBitrate: 12000},
+	{id: 9, maxPictureSize: 2359296, maxSampleRate: 141557760, maxBitrate: 20000},
+	{id: 12, maxPictureSize: 8912896, maxSampleRate: 267386880, maxBitrate: 30000},
+	{id: 13, maxPictureSize: 8912896, maxSampleRate: 534773760, maxBitrate: 40000},
+	{id: 14, maxPictureSize: 8912896, maxSampleRate: 1069547520, maxBitrate: 60000},
+}
+
+// getOutputSize will return the size of the video of this variant once
+// scaled.
+func (v *HLSVariant) getOutputSize() (int, int) {
+	width, height := v.videoSize.Width, v.videoSize.Height
+
+	switch {
+	case width > 0 && height > 0:
+		return width, height
+	case width > 0:
+		return width, width * assumedSourceHeight / assumedSourceWidth
+	case height > 0:
+		return height * assumedSourceWidth / assumedSourceHeight, height
+	default:
+		return assumedSourceWidth, assumedSourceHeight
+	}
+}
+
+// getCodecLevel will return the lowest level that allows the video of this
+// variant, or the highest level if none do.
+func (v *HLSVariant) getCodecLevel(levels []codecLevel) codecLevel {
+	width, height := v.getOutputSize()
+	pictureSize := width * height
+	sampleRate := pictureSize * v.framerate
+
+	for _, level := range levels {
+		if pictureSize <= level.maxPictureSize && sampleRate <= level.maxSampleRate && v.getMaxVideoBitrate() <= level.maxBitrate {
+			return level
+		}
+	}
+
+	return levels[len(levels)-1]
+}
+
+// getH264CodecsAttribute will return the identifier of the High profile
+// H.264 video of this variant.
+func (v *HLSVariant) getH264CodecsAttribute() string {
+	return fmt.Sprintf("avc1.6400%02x", v.getCodecLevel(h264Levels).id)
+}
+
+// getHEVCCodecsAttribute will return the identifier of the Main profile HEVC
+// video of this variant.
+func (v *HLSVariant) getHEVCCodecsAttribute() string {
+	return fmt.Sprintf("hvc1.1.6.L%d.B0", v.getCodecLevel(hevcLevels).id)
+}
+
+// getAV1CodecsAttribute will return the identifier of the 8-bit Main profile
+// AV1 video of this variant.
+func (v *HLSVariant) getAV1CodecsAttribute() string {
+	return fmt.Sprintf("av01.0.%02dM.08", v.getCodecLevel(av1Levels).id)
+}
@@ -0,0 +1,31 @@
+package transcoder
+
+import "testing"
+
+func TestCodecsAttributeFromVariant(t *testing.T) {
+	tests := []struct {
+		codec     Codec
+		width     int
+		height    int
+		framerate int
+		bitrate   int
+		expected  string
+	}{
+		{&Libx264Codec{}, 640, 480, 30, 1200, "avc1.64001e"},
+		{&NvencCodec{}, 1920, 1080, 60, 6000, "avc1.64002a"},
+		{&Libx265Codec{}, 0, 0, 30, 3000, "hvc1.1.6.L120.B0"},
+		{&SvtAv1Codec{}, 1280, 0, 30, 2000, "av01.0.05M.08"},
+	}
+
+	for _, test := range tests {
+		variant := HLSVariant{}
+		variant.SetVideoScalingWidth(test.width)
+		variant.SetVideoScalingHeight(test.height)
+		variant.SetVideoFramerate(test.framerate)
+		variant.SetVideoBitrate(test.bitrate)
+
+		if attribute := test.codec.CodecsAttribute(&variant); attribute != test.expected {
+			t.Errorf("expected %s for %s %dx%d@%d, got %s", test.expected, test.codec.Name(), test.width, test.height, test.framerate, attribute)
+		}
+	}
+}
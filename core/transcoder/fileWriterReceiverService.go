@@ -79,9 +79,9 @@ func (s *FileWriterReceiverService) uploadHandler(w http.ResponseWriter, r *http
 }
 
 func (s *FileWriterReceiverService) fileWritten(path string) {
-	if utils.GetRelativePathFromAbsolutePath(path) == "hls/stream.m3u8" {
+	if isMasterPlaylist(path) {
 		s.callbacks.MasterPlaylistWritten(path)
-	} else if strings.HasSuffix(path, ".ts") {
+	} else if utils.IsHLSSegment(path) || utils.IsHLSInitSegment(path) {
 		s.callbacks.SegmentWritten(path)
 	} else if strings.HasSuffix(path, ".m3u8") {
 		s.callbacks.VariantPlaylistWritten(path)
@@ -1,25 +1,81 @@
 package transcoder
 
 import (
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/owncast/owncast/core/dash"
+	"github.com/owncast/owncast/core/llhls"
+	"github.com/owncast/owncast/core/recording"
 	"github.com/owncast/owncast/models"
 )
 
 // HLSHandler gets told about available HLS playlists and segments.
 type HLSHandler struct {
 	Storage models.StorageProvider
+
+	// The transcoder writing the playlists of each channel.
+	transcoders     map[string]*Transcoder
+	transcodersLock sync.Mutex
+}
+
+// SetChannelTranscoder will set the transcoder writing the playlists of a
+// channel.
+func (h *HLSHandler) SetChannelTranscoder(channelID string, t *Transcoder) {
+	h.transcodersLock.Lock()
+	defer h.transcodersLock.Unlock()
+
+	if h.transcoders == nil {
+		h.transcoders = make(map[string]*Transcoder)
+	}
+	h.transcoders[channelID] = t
+}
+
+func (h *HLSHandler) getChannelTranscoder(channelID string) *Transcoder {
+	h.transcodersLock.Lock()
+	defer h.transcodersLock.Unlock()
+
+	return h.transcoders[channelID]
 }
 
 // SegmentWritten is fired when a HLS segment is written to disk.
 func (h *HLSHandler) SegmentWritten(localFilePath string) {
+	// Recording and low latency playlists are only available to the default channel.
+	if getChannelIDFromFilePath(localFilePath) == models.DefaultChannelID {
+		// Recorded before the storage provider is able to remove the segment.
+		recording.SegmentWritten(localFilePath)
+		llhls.SegmentWritten(localFilePath)
+	}
 	h.Storage.SegmentWritten(localFilePath)
 }
 
 // VariantPlaylistWritten is fired when a HLS variant playlist is written to disk.
 func (h *HLSHandler) VariantPlaylistWritten(localFilePath string) {
+	if getChannelIDFromFilePath(localFilePath) != models.DefaultChannelID {
+		h.Storage.VariantPlaylistWritten(localFilePath)
+		return
+	}
+
+	recording.VariantPlaylistWritten(localFilePath)
+	llhls.VariantPlaylistWritten(localFilePath)
+	// Read before the storage provider is able to rewrite or remove the
+	// playlist.
+	manifestPath, manifestWritten := dash.VariantPlaylistWritten(localFilePath)
 	h.Storage.VariantPlaylistWritten(localFilePath)
+
+	if manifestWritten {
+		h.Storage.DASHManifestWritten(manifestPath)
+	}
 }
 
 // MasterPlaylistWritten is fired when a HLS master playlist is written to disk.
 func (h *HLSHandler) MasterPlaylistWritten(localFilePath string) {
+	if t := h.getChannelTranscoder(getChannelIDFromFilePath(localFilePath)); t != nil {
+		if err := setMasterPlaylistCodecs(localFilePath, t.getCodecsAttributes()); err != nil {
+			log.Warnln("unable to set master playlist codecs", err)
+		}
+	}
+
 	h.Storage.MasterPlaylistWritten(localFilePath)
 }
@@ -0,0 +1,39 @@
+package transcoder
+
+import (
+	"bufio"
+	"os"
+
+	"github.com/grafov/m3u8"
+
+	"github.com/owncast/owncast/core/playlist"
+	"github.com/owncast/owncast/utils"
+)
+
+// setMasterPlaylistCodecs will set the CODECS attribute of the variants in
+// the master playlist written by ffmpeg, which is not always able to
+// determine it for newer codecs.
+func setMasterPlaylistCodecs(localFilePath string, attributes map[string]string) error {
+	if len(attributes) == 0 {
+		return nil
+	}
+
+	f, err := os.Open(localFilePath) // nolint: gosec
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	p := m3u8.NewMasterPlaylist()
+	if err := p.DecodeFrom(bufio.NewReader(f), false); err != nil {
+		return err
+	}
+
+	for _, variant := range p.Variants {
+		if codecs, ok := attributes[utils.GetIndexFromFilePath(variant.URI)]; ok {
+			variant.Codecs = codecs
+		}
+	}
+
+	return playlist.WritePlaylist(p.String(), localFilePath)
+}
@@ -0,0 +1,59 @@
+package transcoder
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/utils"
+	"github.com/pkg/errors"
+)
+
+const (
+	// OfflineFMP4InitFilename is the initialization segment of the fMP4
+	// offline clip.
+	OfflineFMP4InitFilename = "offline-init.mp4"
+	// OfflineFMP4SegmentFilename is the media segment of the fMP4 offline
+	// clip.
+	OfflineFMP4SegmentFilename = "offline0.m4s"
+)
+
+// MakeFMP4OfflineClip will remux the MPEG-TS offline clip into an fMP4
+// initialization segment and media segment written to outputDirectory, so it
+// can be appended to fMP4 playlists.
+func MakeFMP4OfflineClip(sourceFile string, outputDirectory string) error {
+	ffmpegPath := utils.ValidatedFfmpegPath(data.GetFfMpegPath())
+	playlistFile := filepath.Join(outputDirectory, "offline.m3u8")
+
+	remuxFlags := []string{
+		ffmpegPath,
+		"-y",             // Overwrite files
+		"-i", sourceFile, // Input
+		"-c copy",                // Remux only
+		"-bsf:a aac_adtstoasc",   // MPEG-TS AAC to MP4 AAC
+		"-f hls",                 // format
+		"-hls_time 10",           // Longer than the clip so it is a single segment
+		"-hls_list_size 0",       // Keep every segment
+		"-hls_segment_type fmp4", // fMP4 segments
+		"-hls_fmp4_init_filename", OfflineFMP4InitFilename,
+		"-hls_segment_filename", filepath.Join(outputDirectory, "offline%d.m4s"),
+		playlistFile,
+	}
+
+	ffmpegCmd := strings.Join(remuxFlags, " ")
+	if output, err := exec.Command("sh", "-c", ffmpegCmd).CombinedOutput(); err != nil {
+		return errors.Wrap(err, "unable to remux offline clip to fmp4: "+string(output))
+	}
+
+	_ = os.Remove(playlistFile)
+
+	for _, file := range []string{OfflineFMP4InitFilename, OfflineFMP4SegmentFilename} {
+		if !utils.DoesFileExists(filepath.Join(outputDirectory, file)) {
+			return errors.New("remuxing the offline clip did not write " + file)
+		}
+	}
+
+	return nil
+}
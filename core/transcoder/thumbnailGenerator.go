@@ -60,8 +60,19 @@ func fireThumbnailGenerator(segmentPath string, variantIndex int) error {
 
 	var modTime time.Time
 	var names []string
+	var initModTime time.Time
+	initSegment := ""
 	for _, f := range files {
-		if path.Ext(f.Name()) != ".ts" {
+		// Use the init segment of the most recent stream.
+		if utils.IsHLSInitSegment(f.Name()) {
+			if fi, err := f.Info(); err == nil && fi.ModTime().After(initModTime) {
+				initModTime = fi.ModTime()
+				initSegment = f.Name()
+			}
+			continue
+		}
+
+		if !utils.IsHLSSegment(f.Name()) {
 			continue
 		}
 
@@ -86,6 +97,20 @@ func fireThumbnailGenerator(segmentPath string, variantIndex int) error {
 	}
 
 	mostRecentFile := path.Join(framePath, names[0])
+
+	// fMP4 segments are only able to be decoded along with their init segment.
+	if path.Ext(mostRecentFile) == ".m4s" {
+		if initSegment == "" {
+			return nil
+		}
+
+		sourceFile := path.Join(config.TempDir, "tempthumbnailsource.mp4")
+		if err := concatenateFiles(sourceFile, path.Join(framePath, initSegment), mostRecentFile); err != nil {
+			return err
+		}
+		defer os.Remove(sourceFile)
+		mostRecentFile = sourceFile
+	}
 	ffmpegPath := utils.ValidatedFfmpegPath(data.GetFfMpegPath())
 	outputFileTemp := path.Join(config.TempDir, "tempthumbnail.jpg")
 
@@ -138,3 +163,26 @@ func makeAnimatedGifPreview(sourceFile string, outputFile string) {
 		log.Errorln(err)
 	}
 }
+
+// concatenateFiles will write the contents of the source files, in order, to
+// the output file.
+func concatenateFiles(outputFile string, sourceFiles ...string) error {
+	output, err := os.Create(outputFile) // nolint: gosec
+	if err != nil {
+		return err
+	}
+	defer output.Close()
+
+	for _, sourceFile := range sourceFiles {
+		content, err := os.ReadFile(sourceFile) // nolint: gosec
+		if err != nil {
+			return err
+		}
+
+		if _, err := output.Write(content); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -4,7 +4,9 @@ import (
 	"bufio"
 	"fmt"
 	"io"
+	"math"
 	"os/exec"
+	"path"
 	"strconv"
 	"strings"
 
@@ -18,13 +20,12 @@ import (
 	"github.com/owncast/owncast/utils"
 )
 
-var _commandExec *exec.Cmd
-
 // Transcoder is a single instance of a video transcoder.
 type Transcoder struct {
 	codec Codec
 
-	stdin *io.PipeReader
+	stdin       *io.PipeReader
+	commandExec *exec.Cmd
 
 	TranscoderCompleted  func(error)
 	playlistOutputPath   string
@@ -33,10 +34,12 @@ type Transcoder struct {
 	internalListenerPort string
 	input                string
 	segmentOutputPath    string
+	channelID            string
 	variants             []HLSVariant
 
 	currentStreamOutputSettings []models.StreamOutputVariant
 	currentLatencyLevel         models.LatencyLevel
+	segmentFormat               models.SegmentFormat
 	appendToStream              bool
 	isEvent                     bool
 }
@@ -54,6 +57,8 @@ type HLSVariant struct {
 	cpuUsageLevel      int  // The amount of hardware to use for encoding a stream
 	isVideoPassthrough bool // Override all settings and just copy the video stream
 
+	codec Codec // The codec used for this variant instead of the transcoder's codec
+
 	isAudioPassthrough bool // Override all settings and just copy the audio stream
 }
 
@@ -104,7 +109,7 @@ func (v *VideoSize) getString() string {
 // Stop will stop the transcoder and kill all processing.
 func (t *Transcoder) Stop() {
 	log.Traceln("Transcoder STOP requested.")
-	err := _commandExec.Process.Kill()
+	err := t.commandExec.Process.Kill()
 	if err != nil {
 		log.Errorln(err)
 	}
@@ -118,24 +123,24 @@ func (t *Transcoder) Start(shouldLog bool) {
 	if shouldLog {
 		log.Infof("Processing video using codec %s with %d output qualities configured.", t.codec.DisplayName(), len(t.variants))
 	}
-	createVariantDirectories()
+	createVariantDirectories(t.channelID)
 
 	if config.EnableDebugFeatures {
 		log.Println(command)
 	}
 
-	_commandExec = exec.Command("sh", "-c", command)
+	t.commandExec = exec.Command("sh", "-c", command)
 
 	if t.stdin != nil {
-		_commandExec.Stdin = t.stdin
+		t.commandExec.Stdin = t.stdin
 	}
 
-	stdout, err := _commandExec.StderrPipe()
+	stdout, err := t.commandExec.StderrPipe()
 	if err != nil {
 		log.Fatalln(err)
 	}
 
-	if err := _commandExec.Start(); err != nil {
+	if err := t.commandExec.Start(); err != nil {
 		log.Errorln("Transcoder error. See", logging.GetTranscoderLogFilePath(), "for full output to debug.")
 		log.Panicln(err, command)
 	}
@@ -148,7 +153,7 @@ func (t *Transcoder) Start(shouldLog bool) {
 		}
 	}()
 
-	err = _commandExec.Wait()
+	err = t.commandExec.Wait()
 	if t.TranscoderCompleted != nil {
 		t.TranscoderCompleted(err)
 	}
@@ -163,6 +168,11 @@ func (t *Transcoder) SetLatencyLevel(level models.LatencyLevel) {
 	t.currentLatencyLevel = level
 }
 
+// SetSegmentFormat will set the container format of the segments written.
+func (t *Transcoder) SetSegmentFormat(format models.SegmentFormat) {
+	t.segmentFormat = format
+}
+
 // SetIsEvent will allow you to set a stream as an "event".
 func (t *Transcoder) SetIsEvent(isEvent bool) {
 	t.isEvent = isEvent
@@ -170,7 +180,7 @@ func (t *Transcoder) SetIsEvent(isEvent bool) {
 
 func (t *Transcoder) getString() string {
 	port := t.internalListenerPort
-	localListenerAddress := "http://127.0.0.1:" + port
+	localListenerAddress := "http://127.0.0.1:" + port + getChannelPathPrefix(t.channelID)
 
 	hlsOptionFlags := []string{
 		"program_date_time",
@@ -202,7 +212,7 @@ func (t *Transcoder) getString() string {
 		t.ffmpegPath,
 		"-hide_banner",
 		"-loglevel warning",
-		t.codec.GlobalFlags(),
+		t.getGlobalFlags(),
 		"-fflags +genpts", // Generate presentation time stamp if missing
 		"-flags +cgop",    // Force closed GOPs
 		"-i ", t.input,
@@ -212,21 +222,21 @@ func (t *Transcoder) getString() string {
 		// HLS Output
 		"-f", "hls",
 
-		"-hls_time", strconv.Itoa(t.currentLatencyLevel.SecondsPerSegment), // Length of each segment
-		"-hls_list_size", strconv.Itoa(t.currentLatencyLevel.SegmentCount), // Max # in variant playlist
+		"-hls_time", t.getHLSTime(), // Length of each segment
+		"-hls_list_size", strconv.Itoa(t.getHLSListSize()), // Max # in variant playlist
 		hlsOptionsString,
 		hlsEventString,
-		"-segment_format_options", "mpegts_flags=mpegts_copyts=1",
+		t.getSegmentFormatString(),
 
 		// Video settings
-		t.codec.ExtraArguments(),
+		t.getExtraArguments(),
 		"-pix_fmt", t.codec.PixelFormat(),
 		"-sc_threshold", "0", // Disable scene change detection for creating segments
 
 		// Filenames
 		"-master_pl_name", "stream.m3u8",
 
-		"-hls_segment_filename", localListenerAddress + "/%v/stream-" + t.segmentIdentifier + "-%d.ts", // Send HLS segments back to us over HTTP
+		"-hls_segment_filename", localListenerAddress + "/%v/stream-" + t.segmentIdentifier + "-%d" + t.getSegmentExtension(), // Send HLS segments back to us over HTTP
 		"-max_muxing_queue_size", "400", // Workaround for Too many packets error: https://trac.ffmpeg.org/ticket/6375?cversion=0
 
 		"-method PUT",                            // HLS results sent back to us will be over PUTs
@@ -236,6 +246,125 @@ func (t *Transcoder) getString() string {
 	return strings.Join(ffmpegFlags, " ")
 }
 
+// getSegmentFormat will return the container format of segments, which is
+// always fMP4 for codecs that require it.
+func (t *Transcoder) getSegmentFormat() models.SegmentFormat {
+	if codecRequiresFMP4(t.codec) {
+		return models.SegmentFormatFMP4
+	}
+
+	for i := range t.variants {
+		if !t.variants[i].isVideoPassthrough && codecRequiresFMP4(t.variants[i].getCodec(t)) {
+			return models.SegmentFormatFMP4
+		}
+	}
+
+	return t.segmentFormat
+}
+
+// hasMixedCodecs will return if variants are encoded with different codecs.
+func (t *Transcoder) hasMixedCodecs() bool {
+	for i := range t.variants {
+		if !t.variants[i].isVideoPassthrough && t.variants[i].getCodec(t).Name() != t.codec.Name() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getGlobalFlags will return the global flags of the codecs being used.
+func (t *Transcoder) getGlobalFlags() string {
+	if flags := t.codec.GlobalFlags(); flags != "" || !t.hasMixedCodecs() {
+		return flags
+	}
+
+	// Codecs that are able to be mixed only have global flags for a
+	// single one of them. See ValidateVariantCodecs.
+	for i := range t.variants {
+		if flags := t.variants[i].getCodec(t).GlobalFlags(); flags != "" && !t.variants[i].isVideoPassthrough {
+			return flags
+		}
+	}
+
+	return ""
+}
+
+// getExtraArguments will return the extra arguments of the transcoder's
+// codec, which are set per variant when codecs are mixed.
+func (t *Transcoder) getExtraArguments() string {
+	if t.hasMixedCodecs() {
+		return ""
+	}
+
+	return t.codec.ExtraArguments()
+}
+
+// scopeArgumentsToVariant will limit codec arguments, such as
+// "-tune zerolatency", to the video of a single variant.
+func scopeArgumentsToVariant(arguments string, index int) string {
+	fields := strings.Fields(arguments)
+	for i, field := range fields {
+		if strings.HasPrefix(field, "-") {
+			fields[i] = fmt.Sprintf("%s:v:%d", field, index)
+		}
+	}
+
+	return strings.Join(fields, " ")
+}
+
+// getSegmentFormatString will return the flags for the container format of segments.
+func (t *Transcoder) getSegmentFormatString() string {
+	if t.getSegmentFormat() == models.SegmentFormatFMP4 {
+		// The init segment is written alongside each variant playlist.
+		return "-hls_segment_type fmp4 -hls_fmp4_init_filename init-" + t.segmentIdentifier + "-%v.mp4"
+	}
+
+	return "-segment_format_options mpegts_flags=mpegts_copyts=1"
+}
+
+// getSegmentExtension will return the file extension of segments.
+func (t *Transcoder) getSegmentExtension() string {
+	if t.getSegmentFormat() == models.SegmentFormatFMP4 {
+		return ".m4s"
+	}
+
+	return ".ts"
+}
+
+// getCodecsAttributes will return the CODECS attribute of each variant
+// that is encoded by the transcoder.
+func (t *Transcoder) getCodecsAttributes() map[string]string {
+	attributes := make(map[string]string)
+	for i := range t.variants {
+		variant := &t.variants[i]
+		// ffmpeg determines the codec of passed through video itself.
+		if variant.isVideoPassthrough {
+			continue
+		}
+
+		attributes[strconv.Itoa(variant.index)] = variant.getCodec(t).CodecsAttribute(variant) + "," + aacCodecsAttribute
+	}
+
+	return attributes
+}
+
+// getHLSTime will return the length of each segment written by ffmpeg.
+// When using LL-HLS each segment written is a partial segment.
+func (t *Transcoder) getHLSTime() string {
+	return strconv.FormatFloat(t.currentLatencyLevel.GetSecondsPerPart(), 'f', -1, 64)
+}
+
+// getHLSListSize will return the number of segments in the variant playlists
+// written by ffmpeg.
+func (t *Transcoder) getHLSListSize() int {
+	if t.currentLatencyLevel.IsLowLatencyHLS() {
+		return t.currentLatencyLevel.SegmentCount * t.currentLatencyLevel.PartsPerSegment
+	}
+
+	return t.currentLatencyLevel.SegmentCount
+}
+
 func getVariantFromConfigQuality(quality models.StreamOutputVariant, index int) HLSVariant {
 	variant := HLSVariant{}
 	variant.index = index
@@ -261,6 +390,9 @@ func getVariantFromConfigQuality(quality models.StreamOutputVariant, index int)
 	// "superfast" and "ultrafast" are generally not recommended since they look bad.
 	// https://trac.ffmpeg.org/wiki/Encode/H.264
 	variant.cpuUsageLevel = quality.CPUUsageLevel
+	if quality.Codec != "" {
+		variant.codec = getCodec(quality.Codec)
+	}
 
 	variant.SetVideoBitrate(quality.VideoBitrate)
 	variant.SetAudioBitrate(strconv.Itoa(quality.AudioBitrate) + "k")
@@ -281,6 +413,7 @@ func NewTranscoder() *Transcoder {
 
 	transcoder.currentStreamOutputSettings = data.GetStreamOutputVariants()
 	transcoder.currentLatencyLevel = data.GetStreamLatencyLevel()
+	transcoder.segmentFormat = GetSegmentFormat()
 	transcoder.codec = getCodec(data.GetVideoCodec())
 	transcoder.segmentOutputPath = config.HLSStoragePath
 	transcoder.playlistOutputPath = config.HLSStoragePath
@@ -295,8 +428,18 @@ func NewTranscoder() *Transcoder {
 	return transcoder
 }
 
+// getCodec will return the codec used for this variant.
+func (v *HLSVariant) getCodec(t *Transcoder) Codec {
+	if v.codec != nil {
+		return v.codec
+	}
+
+	return t.codec
+}
+
 // Uses `map` https://www.ffmpeg.org/ffmpeg-all.html#Stream-specifiers-1 https://www.ffmpeg.org/ffmpeg-all.html#Advanced-options
 func (v *HLSVariant) getVariantString(t *Transcoder) string {
+	codec := v.getCodec(t)
 	variantEncoderCommands := []string{
 		v.getVideoQualityString(t),
 		v.getAudioQualityString(),
@@ -305,22 +448,27 @@ func (v *HLSVariant) getVariantString(t *Transcoder) string {
 	if (v.videoSize.Width != 0 || v.videoSize.Height != 0) && !v.isVideoPassthrough {
 		// Order here matters, you must scale before changing hardware formats
 		filters := []string{
-			v.getScalingString(t.codec.Scaler()),
+			v.getScalingString(codec.Scaler()),
 		}
-		if t.codec.ExtraFilters() != "" {
-			filters = append(filters, t.codec.ExtraFilters())
+		if codec.ExtraFilters() != "" {
+			filters = append(filters, codec.ExtraFilters())
 		}
 		scalingAlgorithm := "bilinear"
 		filterString := fmt.Sprintf("-sws_flags %s -filter:v:%d \"%s\"", scalingAlgorithm, v.index, strings.Join(filters, ","))
 		variantEncoderCommands = append(variantEncoderCommands, filterString)
-	} else if t.codec.ExtraFilters() != "" && !v.isVideoPassthrough {
-		filterString := fmt.Sprintf("-filter:v:%d \"%s\"", v.index, t.codec.ExtraFilters())
+	} else if codec.ExtraFilters() != "" && !v.isVideoPassthrough {
+		filterString := fmt.Sprintf("-filter:v:%d \"%s\"", v.index, codec.ExtraFilters())
 		variantEncoderCommands = append(variantEncoderCommands, filterString)
 	}
 
-	preset := t.codec.GetPresetForLevel(v.cpuUsageLevel)
+	preset := codec.GetPresetForLevel(v.cpuUsageLevel)
 	if preset != "" {
-		variantEncoderCommands = append(variantEncoderCommands, fmt.Sprintf("-preset %s", preset))
+		if t.hasMixedCodecs() {
+			// Each encoder has its own presets, so they only apply to this variant.
+			variantEncoderCommands = append(variantEncoderCommands, fmt.Sprintf("-preset:v:%d %s", v.index, preset))
+		} else {
+			variantEncoderCommands = append(variantEncoderCommands, fmt.Sprintf("-preset %s", preset))
+		}
 	}
 
 	return strings.Join(variantEncoderCommands, " ")
@@ -375,16 +523,28 @@ func (v *HLSVariant) getVideoQualityString(t *Transcoder) string {
 		return fmt.Sprintf("-map v:0 -c:v:%d copy", v.index)
 	}
 
-	gop := v.framerate * t.currentLatencyLevel.SecondsPerSegment // force an i-frame every segment
+	codec := v.getCodec(t)
+
+	// Force an i-frame every segment, or every partial segment when using LL-HLS.
+	gop := int(math.Ceil(float64(v.framerate) * t.currentLatencyLevel.GetSecondsPerPart()))
 	cmd := []string{
 		"-map v:0",
-		fmt.Sprintf("-c:v:%d %s", v.index, t.codec.Name()),                // Video codec used for this variant
+		fmt.Sprintf("-c:v:%d %s", v.index, codec.Name()),                  // Video codec used for this variant
 		fmt.Sprintf("-b:v:%d %dk", v.index, v.getAllocatedVideoBitrate()), // The average bitrate for this variant allowing space for audio
 		fmt.Sprintf("-maxrate:v:%d %dk", v.index, v.getMaxVideoBitrate()), // The max bitrate allowed for this variant
 		fmt.Sprintf("-g:v:%d %d", v.index, gop),                           // Suggested interval where i-frames are encoded into the segments
 		fmt.Sprintf("-keyint_min:v:%d %d", v.index, gop),                  // minimum i-keyframe interval
 		fmt.Sprintf("-r:v:%d %d", v.index, v.framerate),
-		t.codec.VariantFlags(v),
+		codec.VariantFlags(v),
+	}
+
+	// The arguments and pixel format of each encoder only apply to its own
+	// variants when encoders are mixed.
+	if t.hasMixedCodecs() {
+		cmd = append(cmd,
+			scopeArgumentsToVariant(codec.ExtraArguments(), v.index),
+			fmt.Sprintf("-pix_fmt:v:%d %s", v.index, codec.PixelFormat()),
+		)
 	}
 
 	return strings.Join(cmd, " ")
@@ -438,6 +598,19 @@ func (t *Transcoder) SetOutputPath(output string) {
 	t.segmentOutputPath = output
 }
 
+// SetChannel will set the channel being transcoded, writing its HLS tree
+// within the directory of the channel. Low latency HLS is only available to
+// the default channel, so other channels use the lowest regular latency.
+func (t *Transcoder) SetChannel(channelID string) {
+	t.channelID = channelID
+	t.segmentOutputPath = path.Join(config.HLSStoragePath, channelID)
+	t.playlistOutputPath = t.segmentOutputPath
+
+	if channelID != models.DefaultChannelID && t.currentLatencyLevel.IsLowLatencyHLS() {
+		t.currentLatencyLevel = models.GetLatencyLevel(0)
+	}
+}
+
 // SetIdentifier enables appending a unique identifier to segment file name.
 func (t *Transcoder) SetIdentifier(output string) {
 	t.segmentIdentifier = output
@@ -0,0 +1,73 @@
+package transcoder
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/owncast/owncast/models"
+)
+
+func TestFFmpegMixedCodecsCommand(t *testing.T) {
+	latencyLevel := models.GetLatencyLevel(2)
+	codec := Libx264Codec{}
+
+	transcoder := new(Transcoder)
+	transcoder.ffmpegPath = filepath.Join("fake", "path", "ffmpeg")
+	transcoder.SetInput("fakecontent.flv")
+	transcoder.SetOutputPath("fakeOutput")
+	transcoder.SetIdentifier("jdoieGi")
+	transcoder.SetInternalHTTPPort("8123")
+	transcoder.SetCodec(codec.Name())
+	transcoder.currentLatencyLevel = latencyLevel
+
+	variant := HLSVariant{}
+	variant.videoBitrate = 1200
+	variant.isAudioPassthrough = true
+	variant.SetVideoFramerate(30)
+	variant.SetCPUUsageLevel(2)
+	transcoder.AddVariant(variant)
+
+	variant2 := HLSVariant{}
+	variant2.videoBitrate = 3500
+	variant2.isAudioPassthrough = true
+	variant2.SetVideoFramerate(24)
+	variant2.SetCPUUsageLevel(4)
+	variant2.codec = getCodec((&SvtAv1Codec{}).Name())
+	transcoder.AddVariant(variant2)
+
+	cmd := transcoder.getString()
+
+	expectedLogPath := filepath.Join("data", "logs", "transcoder.log")
+	expected := `FFREPORT=file="` + expectedLogPath + `":level=32 ` + transcoder.ffmpegPath + ` -hide_banner -loglevel warning  -fflags +genpts -flags +cgop -i  fakecontent.flv  -map v:0 -c:v:0 libx264 -b:v:0 1008k -maxrate:v:0 1088k -g:v:0 90 -keyint_min:v:0 90 -r:v:0 30 -x264-params:v:0 "scenecut=0:open_gop=0" -bufsize:v:0 1088k -profile:v:0 high -tune:v:0 zerolatency -pix_fmt:v:0 yuv420p -map a:0? -c:a:0 copy -preset:v:0 veryfast -map v:0 -c:v:1 libsvtav1 -b:v:1 3308k -maxrate:v:1 3572k -g:v:1 72 -keyint_min:v:1 72 -r:v:1 24 -svtav1-params:v:1 "scd=0:fast-decode=1" -bufsize:v:1 3572k  -pix_fmt:v:1 yuv420p -map a:0? -c:a:1 copy -preset:v:1 8  -var_stream_map "v:0,a:0 v:1,a:1 " -f hls -hls_time 3 -hls_list_size 10 -hls_flags program_date_time+independent_segments+omit_endlist  -hls_segment_type fmp4 -hls_fmp4_init_filename init-jdoieGi-%v.mp4  -pix_fmt yuv420p -sc_threshold 0 -master_pl_name stream.m3u8 -hls_segment_filename http://127.0.0.1:8123/%v/stream-jdoieGi-%d.m4s -max_muxing_queue_size 400 -method PUT http://127.0.0.1:8123/%v/stream.m3u8`
+
+	if cmd != expected {
+		t.Errorf("ffmpeg command does not match expected.\nGot %s\n, want: %s", cmd, expected)
+	}
+}
+
+func TestValidateVariantCodecs(t *testing.T) {
+	available := []string{"libx264", "libsvtav1", "h264_vaapi", "h264_nvenc"}
+
+	tests := []struct {
+		codecs []string
+		valid  bool
+	}{
+		{[]string{"", "libsvtav1"}, true},
+		{[]string{"", "h264_nvenc"}, true},
+		{[]string{"", "libx265"}, false},
+		{[]string{"", "h264_vaapi"}, false},
+		{[]string{"unknown"}, false},
+	}
+
+	for _, test := range tests {
+		variants := []models.StreamOutputVariant{}
+		for _, codec := range test.codecs {
+			variants = append(variants, models.StreamOutputVariant{Codec: codec})
+		}
+
+		err := ValidateVariantCodecs(variants, "libx264", available)
+		if (err == nil) != test.valid {
+			t.Errorf("codecs %v: got error %v, want valid %t", test.codecs, err, test.valid)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package transcoder
 
 import (
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/owncast/owncast/models"
@@ -48,3 +49,54 @@ func TestFFmpegx264Command(t *testing.T) {
 		t.Errorf("ffmpeg command does not match expected.\nGot %s\n, want: %s", cmd, expected)
 	}
 }
+
+func TestFFmpegFMP4Segments(t *testing.T) {
+	transcoder := new(Transcoder)
+	transcoder.ffmpegPath = filepath.Join("fake", "path", "ffmpeg")
+	transcoder.SetInput("fakecontent.flv")
+	transcoder.SetIdentifier("jdofFGg")
+	transcoder.SetInternalHTTPPort("8123")
+	transcoder.SetCodec((&Libx264Codec{}).Name())
+	transcoder.SetLatencyLevel(models.GetLatencyLevel(2))
+	transcoder.SetSegmentFormat(models.SegmentFormatFMP4)
+	transcoder.AddVariant(HLSVariant{isAudioPassthrough: true, isVideoPassthrough: true})
+
+	cmd := transcoder.getString()
+
+	for _, flag := range []string{
+		"-hls_segment_type fmp4 -hls_fmp4_init_filename init-jdofFGg-%v.mp4",
+		"-hls_segment_filename http://127.0.0.1:8123/%v/stream-jdofFGg-%d.m4s",
+	} {
+		if !strings.Contains(cmd, flag) {
+			t.Errorf("ffmpeg command is missing %q:\n%s", flag, cmd)
+		}
+	}
+
+	if strings.Contains(cmd, "mpegts_flags") {
+		t.Errorf("ffmpeg command should not set MPEG-TS options for fMP4 segments:\n%s", cmd)
+	}
+}
+
+func TestFFmpegChannelOutput(t *testing.T) {
+	transcoder := new(Transcoder)
+	transcoder.ffmpegPath = filepath.Join("fake", "path", "ffmpeg")
+	transcoder.SetInput("fakecontent.flv")
+	transcoder.SetIdentifier("jdofFGg")
+	transcoder.SetInternalHTTPPort("8123")
+	transcoder.SetCodec((&Libx264Codec{}).Name())
+	transcoder.SetLatencyLevel(models.GetLatencyLevel(models.LowLatencyHLSLevel))
+	transcoder.SetChannel("second")
+	transcoder.AddVariant(HLSVariant{isAudioPassthrough: true, isVideoPassthrough: true})
+
+	cmd := transcoder.getString()
+
+	for _, flag := range []string{
+		"-hls_segment_filename http://127.0.0.1:8123/second/%v/stream-jdofFGg-%d.ts",
+		"-method PUT http://127.0.0.1:8123/second/%v/stream.m3u8",
+		"-hls_time 1 -hls_list_size 25",
+	} {
+		if !strings.Contains(cmd, flag) {
+			t.Errorf("ffmpeg command is missing %q:\n%s", flag, cmd)
+		}
+	}
+}
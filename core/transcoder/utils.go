@@ -3,12 +3,14 @@ package transcoder
 import (
 	"os"
 	"path"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"sync"
 
 	"github.com/owncast/owncast/config"
 	"github.com/owncast/owncast/core/data"
+	"github.com/owncast/owncast/models"
 	"github.com/owncast/owncast/utils"
 	log "github.com/sirupsen/logrus"
 )
@@ -96,18 +98,79 @@ func handleTranscoderMessage(message string) {
 	_lastTranscoderLogMessage = message
 }
 
-func createVariantDirectories() {
+// getChannelPathPrefix will return the path of a channel's HLS tree relative
+// to the root of the HLS tree.
+func getChannelPathPrefix(channelID string) string {
+	if channelID == models.DefaultChannelID {
+		return ""
+	}
+
+	return "/" + channelID
+}
+
+// getChannelIDFromFilePath will return the channel an HLS file written to
+// disk belongs to.
+func getChannelIDFromFilePath(filePath string) string {
+	relativePath, err := filepath.Rel(config.HLSStoragePath, filePath)
+	if err != nil {
+		return models.DefaultChannelID
+	}
+
+	return models.GetChannelIDFromHLSPath(filepath.ToSlash(relativePath))
+}
+
+// isMasterPlaylist will return if a file is the master playlist written to
+// the root of the HLS tree of a channel.
+func isMasterPlaylist(filePath string) bool {
+	relativePath, err := filepath.Rel(config.HLSStoragePath, filePath)
+	if err != nil || filepath.Base(relativePath) != "stream.m3u8" {
+		return false
+	}
+
+	dir := filepath.Dir(relativePath)
+	return dir == "." || models.IsValidChannelID(dir)
+}
+
+// CleanupHLSDirectory will remove the HLS files of a channel. The other
+// channels are stored within the directory of the default channel, so they
+// are left in place when cleaning it.
+func CleanupHLSDirectory(channelID string) {
+	dir := path.Join(config.HLSStoragePath, channelID)
+	if channelID != models.DefaultChannelID {
+		utils.CleanupDirectory(dir)
+		return
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		utils.CleanupDirectory(dir)
+		return
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() && models.IsValidChannelID(entry.Name()) {
+			continue
+		}
+
+		if err := os.RemoveAll(path.Join(dir, entry.Name())); err != nil {
+			log.Fatalln("Unable to remove directory. Please check the ownership and permissions", err)
+		}
+	}
+}
+
+func createVariantDirectories(channelID string) {
 	// Create private hls data dirs
-	utils.CleanupDirectory(config.HLSStoragePath)
+	CleanupHLSDirectory(channelID)
+	dir := path.Join(config.HLSStoragePath, channelID)
 
 	if len(data.GetStreamOutputVariants()) != 0 {
 		for index := range data.GetStreamOutputVariants() {
-			if err := os.MkdirAll(path.Join(config.HLSStoragePath, strconv.Itoa(index)), 0o750); err != nil {
+			if err := os.MkdirAll(path.Join(dir, strconv.Itoa(index)), 0o750); err != nil {
 				log.Fatalln(err)
 			}
 		}
 	} else {
-		dir := path.Join(config.HLSStoragePath, strconv.Itoa(0))
+		dir := path.Join(dir, strconv.Itoa(0))
 		log.Traceln("Creating", dir)
 		if err := os.MkdirAll(dir, 0o750); err != nil {
 			log.Fatalln(err)
@@ -27,8 +27,11 @@ const (
 
 // User represents a single chat user.
 type User struct {
-	CreatedAt       time.Time  `json:"createdAt"`
-	DisabledAt      *time.Time `json:"disabledAt,omitempty"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	DisabledAt *time.Time `json:"disabledAt,omitempty"`
+	// DisabledUntil is when a temporarily disabled user is re-enabled. Nil
+	// when the user is disabled permanently.
+	DisabledUntil   *time.Time `json:"disabledUntil,omitempty"`
 	NameChangedAt   *time.Time `json:"nameChangedAt,omitempty"`
 	AuthenticatedAt *time.Time `json:"-"`
 	ID              string     `json:"id"`
@@ -42,7 +45,7 @@ type User struct {
 
 // IsEnabled will return if this single user is enabled.
 func (u *User) IsEnabled() bool {
-	return u.DisabledAt == nil
+	return u.DisabledAt == nil || (u.DisabledUntil != nil && time.Now().After(*u.DisabledUntil))
 }
 
 // IsModerator will return if the user has moderation privileges.
@@ -198,9 +201,9 @@ func SetEnabled(userID string, enabled bool) error {
 
 	var stmt *sql.Stmt
 	if !enabled {
-		stmt, err = tx.Prepare("UPDATE users SET disabled_at=DATETIME('now', 'localtime') WHERE id IS ?")
+		stmt, err = tx.Prepare("UPDATE users SET disabled_at=DATETIME('now', 'localtime'), disabled_until=null WHERE id IS ?")
 	} else {
-		stmt, err = tx.Prepare("UPDATE users SET disabled_at=null WHERE id IS ?")
+		stmt, err = tx.Prepare("UPDATE users SET disabled_at=null, disabled_until=null WHERE id IS ?")
 	}
 
 	if err != nil {
@@ -216,6 +219,126 @@ func SetEnabled(userID string, enabled bool) error {
 	return tx.Commit()
 }
 
+// SetDisabledUntil will disable a single user by ID until a point in time,
+// after which EnableExpiredUsers will re-enable them.
+func SetDisabledUntil(userID string, until time.Time) error {
+	_datastore.DbLock.Lock()
+	defer _datastore.DbLock.Unlock()
+
+	tx, err := _datastore.DB.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback() //nolint
+
+	stmt, err := tx.Prepare("UPDATE users SET disabled_at=DATETIME('now', 'localtime'), disabled_until=? WHERE id IS ?")
+	if err != nil {
+		return err
+	}
+
+	defer stmt.Close()
+
+	if _, err := stmt.Exec(until, userID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// SetTimedOutUntil will stop a user from sending chat messages until a
+// point in time.
+func SetTimedOutUntil(userID string, until time.Time) error {
+	_datastore.DbLock.Lock()
+	defer _datastore.DbLock.Unlock()
+
+	_, err := _datastore.DB.Exec("UPDATE users SET timed_out_until=? WHERE id IS ?", until, userID)
+	return err
+}
+
+// GetTimedOutUsers will return when each user that is still timed out is
+// allowed to send chat messages again.
+func GetTimedOutUsers() (map[string]time.Time, error) {
+	rows, err := _datastore.DB.Query("SELECT id, timed_out_until FROM users WHERE timed_out_until IS NOT NULL")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	timeouts := map[string]time.Time{}
+	for rows.Next() {
+		var id string
+		var timedOutUntil time.Time
+		if err := rows.Scan(&id, &timedOutUntil); err != nil {
+			return nil, err
+		}
+
+		if time.Now().Before(timedOutUntil) {
+			timeouts[id] = timedOutUntil
+		}
+	}
+
+	return timeouts, rows.Err()
+}
+
+// SetFirstMessageSent will record when a user first sent a chat message,
+// unless they already have.
+func SetFirstMessageSent(userID string, sentAt time.Time) error {
+	_datastore.DbLock.Lock()
+	defer _datastore.DbLock.Unlock()
+
+	_, err := _datastore.DB.Exec("UPDATE users SET first_message_at=? WHERE id IS ? AND first_message_at IS NULL", sentAt, userID)
+	return err
+}
+
+// HasSentMessage will return if a user has ever sent a chat message.
+func HasSentMessage(userID string) (bool, error) {
+	var firstMessageAt *time.Time
+	if err := _datastore.DB.QueryRow("SELECT first_message_at FROM users WHERE id IS ?", userID).Scan(&firstMessageAt); err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return firstMessageAt != nil, nil
+}
+
+// EnableExpiredUsers will re-enable the temporarily disabled users whose
+// time has passed and return their IDs.
+func EnableExpiredUsers() ([]string, error) {
+	rows, err := _datastore.DB.Query("SELECT id, disabled_until FROM users WHERE disabled_at IS NOT NULL AND disabled_until IS NOT NULL")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	expired := []string{}
+	for rows.Next() {
+		var id string
+		var disabledUntil time.Time
+		if err := rows.Scan(&id, &disabledUntil); err != nil {
+			return nil, err
+		}
+
+		if time.Now().After(disabledUntil) {
+			expired = append(expired, id)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	for _, id := range expired {
+		if err := SetEnabled(id, true); err != nil {
+			return nil, err
+		}
+	}
+
+	return expired, nil
+}
+
 // GetUserByToken will return a user by an access token.
 func GetUserByToken(token string) *User {
 	u, err := _datastore.GetQueries().GetUserByAccessToken(context.Background(), token)
@@ -233,6 +356,11 @@ func GetUserByToken(token string) *User {
 		disabledAt = &u.DisabledAt.Time
 	}
 
+	var disabledUntil *time.Time
+	if u.DisabledUntil.Valid {
+		disabledUntil = &u.DisabledUntil.Time
+	}
+
 	var authenticatedAt *time.Time
 	if u.AuthenticatedAt.Valid {
 		authenticatedAt = &u.AuthenticatedAt.Time
@@ -244,6 +372,7 @@ func GetUserByToken(token string) *User {
 		DisplayColor:    int(u.DisplayColor),
 		CreatedAt:       u.CreatedAt.Time,
 		DisabledAt:      disabledAt,
+		DisabledUntil:   disabledUntil,
 		PreviousNames:   strings.Split(u.PreviousNames.String, ","),
 		NameChangedAt:   &u.NamechangedAt.Time,
 		AuthenticatedAt: authenticatedAt,
@@ -340,7 +469,7 @@ func GetUserByID(id string) *User {
 	_datastore.DbLock.Lock()
 	defer _datastore.DbLock.Unlock()
 
-	query := "SELECT id, display_name, display_color, created_at, disabled_at, previous_names, namechanged_at, scopes FROM users WHERE id = ?"
+	query := "SELECT id, display_name, display_color, created_at, disabled_at, disabled_until, previous_names, namechanged_at, scopes FROM users WHERE id = ?"
 	row := _datastore.DB.QueryRow(query, id)
 	if row == nil {
 		log.Errorln(row)
@@ -351,7 +480,7 @@ func GetUserByID(id string) *User {
 
 // GetDisabledUsers will return back all the currently disabled users that are not API users.
 func GetDisabledUsers() []*User {
-	query := "SELECT id, display_name, scopes, display_color, created_at, disabled_at, previous_names, namechanged_at FROM users WHERE disabled_at IS NOT NULL AND type IS NOT 'API'"
+	query := "SELECT id, display_name, scopes, display_color, created_at, disabled_at, disabled_until, previous_names, namechanged_at FROM users WHERE disabled_at IS NOT NULL AND type IS NOT 'API'"
 
 	rows, err := _datastore.DB.Query(query)
 	if err != nil {
@@ -371,16 +500,16 @@ func GetDisabledUsers() []*User {
 
 // GetModeratorUsers will return a list of users with moderator access.
 func GetModeratorUsers() []*User {
-	query := `SELECT id, display_name, scopes, display_color, created_at, disabled_at, previous_names, namechanged_at FROM (
-		WITH RECURSIVE split(id, display_name, scopes, display_color, created_at, disabled_at, previous_names, namechanged_at, scope, rest) AS (
-		  SELECT id, display_name, scopes, display_color, created_at, disabled_at, previous_names, namechanged_at, '', scopes || ',' FROM users
+	query := `SELECT id, display_name, scopes, display_color, created_at, disabled_at, disabled_until, previous_names, namechanged_at FROM (
+		WITH RECURSIVE split(id, display_name, scopes, display_color, created_at, disabled_at, disabled_until, previous_names, namechanged_at, scope, rest) AS (
+		  SELECT id, display_name, scopes, display_color, created_at, disabled_at, disabled_until, previous_names, namechanged_at, '', scopes || ',' FROM users
 		   UNION ALL
-		  SELECT id, display_name, scopes, display_color, created_at, disabled_at, previous_names, namechanged_at,
+		  SELECT id, display_name, scopes, display_color, created_at, disabled_at, disabled_until, previous_names, namechanged_at,
 				 substr(rest, 0, instr(rest, ',')),
 				 substr(rest, instr(rest, ',')+1)
 			FROM split
 		   WHERE rest <> '')
-		SELECT id, display_name, scopes, display_color, created_at, disabled_at, previous_names, namechanged_at, scope
+		SELECT id, display_name, scopes, display_color, created_at, disabled_at, disabled_until, previous_names, namechanged_at, scope
 		  FROM split
 		 WHERE scope <> ''
 		 ORDER BY created_at
@@ -407,11 +536,12 @@ func getUsersFromRows(rows *sql.Rows) []*User {
 		var displayColor int
 		var createdAt time.Time
 		var disabledAt *time.Time
+		var disabledUntil *time.Time
 		var previousUsernames string
 		var userNameChangedAt *time.Time
 		var scopesString *string
 
-		if err := rows.Scan(&id, &displayName, &scopesString, &displayColor, &createdAt, &disabledAt, &previousUsernames, &userNameChangedAt); err != nil {
+		if err := rows.Scan(&id, &displayName, &scopesString, &displayColor, &createdAt, &disabledAt, &disabledUntil, &previousUsernames, &userNameChangedAt); err != nil {
 			log.Errorln("error creating collection of users from results", err)
 			return nil
 		}
@@ -427,6 +557,7 @@ func getUsersFromRows(rows *sql.Rows) []*User {
 			DisplayColor:  displayColor,
 			CreatedAt:     createdAt,
 			DisabledAt:    disabledAt,
+			DisabledUntil: disabledUntil,
 			PreviousNames: strings.Split(previousUsernames, ","),
 			NameChangedAt: userNameChangedAt,
 			Scopes:        scopes,
@@ -447,11 +578,12 @@ func getUserFromRow(row *sql.Row) *User {
 	var displayColor int
 	var createdAt time.Time
 	var disabledAt *time.Time
+	var disabledUntil *time.Time
 	var previousUsernames string
 	var userNameChangedAt *time.Time
 	var scopesString *string
 
-	if err := row.Scan(&id, &displayName, &displayColor, &createdAt, &disabledAt, &previousUsernames, &userNameChangedAt, &scopesString); err != nil {
+	if err := row.Scan(&id, &displayName, &displayColor, &createdAt, &disabledAt, &disabledUntil, &previousUsernames, &userNameChangedAt, &scopesString); err != nil {
 		return nil
 	}
 
@@ -466,6 +598,7 @@ func getUserFromRow(row *sql.Row) *User {
 		DisplayColor:  displayColor,
 		CreatedAt:     createdAt,
 		DisabledAt:    disabledAt,
+		DisabledUntil: disabledUntil,
 		PreviousNames: strings.Split(previousUsernames, ","),
 		NameChangedAt: userNameChangedAt,
 		Scopes:        scopes,
@@ -0,0 +1,95 @@
+package user
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTemporarilyDisabledUser(t *testing.T) {
+	u, _, err := CreateAnonymousUser("temporarily disabled")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := SetDisabledUntil(u.ID, time.Now().Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+
+	disabled := GetUserByID(u.ID)
+	if disabled.IsEnabled() || disabled.DisabledUntil == nil {
+		t.Fatal("expected user to be disabled with an expiry")
+	}
+
+	// Nothing has expired yet.
+	if enabled, err := EnableExpiredUsers(); err != nil || len(enabled) != 0 {
+		t.Fatalf("expected no users to be re-enabled, got %v %v", enabled, err)
+	}
+
+	if err := SetDisabledUntil(u.ID, time.Now().Add(-time.Second)); err != nil {
+		t.Fatal(err)
+	}
+
+	enabled, err := EnableExpiredUsers()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(enabled) != 1 || enabled[0] != u.ID {
+		t.Fatalf("expected %s to be re-enabled, got %v", u.ID, enabled)
+	}
+
+	if reenabled := GetUserByID(u.ID); !reenabled.IsEnabled() || reenabled.DisabledUntil != nil {
+		t.Error("expected user to be enabled after their disable expired")
+	}
+}
+
+func TestHasSentMessage(t *testing.T) {
+	u, _, err := CreateAnonymousUser("first message")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if sent, err := HasSentMessage(u.ID); err != nil || sent {
+		t.Fatalf("expected a new user not to have sent a message, got %v %v", sent, err)
+	}
+
+	if err := SetFirstMessageSent(u.ID, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+
+	if sent, err := HasSentMessage(u.ID); err != nil || !sent {
+		t.Fatalf("expected the user to have sent a message, got %v %v", sent, err)
+	}
+}
+
+func TestTimedOutUser(t *testing.T) {
+	u, _, err := CreateAnonymousUser("timed out")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	until := time.Now().Add(time.Hour)
+	if err := SetTimedOutUntil(u.ID, until); err != nil {
+		t.Fatal(err)
+	}
+
+	timeouts, err := GetTimedOutUsers()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if timedOutUntil, timedOut := timeouts[u.ID]; !timedOut || !timedOutUntil.Equal(until) {
+		t.Fatalf("expected the user to be timed out until %v, got %v", until, timeouts)
+	}
+
+	if err := SetTimedOutUntil(u.ID, time.Now().Add(-time.Second)); err != nil {
+		t.Fatal(err)
+	}
+
+	timeouts, err = GetTimedOutUsers()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, timedOut := timeouts[u.ID]; timedOut {
+		t.Fatalf("expected an expired timeout not to be returned, got %v", timeouts)
+	}
+}
@@ -15,9 +15,60 @@ func SendChatEvent(chatEvent *events.UserMessageEvent) {
 			ClientID:  chatEvent.ClientID,
 			RawBody:   chatEvent.RawBody,
 			ID:        chatEvent.ID,
+			ReplyTo:   chatEvent.ReplyTo,
 			Visible:   chatEvent.HiddenAt == nil,
 			Timestamp: &chatEvent.Timestamp,
 		},
+		ChannelID: chatEvent.ChannelID,
+		RoomID:    chatEvent.RoomID,
+	}
+
+	SendEventToWebhooks(webhookEvent)
+}
+
+// SendChatEventReaction will send a chat message reaction event to webhook destinations.
+func SendChatEventReaction(event events.ReactionEvent) {
+	webhookEvent := WebhookEvent{
+		Type:      models.MessageReaction,
+		EventData: event,
+		ChannelID: event.ChannelID,
+		RoomID:    event.RoomID,
+	}
+
+	SendEventToWebhooks(webhookEvent)
+}
+
+// SendChatEventMessageEdited will send a chat message edited event to webhook destinations.
+func SendChatEventMessageEdited(event events.MessageEditedEvent) {
+	webhookEvent := WebhookEvent{
+		Type:      models.MessageEdited,
+		EventData: event,
+		ChannelID: event.ChannelID,
+		RoomID:    event.RoomID,
+	}
+
+	SendEventToWebhooks(webhookEvent)
+}
+
+// SendChatEventMessageDeleted will send a chat message deleted event to webhook destinations.
+func SendChatEventMessageDeleted(event events.MessageDeletedEvent) {
+	webhookEvent := WebhookEvent{
+		Type:      models.MessageDeleted,
+		EventData: event,
+		ChannelID: event.ChannelID,
+		RoomID:    event.RoomID,
+	}
+
+	SendEventToWebhooks(webhookEvent)
+}
+
+// SendAbuseReportThresholdReached will send an alert about a chat message
+// that has been reported by enough users to webhook destinations.
+func SendAbuseReportThresholdReached(alert WebhookAbuseReportAlert, channelID string) {
+	webhookEvent := WebhookEvent{
+		Type:      models.AbuseReportThresholdReached,
+		EventData: alert,
+		ChannelID: channelID,
 	}
 
 	SendEventToWebhooks(webhookEvent)
@@ -38,6 +89,7 @@ func SendChatEventUserJoined(event events.UserJoinedEvent) {
 	webhookEvent := WebhookEvent{
 		Type:      models.UserJoined,
 		EventData: event,
+		ChannelID: event.ChannelID,
 	}
 
 	SendEventToWebhooks(webhookEvent)
@@ -48,6 +100,7 @@ func SendChatEventUserParted(event events.UserPartEvent) {
 	webhookEvent := WebhookEvent{
 		Type:      events.UserParted,
 		EventData: event,
+		ChannelID: event.ChannelID,
 	}
 
 	SendEventToWebhooks(webhookEvent)
@@ -13,15 +13,31 @@ func SendStreamStatusEvent(eventType models.EventType) {
 	sendStreamStatusEvent(eventType, shortid.MustGenerate(), time.Now())
 }
 
+// SendChannelStreamStatusEvent will send the webhook destinations of a
+// channel the current stream status of the channel.
+func SendChannelStreamStatusEvent(channelID string, eventType models.EventType) {
+	sendChannelStreamStatusEvent(channelID, eventType, shortid.MustGenerate(), time.Now())
+}
+
 func sendStreamStatusEvent(eventType models.EventType, id string, timestamp time.Time) {
+	sendChannelStreamStatusEvent(models.DefaultChannelID, eventType, id, timestamp)
+}
+
+func sendChannelStreamStatusEvent(channelID string, eventType models.EventType, id string, timestamp time.Time) {
+	name := data.GetServerName()
+	if channel, ok := data.GetChannel(channelID); ok && channel.Name != "" {
+		name = channel.Name
+	}
+
 	SendEventToWebhooks(WebhookEvent{
-		Type: eventType,
+		Type:      eventType,
+		ChannelID: channelID,
 		EventData: map[string]interface{}{
 			"id":          id,
-			"name":        data.GetServerName(),
+			"name":        name,
 			"summary":     data.GetServerSummary(),
-			"streamTitle": data.GetStreamTitle(),
-			"status":      getStatus(),
+			"streamTitle": data.GetChannelStreamTitle(channelID),
+			"status":      getStatus(channelID),
 			"timestamp":   timestamp,
 		},
 	})
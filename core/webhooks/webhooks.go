@@ -13,6 +13,12 @@ import (
 type WebhookEvent struct {
 	EventData interface{}      `json:"eventData,omitempty"`
 	Type      models.EventType `json:"type"` // messageSent | userJoined | userNameChange
+	// ChannelID is the channel the event took place in. Empty for the
+	// default channel.
+	ChannelID string `json:"channelId,omitempty"`
+	// RoomID is the chat room of the channel the event took place in. Empty
+	// for the main chat.
+	RoomID string `json:"roomId,omitempty"`
 }
 
 // WebhookChatMessage represents a single chat message sent as a webhook payload.
@@ -22,10 +28,20 @@ type WebhookChatMessage struct {
 	Body      string     `json:"body,omitempty"`
 	RawBody   string     `json:"rawBody,omitempty"`
 	ID        string     `json:"id,omitempty"`
+	ReplyTo   string     `json:"replyTo,omitempty"`
 	ClientID  uint       `json:"clientId,omitempty"`
 	Visible   bool       `json:"visible"`
 }
 
+// WebhookAbuseReportAlert is sent when a chat message has been reported by
+// enough users to alert the moderators.
+type WebhookAbuseReportAlert struct {
+	User        *user.User `json:"user,omitempty"`
+	MessageID   string     `json:"messageId"`
+	Body        string     `json:"body"`
+	ReportCount int        `json:"reportCount"`
+}
+
 // SendEventToWebhooks will send a single webhook event to all webhook destinations.
 func SendEventToWebhooks(payload WebhookEvent) {
 	sendEventToWebhooks(payload, nil)
@@ -35,6 +51,11 @@ func sendEventToWebhooks(payload WebhookEvent, wg *sync.WaitGroup) {
 	webhooks := data.GetWebhooksForEvent(payload.Type)
 
 	for _, webhook := range webhooks {
+		// Webhooks are only sent the events of their own channel.
+		if webhook.Channel != payload.ChannelID {
+			continue
+		}
+
 		// Use wg to track the number of notifications to be sent.
 		if wg != nil {
 			wg.Add(1)
@@ -18,7 +18,7 @@ import (
 	jsonpatch "gopkg.in/evanphx/json-patch.v5"
 )
 
-func fakeGetStatus() models.Status {
+func fakeGetStatus(channelID string) models.Status {
 	return models.Status{
 		Online:                true,
 		ViewerCount:           5,
@@ -25,11 +25,11 @@ type Job struct {
 
 var (
 	queue     chan Job
-	getStatus func() models.Status
+	getStatus func(channelID string) models.Status
 )
 
-// SetupWebhooks initializes the webhook worker pool and sets the function to get the current status.
-func SetupWebhooks(getStatusFunc func() models.Status) {
+// SetupWebhooks initializes the webhook worker pool and sets the function to get the current status of a channel.
+func SetupWebhooks(getStatusFunc func(channelID string) models.Status) {
 	getStatus = getStatusFunc
 	initWorkerPool()
 }
@@ -50,6 +50,8 @@ type IpBan struct {
 	IpAddress string
 	Notes     sql.NullString
 	CreatedAt sql.NullTime
+	ExpiresAt sql.NullTime
+	UserID    sql.NullString
 }
 
 type Message struct {
@@ -63,6 +65,10 @@ type Message struct {
 	Subtitle  sql.NullString
 	Image     sql.NullString
 	Link      sql.NullString
+	Channel   string
+	ReplyTo   sql.NullString
+	EditedAt  sql.NullTime
+	DeletedAt sql.NullTime
 }
 
 type Notification struct {
@@ -72,6 +78,15 @@ type Notification struct {
 	CreatedAt   sql.NullTime
 }
 
+type Recording struct {
+	ID           string
+	Title        sql.NullString
+	VariantIndex int32
+	Visibility   string
+	StartedAt    time.Time
+	EndedAt      sql.NullTime
+}
+
 type User struct {
 	ID              string
 	DisplayName     string
@@ -84,6 +99,9 @@ type User struct {
 	AuthenticatedAt sql.NullTime
 	Type            sql.NullString
 	LastUsed        interface{}
+	DisabledUntil   sql.NullTime
+	FirstMessageAt  sql.NullTime
+	TimedOutUntil   sql.NullTime
 }
 
 type UserAccessToken struct {
@@ -58,13 +58,18 @@ SELECT count(*) FROM ap_accepted_activities WHERE iri = $1 AND actor = $2 AND TY
 UPDATE ap_followers SET inbox = $1, name = $2, username = $3, image = $4 WHERE iri = $5;
 
 -- name: BanIPAddress :exec
-INSERT INTO ip_bans(ip_address, notes) values($1, $2);
+INSERT INTO ip_bans(ip_address, notes, expires_at, user_id) values($1, $2, $3, $4)
+  ON CONFLICT(ip_address) DO UPDATE SET notes = excluded.notes, user_id = excluded.user_id,
+  expires_at = CASE WHEN ip_bans.expires_at IS NULL OR excluded.expires_at IS NULL THEN NULL ELSE MAX(ip_bans.expires_at, excluded.expires_at) END;
 
 -- name: RemoveIPAddressBan :exec
 DELETE FROM ip_bans WHERE ip_address = $1;
 
--- name: IsIPAddressBlocked :one
-SELECT count(*) FROM ip_bans WHERE ip_address = $1;
+-- name: RemoveIPAddressBansForUser :exec
+DELETE FROM ip_bans WHERE user_id = $1;
+
+-- name: GetIPAddressBanExpiry :one
+SELECT expires_at FROM ip_bans WHERE ip_address = $1;
 
 -- name: GetIPAddressBans :many
 SELECT * FROM ip_bans;
@@ -86,7 +91,7 @@ SELECT users.id, display_name, display_color, users.created_at, disabled_at, pre
 INSERT INTO user_access_tokens(token, user_id) values($1, $2);
 
 -- name: GetUserByAccessToken :one
-SELECT users.id, display_name, display_color, users.created_at, disabled_at, previous_names, namechanged_at, authenticated_at, scopes FROM users, user_access_tokens WHERE token = $1 AND users.id = user_id;
+SELECT users.id, display_name, display_color, users.created_at, disabled_at, disabled_until, previous_names, namechanged_at, authenticated_at, scopes FROM users, user_access_tokens WHERE token = $1 AND users.id = user_id;
 
 -- name: GetUserDisplayNameByToken :one
 SELECT display_name FROM users, user_access_tokens WHERE token = $1 AND users.id = user_id AND disabled_at = NULL;
@@ -108,3 +113,27 @@ UPDATE users SET display_name = $1, previous_names = previous_names || $2, namec
 
 -- name: ChangeDisplayColor :exec
 UPDATE users SET display_color = $1 WHERE id = $2;
+
+-- name: AddRecording :exec
+INSERT INTO recordings(id, title, variant_index, visibility, started_at) values($1, $2, $3, $4, $5);
+
+-- name: SetRecordingEnded :exec
+UPDATE recordings SET ended_at = $1 WHERE id = $2;
+
+-- name: GetRecordings :many
+SELECT id, title, variant_index, visibility, started_at, ended_at FROM recordings ORDER BY started_at DESC;
+
+-- name: GetRecordingByID :one
+SELECT id, title, variant_index, visibility, started_at, ended_at FROM recordings WHERE id = $1;
+
+-- name: GetFinishedRecordingsWithVisibility :many
+SELECT id, title, variant_index, visibility, started_at, ended_at FROM recordings WHERE visibility = $1 AND ended_at IS NOT NULL ORDER BY started_at DESC;
+
+-- name: SetRecordingVisibility :exec
+UPDATE recordings SET visibility = $1 WHERE id = $2;
+
+-- name: GetUnfinishedRecordings :many
+SELECT id, title, variant_index, visibility, started_at, ended_at FROM recordings WHERE ended_at IS NULL;
+
+-- name: DeleteRecording :exec
+DELETE FROM recordings WHERE id = $1;
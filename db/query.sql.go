@@ -83,6 +83,29 @@ func (q *Queries) AddNotification(ctx context.Context, arg AddNotificationParams
 	return err
 }
 
+const addRecording = `-- name: AddRecording :exec
+INSERT INTO recordings(id, title, variant_index, visibility, started_at) values($1, $2, $3, $4, $5)
+`
+
+type AddRecordingParams struct {
+	ID           string
+	Title        sql.NullString
+	VariantIndex int32
+	Visibility   string
+	StartedAt    time.Time
+}
+
+func (q *Queries) AddRecording(ctx context.Context, arg AddRecordingParams) error {
+	_, err := q.db.ExecContext(ctx, addRecording,
+		arg.ID,
+		arg.Title,
+		arg.VariantIndex,
+		arg.Visibility,
+		arg.StartedAt,
+	)
+	return err
+}
+
 const addToAcceptedActivities = `-- name: AddToAcceptedActivities :exec
 INSERT INTO ap_accepted_activities(iri, actor, type, timestamp) values($1, $2, $3, $4)
 `
@@ -140,16 +163,25 @@ func (q *Queries) ApproveFederationFollower(ctx context.Context, arg ApproveFede
 }
 
 const banIPAddress = `-- name: BanIPAddress :exec
-INSERT INTO ip_bans(ip_address, notes) values($1, $2)
+INSERT INTO ip_bans(ip_address, notes, expires_at, user_id) values($1, $2, $3, $4)
+  ON CONFLICT(ip_address) DO UPDATE SET notes = excluded.notes, user_id = excluded.user_id,
+  expires_at = CASE WHEN ip_bans.expires_at IS NULL OR excluded.expires_at IS NULL THEN NULL ELSE MAX(ip_bans.expires_at, excluded.expires_at) END
 `
 
 type BanIPAddressParams struct {
 	IpAddress string
 	Notes     sql.NullString
+	ExpiresAt sql.NullTime
+	UserID    sql.NullString
 }
 
 func (q *Queries) BanIPAddress(ctx context.Context, arg BanIPAddressParams) error {
-	_, err := q.db.ExecContext(ctx, banIPAddress, arg.IpAddress, arg.Notes)
+	_, err := q.db.ExecContext(ctx, banIPAddress,
+		arg.IpAddress,
+		arg.Notes,
+		arg.ExpiresAt,
+		arg.UserID,
+	)
 	return err
 }
 
@@ -188,6 +220,15 @@ func (q *Queries) ChangeDisplayName(ctx context.Context, arg ChangeDisplayNamePa
 	return err
 }
 
+const deleteRecording = `-- name: DeleteRecording :exec
+DELETE FROM recordings WHERE id = $1
+`
+
+func (q *Queries) DeleteRecording(ctx context.Context, id string) error {
+	_, err := q.db.ExecContext(ctx, deleteRecording, id)
+	return err
+}
+
 const doesInboundActivityExist = `-- name: DoesInboundActivityExist :one
 SELECT count(*) FROM ap_accepted_activities WHERE iri = $1 AND actor = $2 AND TYPE = $3
 `
@@ -296,6 +337,40 @@ func (q *Queries) GetFederationFollowersWithOffset(ctx context.Context, arg GetF
 	return items, nil
 }
 
+const getFinishedRecordingsWithVisibility = `-- name: GetFinishedRecordingsWithVisibility :many
+SELECT id, title, variant_index, visibility, started_at, ended_at FROM recordings WHERE visibility = $1 AND ended_at IS NOT NULL ORDER BY started_at DESC
+`
+
+func (q *Queries) GetFinishedRecordingsWithVisibility(ctx context.Context, visibility string) ([]Recording, error) {
+	rows, err := q.db.QueryContext(ctx, getFinishedRecordingsWithVisibility, visibility)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Recording
+	for rows.Next() {
+		var i Recording
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.VariantIndex,
+			&i.Visibility,
+			&i.StartedAt,
+			&i.EndedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getFollowerByIRI = `-- name: GetFollowerByIRI :one
 SELECT iri, inbox, name, username, image, request, request_object, created_at, approved_at, disabled_at FROM ap_followers WHERE iri = $1
 `
@@ -333,8 +408,19 @@ func (q *Queries) GetFollowerCount(ctx context.Context) (int64, error) {
 	return count, err
 }
 
+const getIPAddressBanExpiry = `-- name: GetIPAddressBanExpiry :one
+SELECT expires_at FROM ip_bans WHERE ip_address = $1
+`
+
+func (q *Queries) GetIPAddressBanExpiry(ctx context.Context, ipAddress string) (sql.NullTime, error) {
+	row := q.db.QueryRowContext(ctx, getIPAddressBanExpiry, ipAddress)
+	var expires_at sql.NullTime
+	err := row.Scan(&expires_at)
+	return expires_at, err
+}
+
 const getIPAddressBans = `-- name: GetIPAddressBans :many
-SELECT ip_address, notes, created_at FROM ip_bans
+SELECT ip_address, notes, created_at, expires_at, user_id FROM ip_bans
 `
 
 func (q *Queries) GetIPAddressBans(ctx context.Context) ([]IpBan, error) {
@@ -346,7 +432,13 @@ func (q *Queries) GetIPAddressBans(ctx context.Context) ([]IpBan, error) {
 	var items []IpBan
 	for rows.Next() {
 		var i IpBan
-		if err := rows.Scan(&i.IpAddress, &i.Notes, &i.CreatedAt); err != nil {
+		if err := rows.Scan(
+			&i.IpAddress,
+			&i.Notes,
+			&i.CreatedAt,
+			&i.ExpiresAt,
+			&i.UserID,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -541,6 +633,58 @@ func (q *Queries) GetOutboxWithOffset(ctx context.Context, arg GetOutboxWithOffs
 	return items, nil
 }
 
+const getRecordingByID = `-- name: GetRecordingByID :one
+SELECT id, title, variant_index, visibility, started_at, ended_at FROM recordings WHERE id = $1
+`
+
+func (q *Queries) GetRecordingByID(ctx context.Context, id string) (Recording, error) {
+	row := q.db.QueryRowContext(ctx, getRecordingByID, id)
+	var i Recording
+	err := row.Scan(
+		&i.ID,
+		&i.Title,
+		&i.VariantIndex,
+		&i.Visibility,
+		&i.StartedAt,
+		&i.EndedAt,
+	)
+	return i, err
+}
+
+const getRecordings = `-- name: GetRecordings :many
+SELECT id, title, variant_index, visibility, started_at, ended_at FROM recordings ORDER BY started_at DESC
+`
+
+func (q *Queries) GetRecordings(ctx context.Context) ([]Recording, error) {
+	rows, err := q.db.QueryContext(ctx, getRecordings)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Recording
+	for rows.Next() {
+		var i Recording
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.VariantIndex,
+			&i.Visibility,
+			&i.StartedAt,
+			&i.EndedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getRejectedAndBlockedFollowers = `-- name: GetRejectedAndBlockedFollowers :many
 SELECT iri, name, username, image, created_at, disabled_at FROM ap_followers WHERE disabled_at is not null
 `
@@ -584,8 +728,42 @@ func (q *Queries) GetRejectedAndBlockedFollowers(ctx context.Context) ([]GetReje
 	return items, nil
 }
 
+const getUnfinishedRecordings = `-- name: GetUnfinishedRecordings :many
+SELECT id, title, variant_index, visibility, started_at, ended_at FROM recordings WHERE ended_at IS NULL
+`
+
+func (q *Queries) GetUnfinishedRecordings(ctx context.Context) ([]Recording, error) {
+	rows, err := q.db.QueryContext(ctx, getUnfinishedRecordings)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Recording
+	for rows.Next() {
+		var i Recording
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.VariantIndex,
+			&i.Visibility,
+			&i.StartedAt,
+			&i.EndedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getUserByAccessToken = `-- name: GetUserByAccessToken :one
-SELECT users.id, display_name, display_color, users.created_at, disabled_at, previous_names, namechanged_at, authenticated_at, scopes FROM users, user_access_tokens WHERE token = $1 AND users.id = user_id
+SELECT users.id, display_name, display_color, users.created_at, disabled_at, disabled_until, previous_names, namechanged_at, authenticated_at, scopes FROM users, user_access_tokens WHERE token = $1 AND users.id = user_id
 `
 
 type GetUserByAccessTokenRow struct {
@@ -594,6 +772,7 @@ type GetUserByAccessTokenRow struct {
 	DisplayColor    int32
 	CreatedAt       sql.NullTime
 	DisabledAt      sql.NullTime
+	DisabledUntil   sql.NullTime
 	PreviousNames   sql.NullString
 	NamechangedAt   sql.NullTime
 	AuthenticatedAt sql.NullTime
@@ -609,6 +788,7 @@ func (q *Queries) GetUserByAccessToken(ctx context.Context, token string) (GetUs
 		&i.DisplayColor,
 		&i.CreatedAt,
 		&i.DisabledAt,
+		&i.DisabledUntil,
 		&i.PreviousNames,
 		&i.NamechangedAt,
 		&i.AuthenticatedAt,
@@ -677,17 +857,6 @@ func (q *Queries) IsDisplayNameAvailable(ctx context.Context, displayName string
 	return count, err
 }
 
-const isIPAddressBlocked = `-- name: IsIPAddressBlocked :one
-SELECT count(*) FROM ip_bans WHERE ip_address = $1
-`
-
-func (q *Queries) IsIPAddressBlocked(ctx context.Context, ipAddress string) (int64, error) {
-	row := q.db.QueryRowContext(ctx, isIPAddressBlocked, ipAddress)
-	var count int64
-	err := row.Scan(&count)
-	return count, err
-}
-
 const rejectFederationFollower = `-- name: RejectFederationFollower :exec
 UPDATE ap_followers SET approved_at = null, disabled_at = $1 WHERE iri = $2
 `
@@ -720,6 +889,15 @@ func (q *Queries) RemoveIPAddressBan(ctx context.Context, ipAddress string) erro
 	return err
 }
 
+const removeIPAddressBansForUser = `-- name: RemoveIPAddressBansForUser :exec
+DELETE FROM ip_bans WHERE user_id = $1
+`
+
+func (q *Queries) RemoveIPAddressBansForUser(ctx context.Context, userID sql.NullString) error {
+	_, err := q.db.ExecContext(ctx, removeIPAddressBansForUser, userID)
+	return err
+}
+
 const removeNotificationDestinationForChannel = `-- name: RemoveNotificationDestinationForChannel :exec
 DELETE FROM notifications WHERE channel = $1 AND destination = $2
 `
@@ -748,6 +926,34 @@ func (q *Queries) SetAccessTokenToOwner(ctx context.Context, arg SetAccessTokenT
 	return err
 }
 
+const setRecordingEnded = `-- name: SetRecordingEnded :exec
+UPDATE recordings SET ended_at = $1 WHERE id = $2
+`
+
+type SetRecordingEndedParams struct {
+	EndedAt sql.NullTime
+	ID      string
+}
+
+func (q *Queries) SetRecordingEnded(ctx context.Context, arg SetRecordingEndedParams) error {
+	_, err := q.db.ExecContext(ctx, setRecordingEnded, arg.EndedAt, arg.ID)
+	return err
+}
+
+const setRecordingVisibility = `-- name: SetRecordingVisibility :exec
+UPDATE recordings SET visibility = $1 WHERE id = $2
+`
+
+type SetRecordingVisibilityParams struct {
+	Visibility string
+	ID         string
+}
+
+func (q *Queries) SetRecordingVisibility(ctx context.Context, arg SetRecordingVisibilityParams) error {
+	_, err := q.db.ExecContext(ctx, setRecordingVisibility, arg.Visibility, arg.ID)
+	return err
+}
+
 const setUserAsAuthenticated = `-- name: SetUserAsAuthenticated :exec
 UPDATE users SET authenticated_at = CURRENT_TIMESTAMP WHERE id = $1
 `
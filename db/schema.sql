@@ -40,7 +40,9 @@ CREATE TABLE IF NOT EXISTS ap_accepted_activities (
   CREATE TABLE IF NOT EXISTS ip_bans (
     "ip_address" TEXT NOT NULL PRIMARY KEY,
     "notes" TEXT,
-    "created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+    "created_at" TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    "expires_at" TIMESTAMP,
+    "user_id" TEXT
   );
 
 CREATE TABLE IF NOT EXISTS notifications (
@@ -62,6 +64,9 @@ CREATE TABLE IF NOT EXISTS users (
     "authenticated_at" TIMESTAMP,
 		"type" TEXT DEFAULT 'STANDARD',
 		"last_used" DATETIME DEFAULT CURRENT_TIMESTAMP,
+		"disabled_until" TIMESTAMP,
+		"first_message_at" TIMESTAMP,
+		"timed_out_until" TIMESTAMP,
 		PRIMARY KEY (id)
 	);
 
@@ -91,9 +96,23 @@ CREATE TABLE IF NOT EXISTS messages (
     "subtitle" TEXT,
     "image" TEXT,
     "link" TEXT,
+    "channel" TEXT NOT NULL DEFAULT '',
+    "reply_to" TEXT,
+    "edited_at" DATETIME,
+    "deleted_at" DATETIME,
 		PRIMARY KEY (id)
 	);CREATE INDEX index ON messages (id, user_id, hidden_at, timestamp);
 	CREATE INDEX id ON messages (id);
 	CREATE INDEX user_id ON messages (user_id);
 	CREATE INDEX hidden_at ON messages (hidden_at);
 	CREATE INDEX timestamp ON messages (timestamp);
+
+CREATE TABLE IF NOT EXISTS recordings (
+    "id" TEXT NOT NULL PRIMARY KEY,
+    "title" TEXT,
+    "variant_index" INTEGER NOT NULL,
+    "visibility" TEXT NOT NULL DEFAULT 'private',
+    "started_at" TIMESTAMP NOT NULL,
+    "ended_at" TIMESTAMP
+  );
+  CREATE INDEX recordings_started_at ON recordings (started_at);
@@ -28,6 +28,7 @@ var (
 	webServerPortOverride = flag.String("webserverport", "", "Force the web server to listen on a specific port")
 	webServerIPOverride   = flag.String("webserverip", "", "Force web server to listen on this IP address")
 	rtmpPortOverride      = flag.Int("rtmpport", 0, "Set listen port for the RTMP server")
+	srtPortOverride       = flag.Int("srtport", 0, "Set listen port for the SRT server")
 )
 
 // nolint:cyclop
@@ -156,6 +157,14 @@ func handleCommandLineFlags() {
 			log.Errorln(err)
 		}
 	}
+
+	// Set the srt server port
+	if *srtPortOverride > 0 {
+		log.Println("Saving new SRT server port number to", *srtPortOverride)
+		if err := data.SetSRTPortNumber(float64(*srtPortOverride)); err != nil {
+			log.Errorln(err)
+		}
+	}
 }
 
 func configureLogging(enableDebugFeatures bool, enableVerboseLogging bool) {
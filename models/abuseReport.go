@@ -0,0 +1,48 @@
+package models
+
+import "time"
+
+const (
+	// AbuseReportOpen is a report that has not been handled by a moderator.
+	AbuseReportOpen = "OPEN"
+	// AbuseReportResolved is a report a moderator has acted on.
+	AbuseReportResolved = "RESOLVED"
+	// AbuseReportDismissed is a report a moderator decided needs no action.
+	AbuseReportDismissed = "DISMISSED"
+)
+
+// AbuseReport is a chat user flagging a message or another user to the
+// moderators.
+type AbuseReport struct {
+	Timestamp  time.Time  `json:"timestamp"`
+	ResolvedAt *time.Time `json:"resolvedAt,omitempty"`
+	ReporterID string     `json:"reporterId"`
+	// MessageID is the reported message. Empty when a user is reported
+	// without a message.
+	MessageID string `json:"messageId,omitempty"`
+	// UserID is the reported user, or the author of the reported message.
+	UserID string `json:"userId"`
+	Reason string `json:"reason"`
+	Status string `json:"status"`
+	// ResolvedBy is who changed the status, such as moderator:<user id>.
+	ResolvedBy string `json:"resolvedBy,omitempty"`
+	ChannelID  string `json:"channelId,omitempty"`
+	ID         int    `json:"id"`
+}
+
+// AbuseReportConfig is when and where moderators are alerted about reported
+// chat messages.
+type AbuseReportConfig struct {
+	// DiscordWebhook is the Discord webhook URL alerts are sent to. Empty
+	// disables Discord alerts.
+	DiscordWebhook string `json:"discordWebhook,omitempty"`
+	// Threshold is the number of open reports of a message that alerts the
+	// moderators. Zero disables alerts.
+	Threshold int `json:"threshold"`
+}
+
+// IsValidAbuseReportStatus will return if a status is one of the abuse
+// report statuses.
+func IsValidAbuseReportStatus(status string) bool {
+	return status == AbuseReportOpen || status == AbuseReportResolved || status == AbuseReportDismissed
+}
@@ -0,0 +1,51 @@
+package models
+
+// AutomodRuleType is the kind of content an automod rule looks for.
+type AutomodRuleType = string
+
+const (
+	// AutomodBlockedWords matches messages containing any of a list of words.
+	AutomodBlockedWords AutomodRuleType = "BLOCKED_WORDS"
+	// AutomodRegex matches messages matching a regular expression.
+	AutomodRegex AutomodRuleType = "REGEX"
+	// AutomodLinks matches messages containing links sent by non-moderators.
+	AutomodLinks AutomodRuleType = "LINKS"
+	// AutomodCaps matches messages where at least Threshold percent of the
+	// letters are capitals.
+	AutomodCaps AutomodRuleType = "CAPS"
+	// AutomodEmoji matches messages containing at least Threshold emoji.
+	AutomodEmoji AutomodRuleType = "EMOJI"
+	// AutomodRepeat matches messages where a character or word is repeated
+	// at least Threshold times in a row.
+	AutomodRepeat AutomodRuleType = "REPEAT"
+	// AutomodFirstMessage matches the first message a user sends to chat.
+	AutomodFirstMessage AutomodRuleType = "FIRST_MESSAGE"
+)
+
+// AutomodAction is what happens to a message matched by an automod rule.
+type AutomodAction = string
+
+const (
+	// AutomodActionDrop discards the message.
+	AutomodActionDrop AutomodAction = "DROP"
+	// AutomodActionHide saves the message as hidden. Only the sender sees it.
+	AutomodActionHide AutomodAction = "HIDE"
+	// AutomodActionHold keeps the message from chat until a moderator
+	// reviews it.
+	AutomodActionHold AutomodAction = "HOLD"
+)
+
+// AutomodRule is a single automatic chat moderation rule.
+type AutomodRule struct {
+	ID     string          `json:"id"`
+	Type   AutomodRuleType `json:"type"`
+	Action AutomodAction   `json:"action"`
+	// Pattern is the regular expression of a REGEX rule.
+	Pattern string `json:"pattern,omitempty"`
+	// Words are the blocked words of a BLOCKED_WORDS rule.
+	Words []string `json:"words,omitempty"`
+	// Threshold is the percentage of capitals, number of emoji or number of
+	// repeats a CAPS, EMOJI or REPEAT rule matches at.
+	Threshold int  `json:"threshold,omitempty"`
+	Enabled   bool `json:"enabled"`
+}
@@ -6,6 +6,7 @@ import "time"
 type Broadcaster struct {
 	Time          time.Time            `json:"time"`
 	RemoteAddr    string               `json:"remoteAddr"`
+	Protocol      string               `json:"protocol"`
 	StreamDetails InboundStreamDetails `json:"streamDetails"`
 }
 
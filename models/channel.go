@@ -0,0 +1,39 @@
+package models
+
+import (
+	"regexp"
+	"strings"
+)
+
+// DefaultChannelID is the channel streamed to by stream keys that are not
+// assigned to a channel. It is served from the root of the HLS tree and is
+// the only channel with recordings, low latency playlists, DASH, restreaming
+// and directory listings.
+const DefaultChannelID = ""
+
+// Channel ids are used as a directory name under /hls, so they must never be
+// a number and clash with a variant directory.
+var channelIDRegex = regexp.MustCompile(`^[a-z][a-z0-9-]{0,31}$`)
+
+// Channel is a stream hosted alongside the default stream on this server.
+type Channel struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	StreamTitle string `json:"streamTitle"`
+}
+
+// IsValidChannelID will return if the id is able to be used for a channel.
+func IsValidChannelID(id string) bool {
+	return channelIDRegex.MatchString(id)
+}
+
+// GetChannelIDFromHLSPath will return the channel a file belongs to, given
+// its path relative to the root of the HLS tree.
+func GetChannelIDFromHLSPath(relativePath string) string {
+	relativePath = strings.TrimPrefix(relativePath, "/")
+	if i := strings.Index(relativePath, "/"); i > 0 && IsValidChannelID(relativePath[:i]) {
+		return relativePath[:i]
+	}
+
+	return DefaultChannelID
+}
@@ -0,0 +1,44 @@
+package models
+
+import "time"
+
+// ChatArchiveConfig is the configuration for keeping a long-term, searchable
+// archive of chat.
+type ChatArchiveConfig struct {
+	// RetentionDays is the number of days archived chat is kept for. Zero
+	// keeps it forever.
+	RetentionDays int  `json:"retentionDays"`
+	Enabled       bool `json:"enabled"`
+}
+
+// ChatArchiveEntry is a single archived chat event.
+type ChatArchiveEntry struct {
+	Timestamp   time.Time `json:"timestamp"`
+	ID          string    `json:"id"`
+	UserID      string    `json:"userId,omitempty"`
+	DisplayName string    `json:"displayName,omitempty"`
+	Type        EventType `json:"type"`
+	// Body is the rendered HTML of the event.
+	Body      string     `json:"body"`
+	ChannelID string     `json:"channelId,omitempty"`
+	RoomID    string     `json:"roomId,omitempty"`
+	HiddenAt  *time.Time `json:"hiddenAt,omitempty"`
+	DeletedAt *time.Time `json:"deletedAt,omitempty"`
+	EditedAt  *time.Time `json:"editedAt,omitempty"`
+}
+
+// ChatArchiveQuery filters a search of the chat archive. Empty fields do not
+// filter.
+type ChatArchiveQuery struct {
+	From *time.Time
+	To   *time.Time
+	// Text is an SQLite full-text search expression.
+	Text      string
+	UserID    string
+	Type      EventType
+	ChannelID *string
+	RoomID    *string
+	Limit     int
+	// IncludeRemoved includes hidden and deleted messages.
+	IncludeRemoved bool
+}
@@ -0,0 +1,9 @@
+package models
+
+import "time"
+
+// ChatMessageEdit is the text a chat message had before it was edited.
+type ChatMessageEdit struct {
+	EditedAt time.Time `json:"editedAt"`
+	Body     string    `json:"body"`
+}
@@ -0,0 +1,14 @@
+package models
+
+// ChatModes are the server enforced restrictions on who can send chat
+// messages and what they can send. Moderators are exempt from all of them.
+type ChatModes struct {
+	// SlowModeSeconds is the number of seconds each user must wait between
+	// messages. Zero disables slow mode.
+	SlowModeSeconds int `json:"slowModeSeconds"`
+	// AuthenticatedOnly only allows users that have authenticated with
+	// IndieAuth or the Fediverse to send messages.
+	AuthenticatedOnly bool `json:"authenticatedOnly"`
+	// EmoteOnly only allows messages made up entirely of custom emoji.
+	EmoteOnly bool `json:"emoteOnly"`
+}
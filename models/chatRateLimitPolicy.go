@@ -0,0 +1,30 @@
+package models
+
+// ChatRateLimit is how quickly a chat user is able to send events.
+type ChatRateLimit struct {
+	// EventsPerSecond is the rate events are accepted at over time. Zero is
+	// unlimited.
+	EventsPerSecond float64 `json:"eventsPerSecond"`
+	// Burst is the most events accepted at once.
+	Burst int `json:"burst"`
+}
+
+// ChatRateLimitPolicy is the rate limit of each role of chat user, and how
+// users that keep exceeding it are dealt with.
+type ChatRateLimitPolicy struct {
+	Anonymous     ChatRateLimit `json:"anonymous"`
+	Authenticated ChatRateLimit `json:"authenticated"`
+	Moderator     ChatRateLimit `json:"moderator"`
+	// Bot is the rate limit of each chat integration using the external API.
+	Bot ChatRateLimit `json:"bot"`
+	// RejectionSeconds is how long a client is blocked from sending events
+	// after exceeding its rate limit.
+	RejectionSeconds int `json:"rejectionSeconds"`
+	// EscalationThreshold is how many times a user can exceed their rate
+	// limit within the escalation window before being timed out. Zero never
+	// times users out.
+	EscalationThreshold     int `json:"escalationThreshold"`
+	EscalationWindowSeconds int `json:"escalationWindowSeconds"`
+	// EscalationTimeoutSeconds is how long repeat offenders are timed out for.
+	EscalationTimeoutSeconds int `json:"escalationTimeoutSeconds"`
+}
@@ -0,0 +1,9 @@
+package models
+
+// ChatReaction is every reaction to a chat message with the same emoji.
+type ChatReaction struct {
+	// Emoji is either a unicode emoji or the name of a custom emoji.
+	Emoji   string   `json:"emoji"`
+	UserIDs []string `json:"userIds"`
+	Count   int      `json:"count"`
+}
@@ -0,0 +1,25 @@
+package models
+
+import "regexp"
+
+// DefaultChatRoomID is the main chat room of a channel that every chat
+// client is in.
+const DefaultChatRoomID = ""
+
+var chatRoomIDRegex = regexp.MustCompile(`^[a-z][a-z0-9-]{0,31}$`)
+
+// ChatRoom is a named chat room alongside the main chat of every channel,
+// with its own history.
+type ChatRoom struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// Guests are the IDs of users allowed into a moderator only room.
+	Guests []string `json:"guests,omitempty"`
+	// ModeratorsOnly rooms can only be joined by moderators and guests.
+	ModeratorsOnly bool `json:"moderatorsOnly"`
+}
+
+// IsValidChatRoomID will return if the id is able to be used for a chat room.
+func IsValidChatRoomID(id string) bool {
+	return chatRoomIDRegex.MatchString(id)
+}
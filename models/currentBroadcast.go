@@ -4,4 +4,5 @@ package models
 type CurrentBroadcast struct {
 	OutputSettings []StreamOutputVariant `json:"outputSettings"`
 	LatencyLevel   LatencyLevel          `json:"latencyLevel"`
+	SegmentFormat  SegmentFormat         `json:"segmentFormat"`
 }
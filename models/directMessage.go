@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// DirectMessage is a private chat message from one user to another.
+type DirectMessage struct {
+	Timestamp   time.Time `json:"timestamp"`
+	ID          string    `json:"id"`
+	SenderID    string    `json:"senderId"`
+	RecipientID string    `json:"recipientId"`
+	Body        string    `json:"body"`
+}
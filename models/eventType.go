@@ -10,6 +10,14 @@ const (
 	UserJoined EventType = "USER_JOINED"
 	// UserNameChanged is the event sent when a chat username change takes place.
 	UserNameChanged EventType = "NAME_CHANGE"
+	// MessageReaction is the event sent when a user adds or removes an emoji reaction to a chat message.
+	MessageReaction EventType = "CHAT_REACTION"
+	// MessageEdited is the event sent when a user edits one of their own chat messages.
+	MessageEdited EventType = "MESSAGE_EDITED"
+	// MessageDeleted is the event sent when a user deletes one of their own chat messages.
+	MessageDeleted EventType = "MESSAGE_DELETED"
+	// AbuseReportThresholdReached is the event sent when a chat message has been reported by enough users to alert the moderators.
+	AbuseReportThresholdReached EventType = "REPORT_THRESHOLD_REACHED"
 	// VisibiltyToggled is the event sent when a chat message's visibility changes.
 	VisibiltyToggled EventType = "VISIBILITY-UPDATE"
 	// PING is a ping message.
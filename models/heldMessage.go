@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// HeldMessage is a chat message kept from chat until a moderator reviews it.
+type HeldMessage struct {
+	Timestamp time.Time `json:"timestamp"`
+	ID        string    `json:"id"`
+	UserID    string    `json:"userId"`
+	Body      string    `json:"body"`
+	Reason    string    `json:"reason"`
+	ChannelID string    `json:"channelId,omitempty"`
+	RoomID    string    `json:"roomId,omitempty"`
+	// ReplyTo is the ID of the message this message is a reply to.
+	ReplyTo string `json:"replyTo,omitempty"`
+}
@@ -4,7 +4,10 @@ import "time"
 
 // IPAddress is a simple representation of an IP address.
 type IPAddress struct {
-	CreatedAt time.Time `json:"createdAt"`
-	IPAddress string    `json:"ipAddress"`
-	Notes     string    `json:"notes"`
+	CreatedAt time.Time  `json:"createdAt"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+	IPAddress string     `json:"ipAddress"`
+	Notes     string     `json:"notes"`
+	// UserID is the banned user the address belonged to.
+	UserID string `json:"userId,omitempty"`
 }
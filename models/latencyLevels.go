@@ -1,20 +1,28 @@
 package models
 
+// LowLatencyHLSLevel is the latency level that uses Low-Latency HLS with
+// partial segments and blocking playlist reloads.
+const LowLatencyHLSLevel = -1
+
 // LatencyLevel is a representation of HLS configuration values.
 type LatencyLevel struct {
 	Level             int `json:"level"`
 	SecondsPerSegment int `json:"-"`
 	SegmentCount      int `json:"-"`
+	// PartsPerSegment is the number of LL-HLS partial segments each segment
+	// is made up of. Zero when LL-HLS is not used.
+	PartsPerSegment int `json:"-"`
 }
 
 // GetLatencyConfigs will return the available latency level options.
 func GetLatencyConfigs() map[int]LatencyLevel {
 	return map[int]LatencyLevel{
-		0: {Level: 0, SecondsPerSegment: 1, SegmentCount: 25}, // Approx 5 seconds
-		1: {Level: 1, SecondsPerSegment: 2, SegmentCount: 15}, // Approx 8-9 seconds
-		2: {Level: 2, SecondsPerSegment: 3, SegmentCount: 10}, // Default Approx 10 seconds
-		3: {Level: 3, SecondsPerSegment: 4, SegmentCount: 8},  // Approx 15 seconds
-		4: {Level: 4, SecondsPerSegment: 5, SegmentCount: 5},  // Approx 18 seconds
+		LowLatencyHLSLevel: {Level: LowLatencyHLSLevel, SecondsPerSegment: 2, SegmentCount: 6, PartsPerSegment: 4}, // Approx 2 seconds
+		0:                  {Level: 0, SecondsPerSegment: 1, SegmentCount: 25},                                     // Approx 5 seconds
+		1:                  {Level: 1, SecondsPerSegment: 2, SegmentCount: 15},                                     // Approx 8-9 seconds
+		2:                  {Level: 2, SecondsPerSegment: 3, SegmentCount: 10},                                     // Default Approx 10 seconds
+		3:                  {Level: 3, SecondsPerSegment: 4, SegmentCount: 8},                                      // Approx 15 seconds
+		4:                  {Level: 4, SecondsPerSegment: 5, SegmentCount: 5},                                      // Approx 18 seconds
 	}
 }
 
@@ -22,3 +30,28 @@ func GetLatencyConfigs() map[int]LatencyLevel {
 func GetLatencyLevel(index int) LatencyLevel {
 	return GetLatencyConfigs()[index]
 }
+
+// IsLowLatencyHLS will return if this level uses LL-HLS partial segments.
+func (l LatencyLevel) IsLowLatencyHLS() bool {
+	return l.PartsPerSegment > 0
+}
+
+// GetSecondsPerPart will return the target length of a LL-HLS partial segment.
+func (l LatencyLevel) GetSecondsPerPart() float64 {
+	if !l.IsLowLatencyHLS() {
+		return float64(l.SecondsPerSegment)
+	}
+
+	return float64(l.SecondsPerSegment) / float64(l.PartsPerSegment)
+}
+
+// GetMaxFilesPerVariant will return the number of video files of a single
+// variant that are referenced by its playlist.
+func (l LatencyLevel) GetMaxFilesPerVariant() int {
+	if !l.IsLowLatencyHLS() {
+		return l.SegmentCount
+	}
+
+	// Each segment is made up of its parts along with the complete segment.
+	return l.SegmentCount * (l.PartsPerSegment + 1)
+}
@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// ModerationLogEntry is a single moderation action taken on a chat message.
+type ModerationLogEntry struct {
+	Timestamp time.Time `json:"timestamp"`
+	UserID    string    `json:"userId"`
+	MessageID string    `json:"messageId"`
+	Body      string    `json:"body"`
+	// Source is what took the action, such as the automod rule ID.
+	Source    string `json:"source"`
+	Reason    string `json:"reason"`
+	Action    string `json:"action"`
+	ChannelID string `json:"channelId,omitempty"`
+	ID        int    `json:"id"`
+}
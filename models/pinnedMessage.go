@@ -0,0 +1,42 @@
+package models
+
+import "time"
+
+// AnnouncementStyle is how a pinned announcement is displayed.
+type AnnouncementStyle = string
+
+const (
+	// AnnouncementStyleInfo announcements share general information.
+	AnnouncementStyleInfo AnnouncementStyle = "INFO"
+	// AnnouncementStyleWarning announcements ask for attention.
+	AnnouncementStyleWarning AnnouncementStyle = "WARNING"
+	// AnnouncementStyleCelebration announcements celebrate something.
+	AnnouncementStyleCelebration AnnouncementStyle = "CELEBRATION"
+)
+
+// IsValidAnnouncementStyle will return if the style is a known value.
+func IsValidAnnouncementStyle(style AnnouncementStyle) bool {
+	switch style {
+	case AnnouncementStyleInfo, AnnouncementStyleWarning, AnnouncementStyleCelebration:
+		return true
+	}
+
+	return false
+}
+
+// PinnedMessage is a chat message or announcement kept at the top of the
+// chat of a channel.
+type PinnedMessage struct {
+	PinnedAt time.Time `json:"pinnedAt"`
+	// MessageID is the chat message that was pinned. Empty for
+	// announcements.
+	MessageID string `json:"messageId,omitempty"`
+	// UserID is the user that sent the pinned chat message.
+	UserID string `json:"userId,omitempty"`
+	Body   string `json:"body"`
+	// Style is how an announcement is displayed. Empty for chat messages.
+	Style AnnouncementStyle `json:"style,omitempty"`
+	// PinnedBy is admin, or the moderator or integration that pinned it.
+	PinnedBy  string `json:"pinnedBy"`
+	ChannelID string `json:"channelId,omitempty"`
+}
@@ -0,0 +1,46 @@
+package models
+
+import "time"
+
+// RecordingVisibility is who is able to watch a recording.
+type RecordingVisibility = string
+
+const (
+	// RecordingVisibilityPublic recordings are listed and can be watched by anybody.
+	RecordingVisibilityPublic RecordingVisibility = "public"
+	// RecordingVisibilityUnlisted recordings are not listed but can be watched by anybody with a link.
+	RecordingVisibilityUnlisted RecordingVisibility = "unlisted"
+	// RecordingVisibilityPrivate recordings are only available to the admin.
+	RecordingVisibilityPrivate RecordingVisibility = "private"
+)
+
+// IsValidRecordingVisibility will return if the visibility is a known value.
+func IsValidRecordingVisibility(visibility RecordingVisibility) bool {
+	switch visibility {
+	case RecordingVisibilityPublic, RecordingVisibilityUnlisted, RecordingVisibilityPrivate:
+		return true
+	}
+
+	return false
+}
+
+// RecordingConfig is the configuration for recording streams.
+type RecordingConfig struct {
+	// DefaultVisibility is the visibility new recordings are created with.
+	DefaultVisibility RecordingVisibility `json:"defaultVisibility,omitempty"`
+	// VariantIndex is the stream output variant that gets recorded.
+	VariantIndex int  `json:"variantIndex"`
+	Enabled      bool `json:"enabled"`
+}
+
+// Recording is a single recorded stream.
+type Recording struct {
+	StartedAt  time.Time           `json:"startedAt"`
+	EndedAt    *time.Time          `json:"endedAt,omitempty"`
+	ID         string              `json:"id"`
+	Title      string              `json:"title"`
+	Visibility RecordingVisibility `json:"visibility"`
+	// Duration is the length of the recording in seconds.
+	Duration     float64 `json:"duration"`
+	VariantIndex int     `json:"variantIndex"`
+}
@@ -0,0 +1,52 @@
+package models
+
+import (
+	"net/url"
+	"time"
+)
+
+// RestreamState is the state of the connection to a restream destination.
+type RestreamState = string
+
+const (
+	// RestreamStateDisabled destinations are not sent the stream.
+	RestreamStateDisabled RestreamState = "disabled"
+	// RestreamStateIdle destinations are waiting for a stream to begin.
+	RestreamStateIdle RestreamState = "idle"
+	// RestreamStateConnecting destinations are being connected to.
+	RestreamStateConnecting RestreamState = "connecting"
+	// RestreamStateLive destinations are being sent the stream.
+	RestreamStateLive RestreamState = "live"
+	// RestreamStateReconnecting destinations have failed and will be retried.
+	RestreamStateReconnecting RestreamState = "reconnecting"
+)
+
+// RestreamDestination is an external RTMP server the stream is copied to.
+type RestreamDestination struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// URL is the full RTMP or RTMPS publishing URL, including the stream key.
+	URL     string `json:"url"`
+	Enabled bool   `json:"enabled"`
+}
+
+// IsValidRestreamURL will return if the URL is an RTMP or RTMPS URL.
+func IsValidRestreamURL(destinationURL string) bool {
+	u, err := url.Parse(destinationURL)
+	if err != nil {
+		return false
+	}
+
+	return (u.Scheme == "rtmp" || u.Scheme == "rtmps") && u.Host != ""
+}
+
+// RestreamStatus is the live status of a single restream destination. The
+// URL is left out as it contains the stream key of the destination.
+type RestreamStatus struct {
+	ConnectedAt *time.Time    `json:"connectedAt,omitempty"`
+	ID          string        `json:"id"`
+	Name        string        `json:"name"`
+	State       RestreamState `json:"state"`
+	LastError   string        `json:"lastError,omitempty"`
+	Reconnects  int           `json:"reconnects"`
+}
@@ -0,0 +1,16 @@
+package models
+
+// SegmentFormat is the container format of HLS video segments.
+type SegmentFormat = string
+
+const (
+	// SegmentFormatMPEGTS is MPEG-TS segments.
+	SegmentFormatMPEGTS SegmentFormat = "mpegts"
+	// SegmentFormatFMP4 is fragmented MP4 (CMAF) segments with an init segment.
+	SegmentFormatFMP4 SegmentFormat = "fmp4"
+)
+
+// IsValidSegmentFormat will return if the segment format is supported.
+func IsValidSegmentFormat(format SegmentFormat) bool {
+	return format == SegmentFormatMPEGTS || format == SegmentFormatFMP4
+}
@@ -8,6 +8,7 @@ type StorageProvider interface {
 	SegmentWritten(localFilePath string)
 	VariantPlaylistWritten(localFilePath string)
 	MasterPlaylistWritten(localFilePath string)
+	DASHManifestWritten(localFilePath string)
 
 	Cleanup() error
 }
@@ -4,4 +4,7 @@ package models
 type StreamKey struct {
 	Key     string `json:"key"`
 	Comment string `json:"comment"`
+	// Channel is the id of the channel this key streams to. Empty for the
+	// default channel.
+	Channel string `json:"channel,omitempty"`
 }
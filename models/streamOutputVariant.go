@@ -28,6 +28,10 @@ type StreamOutputVariant struct {
 	Framerate int `yaml:"framerate" json:"framerate"`
 	// CPUUsageLevel represents a codec preset to configure CPU usage.
 	CPUUsageLevel int `json:"cpuUsageLevel"`
+
+	// Codec is the video codec used for this variant. When not set the
+	// server's video codec is used.
+	Codec string `yaml:"codec" json:"codec,omitempty"`
 }
 
 // GetFramerate returns the framerate or default.
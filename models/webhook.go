@@ -8,16 +8,23 @@ import (
 
 // Webhook is an event that is sent to 3rd party, external services with details about something that took place within an Owncast server.
 type Webhook struct {
-	Timestamp time.Time   `json:"timestamp"`
-	LastUsed  *time.Time  `json:"lastUsed"`
-	URL       string      `json:"url"`
-	Events    []EventType `json:"events"`
-	ID        int         `json:"id"`
+	Timestamp time.Time  `json:"timestamp"`
+	LastUsed  *time.Time `json:"lastUsed"`
+	URL       string     `json:"url"`
+	// Channel is the id of the channel this webhook is sent the events of.
+	// Empty for the default channel.
+	Channel string      `json:"channel,omitempty"`
+	Events  []EventType `json:"events"`
+	ID      int         `json:"id"`
 }
 
 // For an event to be seen as "valid" it must live in this slice.
 var validEvents = []EventType{
 	MessageSent,
+	MessageReaction,
+	MessageEdited,
+	MessageDeleted,
+	AbuseReportThresholdReached,
 	UserJoined,
 	UserNameChanged,
 	VisibiltyToggled,
@@ -140,6 +140,27 @@ func (n *Notifier) notifyDiscord() {
 	}
 }
 
+// NotifyModerators will send a message that needs moderator attention to
+// the moderation Discord webhook, if one is configured.
+func NotifyModerators(message string) error {
+	webhook := data.GetAbuseReportConfig().DiscordWebhook
+	if webhook == "" {
+		return nil
+	}
+
+	var image string
+	if serverURL := data.GetServerURL(); serverURL != "" {
+		image = serverURL + "/logo"
+	}
+
+	discordNotifier, err := discord.New(data.GetServerName(), image, webhook)
+	if err != nil {
+		return errors.Wrap(err, "error creating moderation discord notifier")
+	}
+
+	return discordNotifier.Send(message)
+}
+
 // Notify will fire the different notification channels.
 func (n *Notifier) Notify() {
 	if n.browser != nil {
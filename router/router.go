@@ -56,12 +56,21 @@ func Start() error {
 	// status of the system
 	http.HandleFunc("/api/status", controllers.GetStatus)
 
+	// the channels hosted alongside the default stream
+	http.HandleFunc("/api/channels", controllers.GetChannels)
+
 	// custom emoji supported in the chat
 	http.HandleFunc("/api/emoji", controllers.GetCustomEmojiList)
 
 	// chat rest api
 	http.HandleFunc("/api/chat", middleware.RequireUserAccessToken(controllers.GetChatMessages))
 
+	// the direct messages sent and received by the user
+	http.HandleFunc("/api/chat/directmessages", middleware.RequireUserAccessToken(controllers.GetDirectMessages))
+
+	// the chat rooms the user is able to join
+	http.HandleFunc("/api/chat/rooms", middleware.RequireUserAccessToken(controllers.GetChatRooms))
+
 	// web config api
 	http.HandleFunc("/api/config", controllers.GetWebConfig)
 
@@ -86,6 +95,15 @@ func Start() error {
 	// return followers
 	http.HandleFunc("/api/followers", middleware.HandlePagination(controllers.GetFollowers))
 
+	// return the publicly listed stream recordings
+	http.HandleFunc("/api/vods", controllers.GetVODs)
+
+	// return the chat sent during a window of a stream recording
+	http.HandleFunc("/api/vods/", controllers.GetVODChat)
+
+	// return recorded HLS video
+	http.HandleFunc("/vod/", controllers.HandleVODRequest)
+
 	// save client video playback metrics
 	http.HandleFunc("/api/metrics/playback", controllers.ReportPlaybackMetrics)
 
@@ -100,6 +118,9 @@ func Start() error {
 	// Return HLS video
 	http.HandleFunc("/hls/", controllers.HandleHLSRequest)
 
+	// DASH manifest of the same video
+	http.HandleFunc("/dash/", controllers.HandleDASHRequest)
+
 	// Disconnect inbound stream
 	http.HandleFunc("/api/admin/disconnect", middleware.RequireAdminAuth(admin.DisconnectInboundConnection))
 
@@ -201,9 +222,51 @@ func Start() error {
 	// Disable chat user join messages
 	http.HandleFunc("/api/admin/config/chat/joinmessagesenabled", middleware.RequireAdminAuth(admin.SetChatJoinMessagesEnabled))
 
+	// Set how long users can edit and delete their own chat messages for
+	http.HandleFunc("/api/admin/config/chat/messageeditwindow", middleware.RequireAdminAuth(admin.SetChatMessageEditWindow))
+
+	// Set how many days direct messages between users are kept for
+	http.HandleFunc("/api/admin/config/chat/directmessageretention", middleware.RequireAdminAuth(admin.SetDirectMessageRetention))
+
 	// Enable/disable chat established user mode
 	http.HandleFunc("/api/admin/config/chat/establishedusermode", middleware.RequireAdminAuth(admin.SetEnableEstablishedChatUserMode))
 
+	// Set the slow, authenticated-only and emote-only chat modes
+	http.HandleFunc("/api/admin/chat/modes", middleware.RequireAdminAuth(admin.SetChatModes))
+
+	// Set the automatic chat moderation rules
+	http.HandleFunc("/api/admin/chat/automod", middleware.RequireAdminAuth(admin.SetAutomodRules))
+
+	// Enable the long-term chat archive and set how long it is kept for
+	http.HandleFunc("/api/admin/chat/archive/config", middleware.RequireAdminAuth(admin.SetChatArchiveConfig))
+
+	// Search the long-term chat archive
+	http.HandleFunc("/api/admin/chat/archive/search", middleware.RequireAdminAuth(admin.SearchChatArchive))
+
+	// Export the archived chat of a stream
+	http.HandleFunc("/api/admin/chat/archive/export", middleware.RequireAdminAuth(admin.ExportChatArchive))
+
+	// Pin a chat message or announcement to the top of chat
+	http.HandleFunc("/api/admin/chat/pin", middleware.RequireAdminAuth(admin.PinChatMessage))
+
+	// Remove the message pinned to the top of chat
+	http.HandleFunc("/api/admin/chat/unpin", middleware.RequireAdminAuth(admin.UnpinChatMessage))
+
+	// Get the abuse reports of chat messages and users
+	http.HandleFunc("/api/admin/chat/reports", middleware.RequireAdminAuth(admin.GetAbuseReports))
+
+	// Mark an abuse report as open, resolved or dismissed
+	http.HandleFunc("/api/admin/chat/reports/status", middleware.RequireAdminAuth(admin.SetAbuseReportStatus))
+
+	// Set when and where moderators are alerted about reported chat messages
+	http.HandleFunc("/api/admin/chat/reports/config", middleware.RequireAdminAuth(admin.SetAbuseReportConfig))
+
+	// Get the direct messages sent and received by a user
+	http.HandleFunc("/api/admin/chat/directmessages", middleware.RequireAdminAuth(admin.GetDirectMessages))
+
+	// Get the log of moderation actions taken on chat messages
+	http.HandleFunc("/api/admin/chat/moderationlog", middleware.RequireAdminAuth(admin.GetModerationLog))
+
 	// Set chat usernames that are not allowed
 	http.HandleFunc("/api/admin/config/chat/forbiddenusernames", middleware.RequireAdminAuth(admin.SetForbiddenUsernameList))
 
@@ -213,9 +276,42 @@ func Start() error {
 	// Set video codec
 	http.HandleFunc("/api/admin/config/video/codec", middleware.RequireAdminAuth(admin.SetVideoCodec))
 
+	// Set the container format of video segments
+	http.HandleFunc("/api/admin/config/video/segmentformat", middleware.RequireAdminAuth(admin.SetSegmentFormat))
+
 	// Set style/color/css values
 	http.HandleFunc("/api/admin/config/appearance", middleware.RequireAdminAuth(admin.SetCustomColorVariableValues))
 
+	// Set the stream recording configuration
+	http.HandleFunc("/api/admin/config/recording", middleware.RequireAdminAuth(admin.SetRecordingConfiguration))
+
+	// Set the external RTMP destinations the stream is copied to
+	http.HandleFunc("/api/admin/config/restream", middleware.RequireAdminAuth(admin.SetRestreamDestinations))
+
+	// Enable or disable a single restream destination
+	http.HandleFunc("/api/admin/config/restream/enabled", middleware.RequireAdminAuth(admin.SetRestreamDestinationEnabled))
+
+	// Set the channels hosted alongside the default stream
+	http.HandleFunc("/api/admin/config/channels", middleware.RequireAdminAuth(admin.SetChannels))
+
+	// Set the chat rate limits of each role of user
+	http.HandleFunc("/api/admin/config/chat/ratelimits", middleware.RequireAdminAuth(admin.SetChatRateLimitPolicy))
+
+	// Set the chat rooms available alongside the main chat
+	http.HandleFunc("/api/admin/config/chat/rooms", middleware.RequireAdminAuth(admin.SetChatRooms))
+
+	// Return all stream recordings
+	http.HandleFunc("/api/admin/recordings", middleware.RequireAdminAuth(admin.GetRecordings))
+
+	// Download the video of a single stream recording
+	http.HandleFunc("/api/admin/recordings/download", middleware.RequireAdminAuth(admin.DownloadRecording))
+
+	// Change who is able to watch a single stream recording
+	http.HandleFunc("/api/admin/recordings/visibility", middleware.RequireAdminAuth(admin.SetRecordingVisibility))
+
+	// Delete a single stream recording
+	http.HandleFunc("/api/admin/recordings/delete", middleware.RequireAdminAuth(admin.DeleteRecording))
+
 	// Return all webhooks
 	http.HandleFunc("/api/admin/webhooks", middleware.RequireAdminAuth(admin.GetWebhooks))
 
@@ -261,6 +357,12 @@ func Start() error {
 	// Hide chat message
 	http.HandleFunc("/api/integrations/chat/messagevisibility", middleware.RequireExternalAPIAccessToken(user.ScopeHasAdminAccess, admin.ExternalUpdateMessageVisibility))
 
+	// Pin a chat message or announcement to the top of chat
+	http.HandleFunc("/api/integrations/chat/pin", middleware.RequireExternalAPIAccessToken(user.ScopeCanSendSystemMessages, admin.ExternalPinChatMessage))
+
+	// Remove the message pinned to the top of chat
+	http.HandleFunc("/api/integrations/chat/unpin", middleware.RequireExternalAPIAccessToken(user.ScopeCanSendSystemMessages, admin.ExternalUnpinChatMessage))
+
 	// Stream title
 	http.HandleFunc("/api/integrations/streamtitle", middleware.RequireExternalAPIAccessToken(user.ScopeHasAdminAccess, admin.ExternalSetStreamTitle))
 
@@ -288,6 +390,9 @@ func Start() error {
 	// Server rtmp port
 	http.HandleFunc("/api/admin/config/rtmpserverport", middleware.RequireAdminAuth(admin.SetRTMPServerPort))
 
+	// Server srt port
+	http.HandleFunc("/api/admin/config/srtserverport", middleware.RequireAdminAuth(admin.SetSRTServerPort))
+
 	// Websocket host override
 	http.HandleFunc("/api/admin/config/sockethostoverride", middleware.RequireAdminAuth(admin.SetSocketHostOverride))
 
@@ -344,6 +449,39 @@ func Start() error {
 	// Enable/disable a user
 	http.HandleFunc("/api/chat/users/setenabled", middleware.RequireUserModerationScopeAccesstoken(admin.UpdateUserEnabled))
 
+	// Set the slow, authenticated-only and emote-only chat modes
+	http.HandleFunc("/api/chat/modes", middleware.RequireUserModerationScopeAccesstoken(admin.SetChatModes))
+
+	// Get the log of moderation actions taken on chat messages
+	http.HandleFunc("/api/moderation/chat/log", middleware.RequireUserModerationScopeAccesstoken(admin.GetModerationLog))
+
+	// Search the long-term chat archive
+	http.HandleFunc("/api/moderation/chat/archive/search", middleware.RequireUserModerationScopeAccesstoken(admin.SearchChatArchive))
+
+	// Get the abuse reports of chat messages and users
+	http.HandleFunc("/api/moderation/chat/reports", middleware.RequireUserModerationScopeAccesstoken(admin.GetAbuseReports))
+
+	// Mark an abuse report as open, resolved or dismissed
+	http.HandleFunc("/api/moderation/chat/reports/status", middleware.RequireUserModerationScopeAccesstoken(admin.SetAbuseReportStatus))
+
+	// Pin a chat message or announcement to the top of chat
+	http.HandleFunc("/api/moderation/chat/pin", middleware.RequireUserModerationScopeAccesstoken(admin.PinChatMessage))
+
+	// Remove the message pinned to the top of chat
+	http.HandleFunc("/api/moderation/chat/unpin", middleware.RequireUserModerationScopeAccesstoken(admin.UnpinChatMessage))
+
+	// Get the direct messages sent and received by a user
+	http.HandleFunc("/api/moderation/chat/directmessages", middleware.RequireUserModerationScopeAccesstoken(admin.GetDirectMessages))
+
+	// Get the chat messages waiting for moderator review
+	http.HandleFunc("/api/moderation/chat/held", middleware.RequireUserModerationScopeAccesstoken(moderation.GetHeldMessages))
+
+	// Approve a held chat message
+	http.HandleFunc("/api/moderation/chat/held/approve", middleware.RequireUserModerationScopeAccesstoken(moderation.ApproveHeldMessage))
+
+	// Reject a held chat message
+	http.HandleFunc("/api/moderation/chat/held/reject", middleware.RequireUserModerationScopeAccesstoken(moderation.RejectHeldMessage))
+
 	// Get a user's details
 	http.HandleFunc("/api/moderation/chat/user/", middleware.RequireUserModerationScopeAccesstoken(moderation.GetUserDetails))
 
@@ -206,6 +206,17 @@ func RenderPageContentMarkdown(raw string) string {
 	return strings.TrimSpace(buf.String())
 }
 
+// IsHLSSegment will return if a file is a MPEG-TS or fMP4 video segment.
+func IsHLSSegment(filePath string) bool {
+	fileExtension := path.Ext(filePath)
+	return fileExtension == ".ts" || fileExtension == ".m4s"
+}
+
+// IsHLSInitSegment will return if a file is the init segment of fMP4 video segments.
+func IsHLSInitSegment(filePath string) bool {
+	return path.Ext(filePath) == ".mp4"
+}
+
 // GetCacheDurationSecondsForPath will return the number of seconds to cache an item.
 func GetCacheDurationSecondsForPath(filePath string) int {
 	filename := path.Base(filePath)
@@ -219,7 +230,7 @@ func GetCacheDurationSecondsForPath(filePath string) int {
 	} else if fileExtension == ".js" || fileExtension == ".css" {
 		// Cache javascript & CSS
 		return 60 * 60 * 24 * defaultDaysCached
-	} else if fileExtension == ".ts" || fileExtension == ".woff2" {
+	} else if IsHLSSegment(filePath) || IsHLSInitSegment(filePath) || fileExtension == ".woff2" {
 		// Cache video segments as long as you want. They can't change.
 		// This matters most for local hosting of segments for recordings
 		// and not for live or 3rd party storage.
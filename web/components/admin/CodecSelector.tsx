@@ -93,6 +93,12 @@ export const CodecSelector: FC<CodecSelectorProps> = () => {
       title = 'OpenMax (omx) for Raspberry Pi';
     } else if (title === 'h264_videotoolbox') {
       title = 'Apple VideoToolbox (hardware)';
+    } else if (title === 'libx265') {
+      title = 'HEVC (libx265)';
+    } else if (title === 'libsvtav1') {
+      title = 'AV1 (SVT-AV1)';
+    } else if (title === 'libaom-av1') {
+      title = 'AV1 (libaom)';
     }
 
     return (
@@ -123,6 +129,15 @@ export const CodecSelector: FC<CodecSelectorProps> = () => {
   } else if (selectedCodec === 'h264_videotoolbox') {
     description =
       'Apple VideoToolbox is a low-level framework that provides direct access to hardware encoders and decoders.';
+  } else if (selectedCodec === 'libx265') {
+    description =
+      'HEVC uses less bandwidth than H.264 for the same quality, but uses much more CPU to encode and is not supported by every browser. Video is always sent as fMP4 segments.';
+  } else if (selectedCodec === 'libsvtav1') {
+    description =
+      'SVT-AV1 is the fastest software AV1 encoder. AV1 uses the least bandwidth, but is only supported by newer browsers and devices. Video is always sent as fMP4 segments.';
+  } else if (selectedCodec === 'libaom-av1') {
+    description =
+      'libaom is the reference AV1 encoder and is much slower than SVT-AV1. Video is always sent as fMP4 segments.';
   }
 
   return (
@@ -19,6 +19,7 @@ import { FormStatusIndicator } from './FormStatusIndicator';
 const { Title } = Typography;
 
 const SLIDER_MARKS = {
+  '-1': 'LL-HLS',
   0: 'Lowest',
   1: ' ',
   2: ' ',
@@ -27,6 +28,7 @@ const SLIDER_MARKS = {
 };
 
 const SLIDER_COMMENTS = {
+  '-1': 'Low-Latency HLS with partial segments. Requires a player with LL-HLS support and is not available when using external storage.',
   0: 'Lowest latency, lowest error tolerance (Not recommended, may not work for all content/configurations.)',
   1: 'Low latency, low error tolerance',
   2: 'Medium latency, medium error tolerance (Default)',
@@ -120,7 +122,7 @@ export const VideoLatency: FC = () => {
         <Slider
           tipFormatter={value => SLIDER_COMMENTS[value]}
           onChange={handleChange}
-          min={0}
+          min={-1}
           max={4}
           marks={SLIDER_MARKS}
           defaultValue={selectedOption}
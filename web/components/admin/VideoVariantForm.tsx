@@ -1,6 +1,16 @@
 // This content populates the video variant modal, which is spawned from the variants table. This relies on the `dataState` prop fed in by the table.
-import React, { FC } from 'react';
-import { Popconfirm, Row, Col, Slider, Collapse, Typography, Alert, Button } from 'antd';
+import React, { FC, useContext } from 'react';
+import {
+  Popconfirm,
+  Row,
+  Col,
+  Slider,
+  Collapse,
+  Typography,
+  Alert,
+  Button,
+  Select,
+} from 'antd';
 import classNames from 'classnames';
 import dynamic from 'next/dynamic';
 import { FieldUpdaterFunc, VideoVariant, UpdateArgs } from '../../types/config-section';
@@ -18,6 +28,7 @@ import {
   FRAMERATE_TOOLTIPS,
 } from '../../utils/config-constants';
 import { ToggleSwitch } from './ToggleSwitch';
+import { ServerStatusContext } from '../../utils/server-status-context';
 
 const { Panel } = Collapse;
 
@@ -37,6 +48,8 @@ export const VideoVariantForm: FC<VideoVariantFormProps> = ({
   onUpdateField,
 }) => {
   const videoPassthroughEnabled = dataState.videoPassthrough;
+  const { serverConfig } = useContext(ServerStatusContext) || {};
+  const { supportedCodecs = [], videoCodec } = serverConfig || {};
 
   const handleFramerateChange = (value: number) => {
     onUpdateField({ fieldName: 'framerate', value });
@@ -79,6 +92,9 @@ export const VideoVariantForm: FC<VideoVariantFormProps> = ({
   const handleNameChanged = (args: UpdateArgs) => {
     onUpdateField({ fieldName: 'name', value: args.value });
   };
+  const handleCodecChanged = (value: string) => {
+    onUpdateField({ fieldName: 'codec', value });
+  };
 
   // Slider notes
   const selectedVideoBRnote = () => {
@@ -337,6 +353,30 @@ export const VideoVariantForm: FC<VideoVariantFormProps> = ({
               </a>
             </p>
           </div>
+
+          {/* VIDEO CODEC FIELD */}
+          <div className="form-module codec-module">
+            <Typography.Title level={3}>Video Codec</Typography.Title>
+            <p className="description">
+              Encode this stream output with a different codec than the rest of your stream. Codecs
+              that require their own hardware are not able to be mixed with others.
+            </p>
+            <Select
+              value={dataState.codec || ''}
+              onChange={handleCodecChanged}
+              disabled={dataState.videoPassthrough}
+              style={{ minWidth: 240 }}
+            >
+              <Select.Option key="default" value="">
+                Same as server ({videoCodec})
+              </Select.Option>
+              {supportedCodecs.map(codec => (
+                <Select.Option key={codec} value={codec}>
+                  {codec}
+                </Select.Option>
+              ))}
+            </Select>
+          </div>
         </Panel>
       </Collapse>
     </div>
@@ -1,7 +1,84 @@
-import { SocketEvent } from './socket-events';
+import { MessageType, SocketEvent } from './socket-events';
 import { User } from './user.model';
 
+export interface ChatReaction {
+  emoji: string;
+  userIds: string[];
+  count: number;
+}
+
 export interface ChatMessage extends SocketEvent {
   user: User;
   body: string;
+  replyTo?: string;
+  reactions?: ChatReaction[];
+  editedAt?: Date;
+  deletedAt?: Date;
+  editHistory?: ChatMessageEdit[];
+  roomId?: string;
+}
+
+export interface ChatRoom {
+  id: string;
+  name: string;
+  moderatorsOnly: boolean;
+  guests?: string[];
+}
+
+export interface ChatRoomJoinedEvent extends SocketEvent {
+  room: ChatRoom;
+  history: ChatMessage[];
+}
+
+export interface ChatReplayMessage {
+  message: ChatMessage;
+  // Seconds into the recorded stream the message was sent at.
+  offset: number;
+}
+
+export interface ChatMessageEdit {
+  body: string;
+  editedAt: Date;
+}
+
+export interface ChatMessageEditedEvent extends SocketEvent {
+  user: User;
+  messageId: string;
+  body: string;
+}
+
+export interface ChatMessageDeletedEvent extends SocketEvent {
+  user: User;
+  messageId: string;
+}
+
+export type AnnouncementStyle = 'INFO' | 'WARNING' | 'CELEBRATION';
+
+export interface MessagePinnedEvent extends SocketEvent {
+  // Only set when a chat message, rather than an announcement, is pinned.
+  user?: User;
+  messageId?: string;
+  body: string;
+  style?: AnnouncementStyle;
+}
+
+export interface ChatReactionEvent extends SocketEvent {
+  user: User;
+  messageId: string;
+  emoji: string;
+  removed?: boolean;
+  reactions: ChatReaction[];
+}
+
+export interface DirectMessage extends SocketEvent {
+  user: User;
+  body: string;
+  recipientId: string;
+}
+
+export interface ChatReportEvent {
+  type: MessageType.REPORT;
+  messageId?: string;
+  userId?: string;
+  reason: string;
 }
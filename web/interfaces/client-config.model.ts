@@ -9,6 +9,8 @@ export interface ClientConfig {
   extraPageContent: string;
   socialHandles: SocialHandle[];
   chatDisabled: boolean;
+  chatModes?: ChatModes;
+  chatMessageEditWindow?: number;
   externalActions: any[];
   customStyles: string;
   appearanceVariables: Map<string, string>;
@@ -19,6 +21,12 @@ export interface ClientConfig {
   socketHostOverride?: string;
 }
 
+export interface ChatModes {
+  slowModeSeconds: number;
+  authenticatedOnly: boolean;
+  emoteOnly: boolean;
+}
+
 interface Authentication {
   indieAuthEnabled: boolean;
 }
@@ -2,6 +2,17 @@ import { User } from './user.model';
 
 export enum MessageType {
   CHAT = 'CHAT',
+  CHAT_REACTION = 'CHAT_REACTION',
+  MESSAGE_EDITED = 'MESSAGE_EDITED',
+  MESSAGE_DELETED = 'MESSAGE_DELETED',
+  DIRECT_MESSAGE = 'DIRECT_MESSAGE',
+  REPORT = 'REPORT',
+  JOIN_ROOM = 'JOIN_ROOM',
+  LEAVE_ROOM = 'LEAVE_ROOM',
+  ROOM_JOINED = 'ROOM_JOINED',
+  ROOM_LEFT = 'ROOM_LEFT',
+  MESSAGE_PINNED = 'MESSAGE_PINNED',
+  MESSAGE_UNPINNED = 'MESSAGE_UNPINNED',
   PING = 'PING',
   NAME_CHANGE = 'NAME_CHANGE',
   COLOR_CHANGE = 'COLOR_CHANGE',
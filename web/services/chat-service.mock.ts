@@ -1,4 +1,4 @@
-import { ChatMessage } from '../interfaces/chat-message.model';
+import { ChatMessage, ChatReplayMessage } from '../interfaces/chat-message.model';
 import { ChatStaticService, UserRegistrationResponse } from './chat-service';
 
 export const chatServiceMockOf = (
@@ -13,6 +13,10 @@ export const chatServiceMockOf = (
     public static async registerUser(): Promise<UserRegistrationResponse> {
       return userRegistrationResponse;
     }
+
+    public static async getVODChat(): Promise<ChatReplayMessage[]> {
+      return [];
+    }
   };
 
 export default chatServiceMockOf;
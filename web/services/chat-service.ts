@@ -1,5 +1,5 @@
 import { createContext } from 'react';
-import { ChatMessage } from '../interfaces/chat-message.model';
+import { ChatMessage, ChatReplayMessage } from '../interfaces/chat-message.model';
 import { getUnauthedData } from '../utils/apis';
 
 const ENDPOINT = `/api/chat`;
@@ -15,6 +15,7 @@ export interface UserRegistrationResponse {
 export interface ChatStaticService {
   getChatHistory(accessToken: string): Promise<ChatMessage[]>;
   registerUser(username: string): Promise<UserRegistrationResponse>;
+  getVODChat(id: string, from: number, to: number): Promise<ChatReplayMessage[]>;
 }
 
 class ChatService {
@@ -27,6 +28,17 @@ class ChatService {
     }
   }
 
+  public static async getVODChat(id: string, from: number, to: number): Promise<ChatReplayMessage[]> {
+    try {
+      const response = await getUnauthedData(
+        `/api/vods/${encodeURIComponent(id)}/chat?from=${from}&to=${to}`,
+      );
+      return response;
+    } catch (e) {
+      return [];
+    }
+  }
+
   public static async registerUser(username: string): Promise<UserRegistrationResponse> {
     const options = {
       method: 'POST',
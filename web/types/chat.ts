@@ -14,6 +14,7 @@ export interface User {
   displayName: string;
   createdAt: Date;
   disabledAt: Date;
+  disabledUntil?: Date;
   previousNames: [string];
   nameChangedAt: Date;
   scopes?: [string];
@@ -66,6 +66,7 @@ export interface VideoVariant {
   scaledHeight: number;
 
   name: string;
+  codec?: string;
 }
 export interface VideoSettingsFields {
   latencyLevel: number;
@@ -131,6 +132,7 @@ export interface Health {
 export interface StreamKey {
   key: string;
   comment: string;
+  channel?: string;
 }
 
 export interface ConfigDetails {